@@ -10,11 +10,6 @@ import (
 
 const APP_EVENT_TIMESTAMP_FORMAT = "2006-01-02T15:04:05-07:00"
 
-type PaginatedEventResources struct {
-	Resources []EventResource
-	NextURL   string `json:"next_url"`
-}
-
 type EventResource struct {
 	Resource
 	Entity EventEntity
@@ -45,25 +40,17 @@ func NewCloudControllerAppEventsRepository(config *configuration.Configuration,
 func (repo CloudControllerAppEventsRepository) ListEvents(app cf.Application) (events []cf.Event, apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("/v2/apps/%s/events", app.Guid)
 
-	for path != "" {
-		url := fmt.Sprintf("%s%s", repo.config.Target, path)
-		eventResources := &PaginatedEventResources{}
-		apiResponse = repo.gateway.GetResource(url, repo.config.AccessToken, eventResources)
-		if apiResponse.IsNotSuccessful() {
-			return
-		}
-
-		for _, resource := range eventResources.Resources {
+	apiResponse = repo.gateway.ListPaginatedResources(repo.config.Target, repo.config.AccessToken, path, EventResource{},
+		func(resource interface{}) bool {
+			r := resource.(EventResource)
 			events = append(events, cf.Event{
-				Timestamp:       resource.Entity.Timestamp,
-				ExitDescription: resource.Entity.ExitDescription,
-				ExitStatus:      resource.Entity.ExitStatus,
-				InstanceIndex:   resource.Entity.InstanceIndex,
+				Timestamp:       r.Entity.Timestamp,
+				ExitDescription: r.Entity.ExitDescription,
+				ExitStatus:      r.Entity.ExitStatus,
+				InstanceIndex:   r.Entity.InstanceIndex,
 			})
-		}
-
-		path = eventResources.NextURL
-	}
+			return true
+		})
 
 	return
 }
@@ -80,7 +80,9 @@ func TestListEvents(t *testing.T) {
 		Target:      listEventsServer.URL,
 		AccessToken: "BEARER my_access_token",
 	}
-	repo := NewCloudControllerAppEventsRepository(config, net.NewCloudControllerGateway())
+	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(listEventsServer.TLS.Certificates)
+	repo := NewCloudControllerAppEventsRepository(config, gateway)
 
 	list, apiErr := repo.ListEvents(cf.Application{Guid: "my-app-guid"})
 
@@ -55,6 +55,7 @@ func TestListFiles(t *testing.T) {
 	}
 
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(append(listFilesRedirectServer.TLS.Certificates, listFilesServer.TLS.Certificates...))
 	repo := NewCloudControllerAppFilesRepository(config, gateway)
 
 	list, err := repo.ListFiles(cf.Application{Guid: "my-app-guid"}, "some/path")
@@ -38,6 +38,7 @@ type DomainSummary struct {
 
 type AppSummaryRepository interface {
 	GetSummariesInCurrentSpace() (apps []cf.Application, apiResponse net.ApiResponse)
+	GetSummariesInSpace(space cf.Space) (apps []cf.Application, apiResponse net.ApiResponse)
 	GetSummary(app cf.Application) (summary cf.AppSummary, apiResponse net.ApiResponse)
 }
 
@@ -55,7 +56,11 @@ func NewCloudControllerAppSummaryRepository(config *configuration.Configuration,
 }
 
 func (repo CloudControllerAppSummaryRepository) GetSummariesInCurrentSpace() (apps []cf.Application, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/spaces/%s/summary", repo.config.Target, repo.config.Space.Guid)
+	return repo.GetSummariesInSpace(repo.config.Space)
+}
+
+func (repo CloudControllerAppSummaryRepository) GetSummariesInSpace(space cf.Space) (apps []cf.Application, apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/spaces/%s/summary", repo.config.Target, space.Guid)
 	resource := new(ApplicationSummaries)
 	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resource)
 	if apiResponse.IsNotSuccessful() {
@@ -216,6 +216,7 @@ func createAppSummaryRepo(t *testing.T, requests []testnet.TestRequest) (ts *htt
 	}
 
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	appRepo := NewCloudControllerApplicationRepository(config, gateway)
 	repo = NewCloudControllerAppSummaryRepository(config, gateway, appRepo)
 	return
@@ -9,10 +9,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"mime/multipart"
-	"net/textproto"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -24,7 +23,7 @@ type AppFileResource struct {
 }
 
 type ApplicationBitsRepository interface {
-	UploadApp(app cf.Application, dir string) (apiResponse net.ApiResponse)
+	UploadApp(app cf.Application, dir string, onProgress func(sent, total int64, elapsed time.Duration)) (apiResponse net.ApiResponse)
 }
 
 type CloudControllerApplicationBitsRepository struct {
@@ -40,7 +39,7 @@ func NewCloudControllerApplicationBitsRepository(config *configuration.Configura
 	return
 }
 
-func (repo CloudControllerApplicationBitsRepository) UploadApp(app cf.Application, dir string) (apiResponse net.ApiResponse) {
+func (repo CloudControllerApplicationBitsRepository) UploadApp(app cf.Application, dir string, onProgress func(sent, total int64, elapsed time.Duration)) (apiResponse net.ApiResponse) {
 	dir, resourcesJson, apiResponse := repo.createUploadDir(app, dir)
 	if apiResponse.IsNotSuccessful() {
 		return
@@ -53,7 +52,7 @@ func (repo CloudControllerApplicationBitsRepository) UploadApp(app cf.Applicatio
 	}
 	defer zipFile.Close()
 
-	apiResponse = repo.uploadBits(app, zipFile, resourcesJson)
+	apiResponse = repo.uploadBits(app, zipFile, resourcesJson, onProgress)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -61,35 +60,61 @@ func (repo CloudControllerApplicationBitsRepository) UploadApp(app cf.Applicatio
 	return
 }
 
-func (repo CloudControllerApplicationBitsRepository) uploadBits(app cf.Application, zipFile *os.File, resourcesJson []byte) (apiResponse net.ApiResponse) {
+const (
+	maxUploadAttempts    = 3
+	defaultUploadTimeout = 15 * 60 // seconds
+)
+
+var uploadRetryDelay = 5 * time.Second
+
+func (repo CloudControllerApplicationBitsRepository) uploadBits(app cf.Application, zipFile *os.File, resourcesJson []byte, onProgress func(sent, total int64, elapsed time.Duration)) (apiResponse net.ApiResponse) {
 	url := fmt.Sprintf("%s/v2/apps/%s/bits?async=true", repo.config.Target, app.Guid)
 
-	body, boundary, err := createApplicationUploadBody(zipFile, resourcesJson)
+	body, err := newUploadBody(zipFile, resourcesJson, onProgress)
 	if err != nil {
 		apiResponse = net.NewApiResponseWithError("Error creating upload", err)
 		return
 	}
 
-	request, apiResponse := repo.gateway.NewRequest("PUT", url, repo.config.AccessToken, body)
+	response := &Resource{}
+	for attempt := 1; ; attempt++ {
+		apiResponse = repo.putBits(url, body, response)
+		if apiResponse.IsSuccessful() || attempt == maxUploadAttempts || !isTransientUploadFailure(apiResponse) {
+			break
+		}
+		time.Sleep(uploadRetryDelay * time.Duration(attempt))
+	}
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
 
-	contentType := fmt.Sprintf("multipart/form-data; boundary=%s", boundary)
-	request.HttpReq.Header.Set("Content-Type", contentType)
+	jobGuid := response.Metadata.Guid
+	apiResponse = repo.pollUploadProgress(jobGuid)
 
-	response := &Resource{}
-	_, apiResponse = repo.gateway.PerformRequestForJSONResponse(request, response)
+	return
+}
+
+func (repo CloudControllerApplicationBitsRepository) putBits(url string, body *uploadBody, response *Resource) (apiResponse net.ApiResponse) {
+	request, apiResponse := repo.gateway.NewRequest("PUT", url, repo.config.AccessToken, body)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
 
-	jobGuid := response.Metadata.Guid
-	apiResponse = repo.pollUploadProgress(jobGuid)
+	request.HttpReq.ContentLength = body.Len()
+	request.HttpReq.Header.Set("Content-Type", body.ContentType())
+	request.NoRetries = true
+	request.NoResponseTimeout = true
 
+	_, apiResponse = repo.gateway.PerformRequestForJSONResponse(request, response)
 	return
 }
 
+// uploads are only sent again when the connection failed or the server had a problem,
+// never when the request itself was rejected
+func isTransientUploadFailure(apiResponse net.ApiResponse) bool {
+	return apiResponse.IsError() && (apiResponse.StatusCode == 0 || apiResponse.StatusCode >= 500)
+}
+
 const (
 	uploadStatusFinished = "finished"
 	uploadStatusFailed   = "failed"
@@ -105,15 +130,38 @@ type UploadProgressResponse struct {
 }
 
 func (repo CloudControllerApplicationBitsRepository) pollUploadProgress(jobGuid string) (apiResponse net.ApiResponse) {
-	finished := false
-	for !finished {
+	timeout := repo.uploadTimeout()
+	startedAt := time.Now()
+
+	for {
+		var finished bool
 		finished, apiResponse = repo.uploadProgress(jobGuid)
-		if apiResponse.IsNotSuccessful() {
+		if finished || apiResponse.IsNotSuccessful() {
 			return
 		}
+
+		if time.Since(startedAt) > timeout {
+			apiResponse = net.NewApiResponseWithMessage("Timed out after %s waiting for the upload to be processed. Set CF_UPLOAD_TIMEOUT to wait longer.", timeout)
+			return
+		}
+
 		time.Sleep(time.Second)
 	}
-	return
+}
+
+// CF_UPLOAD_TIMEOUT, in seconds, wins over the timeout in the config file
+func (repo CloudControllerApplicationBitsRepository) uploadTimeout() time.Duration {
+	timeout := repo.config.ApplicationUploadTimeout
+
+	seconds, err := strconv.Atoi(os.Getenv("CF_UPLOAD_TIMEOUT"))
+	if err == nil {
+		timeout = time.Duration(seconds)
+	}
+
+	if timeout <= 0 {
+		timeout = defaultUploadTimeout
+	}
+	return timeout * time.Second
 }
 
 func (repo CloudControllerApplicationBitsRepository) uploadProgress(jobGuid string) (finished bool, apiResponse net.ApiResponse) {
@@ -132,6 +180,16 @@ func (repo CloudControllerApplicationBitsRepository) uploadProgress(jobGuid stri
 	return
 }
 
+// fingerprintCacheFile lives beside the config file, which only the user can read and write.
+func fingerprintCacheFile() (file string) {
+	configFile, err := configuration.ConfigFile()
+	if err != nil {
+		return
+	}
+	file = filepath.Join(filepath.Dir(configFile), "fingerprints.json")
+	return
+}
+
 func (repo CloudControllerApplicationBitsRepository) createUploadDir(app cf.Application, appDir string) (uploadDir string, resourcesJson []byte, apiResponse net.ApiResponse) {
 	var err error
 
@@ -145,7 +203,7 @@ func (repo CloudControllerApplicationBitsRepository) createUploadDir(app cf.Appl
 	}
 
 	// Find which files need to be uploaded
-	allAppFiles, err := cf.AppFilesInDir(appDir)
+	allAppFiles, err := cf.AppFilesInDir(appDir, fingerprintCacheFile())
 	if err != nil {
 		apiResponse = net.NewApiResponseWithError("Error listing app files", err)
 		return
@@ -242,6 +300,9 @@ func extractZip(app cf.Application, zipFile string) (destDir string, err error)
 	return
 }
 
+// resource_match requests are split so that huge apps do not send one enormous body
+var resourceMatchBatchSize = 1000
+
 func (repo CloudControllerApplicationBitsRepository) getFilesToUpload(allAppFiles []cf.AppFile) (appFilesToUpload []cf.AppFile, resourcesJson []byte, apiResponse net.ApiResponse) {
 	appFilesRequest := []AppFileResource{}
 	for _, file := range allAppFiles {
@@ -258,90 +319,47 @@ func (repo CloudControllerApplicationBitsRepository) getFilesToUpload(allAppFile
 		return
 	}
 
-	path := fmt.Sprintf("%s/v2/resource_match", repo.config.Target)
-	req, apiResponse := repo.gateway.NewRequest("PUT", path, repo.config.AccessToken, bytes.NewReader(resourcesJson))
-	if apiResponse.IsNotSuccessful() {
-		return
-	}
-
-	res := []AppFileResource{}
-	_, apiResponse = repo.gateway.PerformRequestForJSONResponse(req, &res)
-
-	appFilesToUpload = make([]cf.AppFile, len(allAppFiles))
-	copy(appFilesToUpload, allAppFiles)
-	for _, file := range res {
-		appFile := cf.AppFile{
-			Path: file.Path,
-			Sha1: file.Sha1,
-			Size: file.Size,
+	matchedPaths := map[string]bool{}
+	for start := 0; start < len(appFilesRequest); start += resourceMatchBatchSize {
+		end := start + resourceMatchBatchSize
+		if end > len(appFilesRequest) {
+			end = len(appFilesRequest)
 		}
-		appFilesToUpload = deleteAppFile(appFilesToUpload, appFile)
-	}
 
-	return
-}
-
-func deleteAppFile(appFiles []cf.AppFile, targetFile cf.AppFile) []cf.AppFile {
-	for i, file := range appFiles {
-		if file.Path == targetFile.Path {
-			appFiles[i] = appFiles[len(appFiles)-1]
-			return appFiles[:len(appFiles)-1]
+		var matched []AppFileResource
+		matched, apiResponse = repo.matchResources(appFilesRequest[start:end])
+		if apiResponse.IsNotSuccessful() {
+			return
 		}
-	}
-	return appFiles
-}
 
-func createApplicationUploadBody(zipFile *os.File, resourcesJson []byte) (body *os.File, boundary string, err error) {
-	tempFile, err := cf.TempFileForRequestBody()
-	if err != nil {
-		return
-	}
-	body, err = os.Create(tempFile)
-	if err != nil {
-		return
+		for _, file := range matched {
+			matchedPaths[file.Path] = true
+		}
 	}
 
-	writer := multipart.NewWriter(body)
-	defer writer.Close()
-
-	boundary = writer.Boundary()
-
-	part, err := writer.CreateFormField("resources")
-	if err != nil {
-		return
+	for _, file := range allAppFiles {
+		if !matchedPaths[file.Path] {
+			appFilesToUpload = append(appFilesToUpload, file)
+		}
 	}
 
-	_, err = io.Copy(part, bytes.NewBuffer(resourcesJson))
-	if err != nil {
-		return
-	}
+	return
+}
 
-	zipStats, err := zipFile.Stat()
+func (repo CloudControllerApplicationBitsRepository) matchResources(resources []AppFileResource) (matched []AppFileResource, apiResponse net.ApiResponse) {
+	resourcesJson, err := json.Marshal(resources)
 	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Failed to create json for resource_match request", err)
 		return
 	}
 
-	if zipStats.Size() == 0 {
-		return
-	}
-
-	part, err = createZipPartWriter(zipStats, writer)
-	if err != nil {
+	path := fmt.Sprintf("%s/v2/resource_match", repo.config.Target)
+	req, apiResponse := repo.gateway.NewRequest("PUT", path, repo.config.AccessToken, bytes.NewReader(resourcesJson))
+	if apiResponse.IsNotSuccessful() {
 		return
 	}
 
-	_, err = io.Copy(part, zipFile)
-	if err != nil {
-		return
-	}
+	matched = []AppFileResource{}
+	_, apiResponse = repo.gateway.PerformRequestForJSONResponse(req, &matched)
 	return
 }
-
-func createZipPartWriter(zipStats os.FileInfo, writer *multipart.Writer) (io.Writer, error) {
-	h := make(textproto.MIMEHeader)
-	h.Set("Content-Disposition", `form-data; name="application"; filename="application.zip"`)
-	h.Set("Content-Type", "application/zip")
-	h.Set("Content-Length", fmt.Sprintf("%d", zipStats.Size()))
-	h.Set("Content-Transfer-Encoding", "binary")
-	return writer.CreatePart(h)
-}
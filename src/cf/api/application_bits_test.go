@@ -16,6 +16,7 @@ import (
 	testcf "testhelpers/cf"
 	testnet "testhelpers/net"
 	"testing"
+	"time"
 )
 
 var expectedResources = testnet.RemoveWhiteSpaceFromBody(`[
@@ -156,7 +157,7 @@ func TestUploadWithInvalidDirectory(t *testing.T) {
 	repo := NewCloudControllerApplicationBitsRepository(config, gateway, zipper)
 	app := cf.Application{}
 
-	apiResponse := repo.UploadApp(app, "/foo/bar")
+	apiResponse := repo.UploadApp(app, "/foo/bar", nil)
 	assert.True(t, apiResponse.IsNotSuccessful())
 	assert.Contains(t, apiResponse.Message, "Error listing app files")
 }
@@ -196,7 +197,161 @@ func TestUploadAppFailsWhilePushingBits(t *testing.T) {
 	assert.False(t, apiResponse.IsSuccessful())
 }
 
+func TestUploadAppBatchesResourceMatchRequests(t *testing.T) {
+	dir, err := os.Getwd()
+	assert.NoError(t, err)
+	dir = filepath.Join(dir, "../../fixtures/example-app")
+
+	resourceMatchBatchSize = 3
+	defer func() { resourceMatchBatchSize = 1000 }()
+
+	firstBatchRequest := testnet.TestRequest{
+		Method: "PUT",
+		Path:   "/v2/resource_match",
+		Matcher: testnet.RequestBodyMatcher(testnet.RemoveWhiteSpaceFromBody(`[
+			{"fn": "Gemfile", "sha1": "d9c3a51de5c89c11331d3b90b972789f1a14699a", "size": 59},
+			{"fn": "Gemfile.lock", "sha1": "345f999aef9070fb9a608e65cf221b7038156b6d", "size": 229},
+			{"fn": "app.rb", "sha1": "2474735f5163ba7612ef641f438f4b5bee00127b", "size": 51}
+		]`)),
+		Response: testnet.TestResponse{
+			Status: http.StatusOK,
+			Body:   `[{"fn": "app.rb", "sha1": "2474735f5163ba7612ef641f438f4b5bee00127b", "size": 51}]`,
+		},
+	}
+
+	secondBatchRequest := testnet.TestRequest{
+		Method: "PUT",
+		Path:   "/v2/resource_match",
+		Matcher: testnet.RequestBodyMatcher(testnet.RemoveWhiteSpaceFromBody(`[
+			{"fn": "config.ru", "sha1": "f097424ce1fa66c6cb9f5e8a18c317376ec12e05", "size": 70},
+			{"fn": "manifest.yml", "sha1": "19b5b4225dc64da3213b1ffaa1e1920ee5faf36c", "size": 111}
+		]`)),
+		Response: testnet.TestResponse{
+			Status: http.StatusOK,
+			Body:   `[{"fn": "config.ru", "sha1": "f097424ce1fa66c6cb9f5e8a18c317376ec12e05", "size": 70}]`,
+		},
+	}
+
+	requests := []testnet.TestRequest{
+		firstBatchRequest,
+		secondBatchRequest,
+		uploadApplicationRequest,
+		createProgressEndpoint("finished"),
+	}
+
+	app, apiResponse := testUploadApp(t, dir, requests)
+	assert.True(t, apiResponse.IsSuccessful())
+	testUploadDir(t, app)
+}
+
+func TestUploadAppReportsProgress(t *testing.T) {
+	dir, err := os.Getwd()
+	assert.NoError(t, err)
+	dir = filepath.Join(dir, "../../fixtures/example-app")
+
+	_, apiResponse, progressReports := testUploadAppWithProgress(t, dir, defaultRequests)
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.NotEmpty(t, progressReports)
+
+	lastReport := progressReports[len(progressReports)-1]
+	assert.Equal(t, lastReport[0], lastReport[1])
+}
+
+func TestUploadAppRetriesTransientFailures(t *testing.T) {
+	dir, err := os.Getwd()
+	assert.NoError(t, err)
+	dir = filepath.Join(dir, "../../fixtures/example-app")
+
+	uploadRetryDelay = 0
+	defer func() { uploadRetryDelay = 5 * time.Second }()
+
+	failedUploadRequest := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "PUT",
+		Path:     "/v2/apps/my-cool-app-guid/bits",
+		Matcher:  uploadBodyMatcher,
+		Response: testnet.TestResponse{Status: http.StatusBadGateway},
+	})
+
+	requests := []testnet.TestRequest{
+		matchResourceRequest,
+		failedUploadRequest,
+		uploadApplicationRequest,
+		createProgressEndpoint("finished"),
+	}
+
+	_, apiResponse := testUploadApp(t, dir, requests)
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
+func TestUploadAppGivesUpAfterMaxUploadAttempts(t *testing.T) {
+	dir, err := os.Getwd()
+	assert.NoError(t, err)
+	dir = filepath.Join(dir, "../../fixtures/example-app")
+
+	uploadRetryDelay = 0
+	defer func() { uploadRetryDelay = 5 * time.Second }()
+
+	failedUploadRequest := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "PUT",
+		Path:     "/v2/apps/my-cool-app-guid/bits",
+		Response: testnet.TestResponse{Status: http.StatusBadGateway},
+	})
+
+	requests := []testnet.TestRequest{matchResourceRequest}
+	for i := 0; i < maxUploadAttempts; i++ {
+		requests = append(requests, failedUploadRequest)
+	}
+
+	_, apiResponse := testUploadApp(t, dir, requests)
+	assert.False(t, apiResponse.IsSuccessful())
+}
+
+func TestUploadAppDoesNotRetryRejectedUploads(t *testing.T) {
+	dir, err := os.Getwd()
+	assert.NoError(t, err)
+	dir = filepath.Join(dir, "../../fixtures/example-app")
+
+	rejectedUploadRequest := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "PUT",
+		Path:     "/v2/apps/my-cool-app-guid/bits",
+		Response: testnet.TestResponse{Status: http.StatusBadRequest},
+	})
+
+	requests := []testnet.TestRequest{
+		matchResourceRequest,
+		rejectedUploadRequest,
+	}
+
+	_, apiResponse := testUploadApp(t, dir, requests)
+	assert.False(t, apiResponse.IsSuccessful())
+}
+
+func TestUploadAppTimesOutWaitingForTheJob(t *testing.T) {
+	dir, err := os.Getwd()
+	assert.NoError(t, err)
+	dir = filepath.Join(dir, "../../fixtures/example-app")
+
+	os.Setenv("CF_UPLOAD_TIMEOUT", "1")
+	defer os.Setenv("CF_UPLOAD_TIMEOUT", "")
+
+	requests := []testnet.TestRequest{
+		matchResourceRequest,
+		uploadApplicationRequest,
+		createProgressEndpoint("running"),
+		createProgressEndpoint("running"),
+	}
+
+	_, apiResponse := testUploadApp(t, dir, requests)
+	assert.False(t, apiResponse.IsSuccessful())
+	assert.Contains(t, apiResponse.Message, "Timed out")
+}
+
 func testUploadApp(t *testing.T, dir string, requests []testnet.TestRequest) (app cf.Application, apiResponse net.ApiResponse) {
+	app, apiResponse, _ = testUploadAppWithProgress(t, dir, requests)
+	return
+}
+
+func testUploadAppWithProgress(t *testing.T, dir string, requests []testnet.TestRequest) (app cf.Application, apiResponse net.ApiResponse, progressReports [][]int64) {
 	ts, handler := testnet.NewTLSServer(t, requests)
 	defer ts.Close()
 
@@ -205,6 +360,7 @@ func testUploadApp(t *testing.T, dir string, requests []testnet.TestRequest) (ap
 		Target:      ts.URL,
 	}
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	file, err := os.Open("../../fixtures/hello_world.txt")
 	assert.NoError(t, err)
 	zipper := &testcf.FakeZipper{ZippedFile: file}
@@ -212,7 +368,9 @@ func testUploadApp(t *testing.T, dir string, requests []testnet.TestRequest) (ap
 
 	app = cf.Application{Name: "my-cool-app", Guid: "my-cool-app-guid"}
 
-	apiResponse = repo.UploadApp(app, dir)
+	apiResponse = repo.UploadApp(app, dir, func(sent, total int64, elapsed time.Duration) {
+		progressReports = append(progressReports, []int64{sent, total})
+	})
 
 	assert.True(t, handler.AllRequestsCalled())
 	return
@@ -30,6 +30,9 @@ type ApplicationEntity struct {
 	Memory          int
 	Routes          []AppRouteResource
 	EnvironmentJson map[string]string `json:"environment_json"`
+	Command         string
+	Buildpack       string
+	Stack           StackResource
 }
 
 type AppRouteResource struct {
@@ -91,6 +94,12 @@ func (repo CloudControllerApplicationRepository) appFromResource(res Application
 		State:           strings.ToLower(res.Entity.State),
 		Instances:       res.Entity.Instances,
 		Memory:          uint64(res.Entity.Memory),
+		Command:         res.Entity.Command,
+		BuildpackUrl:    res.Entity.Buildpack,
+		Stack: cf.Stack{
+			Guid: res.Entity.Stack.Metadata.Guid,
+			Name: res.Entity.Stack.Entity.Name,
+		},
 	}
 	for _, routeResource := range res.Entity.Routes {
 		domainResource := routeResource.Entity.Domain
@@ -30,6 +30,16 @@ var singleAppResponse = testnet.TestResponse{
         "memory": 128,
         "instances": 1,
         "state": "STOPPED",
+        "command": "bundle exec rackup",
+        "buildpack": "http://example.com/buildpack",
+        "stack": {
+          "metadata": {
+            "guid": "stack1-guid"
+          },
+          "entity": {
+            "name": "lucid64"
+          }
+        },
         "routes": [
       	  {
       	    "metadata": {
@@ -73,6 +83,9 @@ func TestFindByName(t *testing.T) {
 	assert.Equal(t, app.EnvironmentVars, map[string]string{"foo": "bar", "baz": "boom"})
 	assert.Equal(t, app.Routes[0].Host, "app1")
 	assert.Equal(t, app.Routes[0].Domain.Name, "cfapps.io")
+	assert.Equal(t, app.Command, "bundle exec rackup")
+	assert.Equal(t, app.BuildpackUrl, "http://example.com/buildpack")
+	assert.Equal(t, app.Stack, cf.Stack{Guid: "stack1-guid", Name: "lucid64"})
 }
 
 func TestFindByNameWhenAppIsNotFound(t *testing.T) {
@@ -383,6 +396,7 @@ func createAppRepo(t *testing.T, requests []testnet.TestRequest) (ts *httptest.S
 		Space:       cf.Space{Name: "my-space", Guid: "my-space-guid"},
 	}
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	repo = NewCloudControllerApplicationRepository(config, gateway)
 	return
 }
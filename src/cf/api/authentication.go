@@ -13,6 +13,8 @@ import (
 
 type AuthenticationRepository interface {
 	Authenticate(email string, password string) (apiResponse net.ApiResponse)
+	AuthenticateWithPasscode(passcode string) (apiResponse net.ApiResponse)
+	AuthenticateWithClientCredentials(clientId, clientSecret string) (apiResponse net.ApiResponse)
 	RefreshAuthToken() (updatedToken string, apiResponse net.ApiResponse)
 }
 
@@ -37,32 +39,88 @@ func (uaa UAAAuthenticationRepository) Authenticate(email string, password strin
 		"scope":      {""},
 	}
 
-	apiResponse = uaa.getAuthToken(data)
+	clientId, clientSecret := uaa.oauthClient()
+	apiResponse = uaa.getAuthToken(data, clientId, clientSecret)
 	if apiResponse.IsNotSuccessful() && apiResponse.StatusCode == 401 {
 		apiResponse.Message = "Password is incorrect, please try again."
 	}
 	return
 }
 
-func (uaa UAAAuthenticationRepository) RefreshAuthToken() (updatedToken string, apiResponse net.ApiResponse) {
+// AuthenticateWithPasscode logs in with a one time passcode from the login
+// server's /passcode page, which is how SSO users get a token.
+func (uaa UAAAuthenticationRepository) AuthenticateWithPasscode(passcode string) (apiResponse net.ApiResponse) {
+	data := url.Values{
+		"passcode":   {passcode},
+		"grant_type": {"password"},
+		"scope":      {""},
+	}
+
+	clientId, clientSecret := uaa.oauthClient()
+	apiResponse = uaa.getAuthToken(data, clientId, clientSecret)
+	if apiResponse.IsNotSuccessful() && apiResponse.StatusCode == 401 {
+		apiResponse.Message = "Passcode is incorrect or has expired, please get a new one and try again."
+	}
+	return
+}
+
+func (uaa UAAAuthenticationRepository) AuthenticateWithClientCredentials(clientId, clientSecret string) (apiResponse net.ApiResponse) {
 	data := url.Values{
-		"refresh_token": {uaa.config.RefreshToken},
-		"grant_type":    {"refresh_token"},
-		"scope":         {""},
+		"grant_type": {"client_credentials"},
+		"scope":      {""},
+	}
+
+	apiResponse = uaa.getAuthToken(data, clientId, clientSecret)
+	if apiResponse.IsNotSuccessful() && apiResponse.StatusCode == 401 {
+		apiResponse.Message = "Client credentials are incorrect, please try again."
+	}
+	return
+}
+
+// RefreshAuthToken gets a new access token with the refresh token. Client
+// credentials tokens come without one, so the client authenticates again.
+func (uaa UAAAuthenticationRepository) RefreshAuthToken() (updatedToken string, apiResponse net.ApiResponse) {
+	if uaa.config.UAAGrantType == "client_credentials" {
+		apiResponse = uaa.AuthenticateWithClientCredentials(uaa.config.ClientCredentialsId, uaa.config.ClientCredentialsSecret)
+	} else {
+		data := url.Values{
+			"refresh_token": {uaa.config.RefreshToken},
+			"grant_type":    {"refresh_token"},
+			"scope":         {""},
+		}
+
+		clientId, clientSecret := uaa.oauthClient()
+		apiResponse = uaa.getAuthToken(data, clientId, clientSecret)
+	}
+	if apiResponse.IsNotSuccessful() {
+		apiResponse.Message = fmt.Sprintf("Error refreshing auth token: %s\n%s", apiResponse.Message, terminal.NotLoggedInText())
+		return
 	}
 
-	apiResponse = uaa.getAuthToken(data)
 	updatedToken = uaa.config.AccessToken
+	return
+}
+
+// oauthClient picks the UAA client that users log in through: CF_CLIENT_ID and
+// CF_CLIENT_SECRET, then the client in the config file, then the cf client.
+func (uaa UAAAuthenticationRepository) oauthClient() (clientId, clientSecret string) {
+	clientId = os.Getenv("CF_CLIENT_ID")
+	if clientId != "" {
+		clientSecret = os.Getenv("CF_CLIENT_SECRET")
+		return
+	}
 
-	if apiResponse.IsError() {
-		fmt.Printf("%s\n\n", terminal.NotLoggedInText())
-		os.Exit(1)
+	if uaa.config.UAAOAuthClient != "" {
+		clientId = uaa.config.UAAOAuthClient
+		clientSecret = uaa.config.UAAOAuthClientSecret
+		return
 	}
 
+	clientId = "cf"
 	return
 }
 
-func (uaa UAAAuthenticationRepository) getAuthToken(data url.Values) (apiResponse net.ApiResponse) {
+func (uaa UAAAuthenticationRepository) getAuthToken(data url.Values, clientId, clientSecret string) (apiResponse net.ApiResponse) {
 	type uaaErrorResponse struct {
 		Code        string `json:"error"`
 		Description string `json:"error_description"`
@@ -76,7 +134,7 @@ func (uaa UAAAuthenticationRepository) getAuthToken(data url.Values) (apiRespons
 	}
 
 	path := fmt.Sprintf("%s/oauth/token", uaa.config.AuthorizationEndpoint)
-	request, apiResponse := uaa.gateway.NewRequest("POST", path, "Basic "+base64.StdEncoding.EncodeToString([]byte("cf:")), strings.NewReader(data.Encode()))
+	request, apiResponse := uaa.gateway.NewRequest("POST", path, "Basic "+base64.StdEncoding.EncodeToString([]byte(clientId+":"+clientSecret)), strings.NewReader(data.Encode()))
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -96,6 +154,20 @@ func (uaa UAAAuthenticationRepository) getAuthToken(data url.Values) (apiRespons
 
 	uaa.config.AccessToken = fmt.Sprintf("%s %s", response.TokenType, response.AccessToken)
 	uaa.config.RefreshToken = response.RefreshToken
+
+	switch grantType := data.Get("grant_type"); grantType {
+	case "refresh_token":
+	case "client_credentials":
+		uaa.config.UAAGrantType = grantType
+		uaa.config.ClientCredentialsId = clientId
+		uaa.config.ClientCredentialsSecret = clientSecret
+	default:
+		// a user logging in replaces any service account session
+		uaa.config.UAAGrantType = grantType
+		uaa.config.ClientCredentialsId = ""
+		uaa.config.ClientCredentialsSecret = ""
+	}
+
 	err := uaa.configRepo.Save()
 	if err != nil {
 		apiResponse = net.NewApiResponseWithError("Error setting configuration", err)
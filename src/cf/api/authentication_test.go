@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	testconfig "testhelpers/configuration"
 	testnet "testhelpers/net"
 	"testing"
@@ -147,6 +148,198 @@ func TestLoggingInWithErrorMaskedAsSuccess(t *testing.T) {
 	assert.Empty(t, savedConfig.AccessToken)
 }
 
+func formMatcher(expected map[string]string) testnet.RequestMatcher {
+	return func(request *http.Request) (err error) {
+		err = request.ParseForm()
+		if err != nil {
+			return
+		}
+
+		for key, value := range expected {
+			if request.Form.Get(key) != value {
+				err = fmt.Errorf("%s did not match.\nExpected:%s\nActual:   %s", key, value, request.Form.Get(key))
+				return
+			}
+		}
+		return
+	}
+}
+
+func basicAuthHeaders(clientId, clientSecret string) http.Header {
+	return http.Header{
+		"content-type":  {"application/x-www-form-urlencoded"},
+		"authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte(clientId+":"+clientSecret))},
+	}
+}
+
+var clientCredentialsTokenResponse = testnet.TestResponse{
+	Status: http.StatusOK,
+	Body: `
+{
+  "access_token": "my_client_token",
+  "token_type": "BEARER",
+  "expires_in": 43199
+}`,
+}
+
+func TestLoggingInWithClientCredentials(t *testing.T) {
+	request := testnet.TestRequest{
+		Method:   "POST",
+		Path:     "/oauth/token",
+		Header:   basicAuthHeaders("ci-client", "ci-secret"),
+		Matcher:  formMatcher(map[string]string{"grant_type": "client_credentials"}),
+		Response: clientCredentialsTokenResponse,
+	}
+
+	ts, handler, auth := setupAuthWithEndpoint(t, request)
+	defer ts.Close()
+
+	apiResponse := auth.AuthenticateWithClientCredentials("ci-client", "ci-secret")
+	savedConfig := testconfig.SavedConfiguration
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, savedConfig.AccessToken, "BEARER my_client_token")
+	assert.Empty(t, savedConfig.RefreshToken)
+	assert.Equal(t, savedConfig.UAAGrantType, "client_credentials")
+	assert.Equal(t, savedConfig.ClientCredentialsId, "ci-client")
+	assert.Equal(t, savedConfig.ClientCredentialsSecret, "ci-secret")
+	assert.Empty(t, savedConfig.UAAOAuthClient)
+}
+
+func TestLoggingInWithBadClientCredentials(t *testing.T) {
+	ts, handler, auth := setupAuthWithEndpoint(t, unsuccessfulLoginRequest)
+	defer ts.Close()
+
+	apiResponse := auth.AuthenticateWithClientCredentials("ci-client", "wrong-secret")
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, apiResponse.Message, "Client credentials are incorrect, please try again.")
+}
+
+func TestLoggingInWithPasscode(t *testing.T) {
+	request := successfulLoginRequest
+	request.Matcher = formMatcher(map[string]string{"grant_type": "password", "passcode": "my-passcode"})
+
+	ts, handler, auth := setupAuthWithEndpoint(t, request)
+	defer ts.Close()
+
+	apiResponse := auth.AuthenticateWithPasscode("my-passcode")
+	savedConfig := testconfig.SavedConfiguration
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, savedConfig.AccessToken, "BEARER my_access_token")
+	assert.Equal(t, savedConfig.RefreshToken, "my_refresh_token")
+	assert.Equal(t, savedConfig.UAAGrantType, "password")
+}
+
+func TestLoggingInAfterClientCredentialsForgetsTheClient(t *testing.T) {
+	ts, handler, auth := setupAuthWithEndpoint(t, successfulLoginRequest)
+	defer ts.Close()
+	auth.config.UAAGrantType = "client_credentials"
+	auth.config.ClientCredentialsId = "ci-client"
+	auth.config.ClientCredentialsSecret = "ci-secret"
+
+	apiResponse := auth.Authenticate("foo@example.com", "bar")
+	savedConfig := testconfig.SavedConfiguration
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, savedConfig.UAAGrantType, "password")
+	assert.Empty(t, savedConfig.ClientCredentialsId)
+	assert.Empty(t, savedConfig.ClientCredentialsSecret)
+}
+
+func TestLoggingInThroughClientFromConfig(t *testing.T) {
+	request := successfulLoginRequest
+	request.Header = basicAuthHeaders("my-client", "my-secret")
+
+	ts, handler, auth := setupAuthWithEndpoint(t, request)
+	defer ts.Close()
+	auth.config.UAAOAuthClient = "my-client"
+	auth.config.UAAOAuthClientSecret = "my-secret"
+
+	apiResponse := auth.Authenticate("foo@example.com", "bar")
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
+func TestLoggingInThroughClientFromEnvironment(t *testing.T) {
+	os.Setenv("CF_CLIENT_ID", "env-client")
+	os.Setenv("CF_CLIENT_SECRET", "env-secret")
+	defer os.Setenv("CF_CLIENT_ID", "")
+	defer os.Setenv("CF_CLIENT_SECRET", "")
+
+	request := successfulLoginRequest
+	request.Header = basicAuthHeaders("env-client", "env-secret")
+
+	ts, handler, auth := setupAuthWithEndpoint(t, request)
+	defer ts.Close()
+	auth.config.UAAOAuthClient = "my-client"
+
+	apiResponse := auth.Authenticate("foo@example.com", "bar")
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
+func TestRefreshingTokenWithRefreshToken(t *testing.T) {
+	request := successfulLoginRequest
+	request.Matcher = formMatcher(map[string]string{"grant_type": "refresh_token", "refresh_token": "old_refresh_token"})
+
+	ts, handler, auth := setupAuthWithEndpoint(t, request)
+	defer ts.Close()
+	auth.config.RefreshToken = "old_refresh_token"
+	auth.config.UAAGrantType = "password"
+
+	updatedToken, apiResponse := auth.RefreshAuthToken()
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, updatedToken, "BEARER my_access_token")
+	assert.Equal(t, testconfig.SavedConfiguration.UAAGrantType, "password")
+}
+
+func TestRefreshingTokenWithClientCredentials(t *testing.T) {
+	request := testnet.TestRequest{
+		Method:   "POST",
+		Path:     "/oauth/token",
+		Header:   basicAuthHeaders("ci-client", "ci-secret"),
+		Matcher:  formMatcher(map[string]string{"grant_type": "client_credentials"}),
+		Response: clientCredentialsTokenResponse,
+	}
+
+	ts, handler, auth := setupAuthWithEndpoint(t, request)
+	defer ts.Close()
+	auth.config.UAAGrantType = "client_credentials"
+	auth.config.ClientCredentialsId = "ci-client"
+	auth.config.ClientCredentialsSecret = "ci-secret"
+
+	updatedToken, apiResponse := auth.RefreshAuthToken()
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, updatedToken, "BEARER my_client_token")
+}
+
+func TestRefreshingTokenWhenTheRefreshTokenIsRejected(t *testing.T) {
+	ts, handler, auth := setupAuthWithEndpoint(t, unsuccessfulLoginRequest)
+	defer ts.Close()
+	auth.config.AccessToken = "BEARER old_access_token"
+	auth.config.RefreshToken = "old_refresh_token"
+
+	updatedToken, apiResponse := auth.RefreshAuthToken()
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Contains(t, apiResponse.Message, "Error refreshing auth token")
+	assert.Contains(t, apiResponse.Message, "Not logged in")
+	assert.Empty(t, updatedToken)
+}
+
 func setupAuthWithEndpoint(t *testing.T, request testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, auth UAAAuthenticationRepository) {
 	ts, handler = testnet.NewTLSServer(t, []testnet.TestRequest{request})
 
@@ -158,6 +351,7 @@ func setupAuthWithEndpoint(t *testing.T, request testnet.TestRequest) (ts *httpt
 	config.AccessToken = ""
 
 	gateway := net.NewUAAGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 
 	auth = NewUAAAuthenticationRepository(gateway, configRepo)
 	return
@@ -123,6 +123,7 @@ func testUploadBuildpack(t *testing.T, dir string, requests []testnet.TestReques
 		Target:      ts.URL,
 	}
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	repo := NewCloudControllerBuildpackBitsRepository(config, gateway, cf.ApplicationZipper{})
 
 	buildpack = cf.Buildpack{Name: "my-cool-buildpack", Guid: "my-cool-buildpack-guid"}
@@ -14,10 +14,6 @@ const (
 	buildpacks_path = "/v2/buildpacks"
 )
 
-type PaginatedBuildpackResources struct {
-	Resources []BuildpackResource
-}
-
 type BuildpackResource struct {
 	Resource
 	Entity BuildpackEntity
@@ -48,12 +44,11 @@ func NewCloudControllerBuildpackRepository(config *configuration.Configuration,
 }
 
 func (repo CloudControllerBuildpackRepository) FindAll() (buildpacks []cf.Buildpack, apiResponse net.ApiResponse) {
-	path := repo.config.Target + buildpacks_path
-	return repo.findAllWithPath(path)
+	return repo.findAllWithPath(buildpacks_path)
 }
 
 func (repo CloudControllerBuildpackRepository) FindByName(name string) (buildpack cf.Buildpack, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s%s?q=name%%3A%s", repo.config.Target, buildpacks_path, url.QueryEscape(name))
+	path := fmt.Sprintf("%s?q=name%%3A%s", buildpacks_path, url.QueryEscape(name))
 	buildpacks, apiResponse := repo.findAllWithPath(path)
 	if apiResponse.IsNotSuccessful() {
 		return
@@ -69,17 +64,11 @@ func (repo CloudControllerBuildpackRepository) FindByName(name string) (buildpac
 }
 
 func (repo CloudControllerBuildpackRepository) findAllWithPath(path string) (buildpacks []cf.Buildpack, apiResponse net.ApiResponse) {
-	response := new(PaginatedBuildpackResources)
-
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, response)
-	if apiResponse.IsNotSuccessful() {
-		return
-	}
-
-	for _, r := range response.Resources {
-		buildpacks = append(buildpacks, unmarshallBuildpack(r))
-	}
-
+	apiResponse = repo.gateway.ListPaginatedResources(repo.config.Target, repo.config.AccessToken, path, BuildpackResource{},
+		func(resource interface{}) bool {
+			buildpacks = append(buildpacks, unmarshallBuildpack(resource.(BuildpackResource)))
+			return true
+		})
 	return
 }
 
@@ -266,6 +266,7 @@ func createBuildpackRepo(t *testing.T, requests []testnet.TestRequest) (ts *http
 		Space:       cf.Space{Name: "my-space", Guid: "my-space-guid"},
 	}
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	repo = NewCloudControllerBuildpackRepository(config, gateway)
 	return
 }
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"cf/configuration"
+	"cf/net"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http/httputil"
+	"strings"
+)
+
+type CurlRepository interface {
+	Request(method, path, headers, body string) (resHeaders, resBody string, apiResponse net.ApiResponse)
+}
+
+type CloudControllerCurlRepository struct {
+	config  *configuration.Configuration
+	gateway net.Gateway
+}
+
+func NewCloudControllerCurlRepository(config *configuration.Configuration, gateway net.Gateway) (repo CloudControllerCurlRepository) {
+	repo.config = config
+	repo.gateway = gateway
+	return
+}
+
+func (repo CloudControllerCurlRepository) Request(method, path, headers, body string) (resHeaders, resBody string, apiResponse net.ApiResponse) {
+	url := fmt.Sprintf("%s/%s", repo.config.Target, strings.TrimLeft(path, "/"))
+
+	var bodyReader io.ReadSeeker
+	if body != "" {
+		bodyReader = strings.NewReader(body)
+	}
+
+	request, apiResponse := repo.gateway.NewRequest(method, url, repo.config.AccessToken, bodyReader)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	for _, line := range strings.Split(headers, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			apiResponse = net.NewApiResponseWithMessage("Invalid header %s, expected NAME: VALUE", line)
+			return
+		}
+		request.HttpReq.Header.Set(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+	}
+
+	// error statuses are passed through like any other response, as curl does
+	rawResponse, apiResponse := repo.gateway.PerformRequestForResponse(request)
+	if rawResponse == nil {
+		return
+	}
+	defer rawResponse.Body.Close()
+	apiResponse = net.NewSuccessfulApiResponse()
+
+	headerBytes, err := httputil.DumpResponse(rawResponse, false)
+	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Error reading response headers", err)
+		return
+	}
+	resHeaders = strings.TrimSpace(string(headerBytes))
+
+	bodyBytes, err := ioutil.ReadAll(rawResponse.Body)
+	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Error reading response", err)
+		return
+	}
+	resBody = string(bodyBytes)
+	return
+}
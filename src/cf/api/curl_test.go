@@ -0,0 +1,97 @@
+package api
+
+import (
+	"cf/configuration"
+	"cf/net"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	testapi "testhelpers/api"
+	testnet "testhelpers/net"
+	"testing"
+)
+
+func TestCurlGetRequest(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/endpoint",
+		Response: testnet.TestResponse{
+			Status: http.StatusOK,
+			Body:   `{"resources": []}`,
+			Header: http.Header{"X-Custom": {"custom-value"}},
+		},
+	})
+
+	ts, handler, repo := createCurlRepo(t, req)
+	defer ts.Close()
+
+	headers, body, apiResponse := repo.Request("GET", "/v2/endpoint", "", "")
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Contains(t, headers, "200 OK")
+	assert.Contains(t, headers, "X-Custom: custom-value")
+	assert.Equal(t, body, "{\"resources\": []}\n")
+}
+
+func TestCurlPostRequestWithHeaders(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "POST",
+		Path:     "/v2/endpoint",
+		Header:   http.Header{"Accept-Language": {"en"}, "X-Other": {"value"}},
+		Matcher:  testnet.RequestBodyMatcher(`{"name":"my-thing"}`),
+		Response: testnet.TestResponse{Status: http.StatusCreated},
+	})
+
+	ts, handler, repo := createCurlRepo(t, req)
+	defer ts.Close()
+
+	_, _, apiResponse := repo.Request("POST", "v2/endpoint", "Accept-Language: en\nX-Other: value", `{"name":"my-thing"}`)
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
+func TestCurlWithInvalidHeader(t *testing.T) {
+	ts, _, repo := createCurlRepo(t, testnet.TestRequest{})
+	defer ts.Close()
+
+	_, _, apiResponse := repo.Request("GET", "/v2/endpoint", "not-a-header", "")
+
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Contains(t, apiResponse.Message, "Invalid header not-a-header")
+}
+
+func TestCurlWhenServerReturnsAnError(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/endpoint",
+		Response: testnet.TestResponse{
+			Status: http.StatusNotFound,
+			Body:   `{"code": 10000, "description": "Unknown request"}`,
+		},
+	})
+
+	ts, handler, repo := createCurlRepo(t, req)
+	defer ts.Close()
+
+	headers, body, apiResponse := repo.Request("GET", "/v2/endpoint", "", "")
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Contains(t, headers, "404 Not Found")
+	assert.Contains(t, body, `"description": "Unknown request"`)
+}
+
+func createCurlRepo(t *testing.T, req testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo CurlRepository) {
+	ts, handler = testnet.NewTLSServer(t, []testnet.TestRequest{req})
+
+	config := &configuration.Configuration{
+		AccessToken: "BEARER my_access_token",
+		Target:      ts.URL,
+	}
+	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
+	repo = NewCloudControllerCurlRepository(config, gateway)
+	return
+}
@@ -8,10 +8,6 @@ import (
 	"strings"
 )
 
-type PaginatedDomainResources struct {
-	Resources []DomainResource
-}
-
 type DomainResource struct {
 	Resource
 	Entity DomainEntity
@@ -48,15 +44,17 @@ func NewCloudControllerDomainRepository(config *configuration.Configuration, gat
 }
 
 func (repo CloudControllerDomainRepository) FindDefaultAppDomain() (domain cf.Domain, apiResponse net.ApiResponse) {
-	sharedPath := fmt.Sprintf("%s/v2/domains?inline-relations-depth=1", repo.config.Target)
-	sharedDomains, apiResponse := repo.findAllWithPath(sharedPath)
+	found := false
+	apiResponse = repo.listDomainsWithPath("/v2/domains?inline-relations-depth=1", func(d cf.Domain) bool {
+		domain = d
+		found = true
+		return false
+	})
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
 
-	if len(sharedDomains) > 0 {
-		domain = sharedDomains[0]
-	} else {
+	if !found {
 		apiResponse = net.NewNotFoundApiResponse("No default domain exists")
 	}
 
@@ -64,14 +62,13 @@ func (repo CloudControllerDomainRepository) FindDefaultAppDomain() (domain cf.Do
 }
 
 func (repo CloudControllerDomainRepository) FindAllByOrg(org cf.Organization) (domains []cf.Domain, apiResponse net.ApiResponse) {
-	scopedPath := fmt.Sprintf("%s/v2/organizations/%s/domains?inline-relations-depth=1", repo.config.Target, org.Guid)
+	scopedPath := fmt.Sprintf("/v2/organizations/%s/domains?inline-relations-depth=1", org.Guid)
 	domains, apiResponse = repo.findAllWithPath(scopedPath)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
 
-	sharedPath := fmt.Sprintf("%s/v2/domains?inline-relations-depth=1", repo.config.Target)
-	sharedDomains, apiResponse := repo.findAllWithPath(sharedPath)
+	sharedDomains, apiResponse := repo.findAllWithPath("/v2/domains?inline-relations-depth=1")
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -94,33 +91,35 @@ func (repo CloudControllerDomainRepository) FindAllByOrg(org cf.Organization) (d
 }
 
 func (repo CloudControllerDomainRepository) findAllWithPath(path string) (domains []cf.Domain, apiResponse net.ApiResponse) {
-	domainResources := new(PaginatedDomainResources)
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, domainResources)
-	if apiResponse.IsNotSuccessful() {
-		return
-	}
-
-	for _, r := range domainResources.Resources {
-		domain := cf.Domain{
-			Name: r.Entity.Name,
-			Guid: r.Metadata.Guid,
-		}
-		domain.Shared = r.Entity.OwningOrganizationGuid == ""
-
-		for _, space := range r.Entity.Spaces {
-			domain.Spaces = append(domain.Spaces, cf.Space{
-				Name: space.Entity.Name,
-				Guid: space.Metadata.Guid,
-			})
-		}
+	apiResponse = repo.listDomainsWithPath(path, func(domain cf.Domain) bool {
 		domains = append(domains, domain)
-	}
-
+		return true
+	})
 	return
 }
 
+func (repo CloudControllerDomainRepository) listDomainsWithPath(path string, cb func(cf.Domain) bool) (apiResponse net.ApiResponse) {
+	return repo.gateway.ListPaginatedResources(repo.config.Target, repo.config.AccessToken, path, DomainResource{},
+		func(resource interface{}) bool {
+			r := resource.(DomainResource)
+			domain := cf.Domain{
+				Name: r.Entity.Name,
+				Guid: r.Metadata.Guid,
+			}
+			domain.Shared = r.Entity.OwningOrganizationGuid == ""
+
+			for _, space := range r.Entity.Spaces {
+				domain.Spaces = append(domain.Spaces, cf.Space{
+					Name: space.Entity.Name,
+					Guid: space.Metadata.Guid,
+				})
+			}
+			return cb(domain)
+		})
+}
+
 func (repo CloudControllerDomainRepository) FindByName(name string) (domain cf.Domain, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/domains?inline-relations-depth=1&q=name%%3A%s", repo.config.Target, name)
+	path := fmt.Sprintf("/v2/domains?inline-relations-depth=1&q=name%%3A%s", name)
 	domains, apiResponse := repo.findAllWithPath(path)
 	if apiResponse.IsNotSuccessful() {
 		return
@@ -135,12 +134,12 @@ func (repo CloudControllerDomainRepository) FindByName(name string) (domain cf.D
 }
 
 func (repo CloudControllerDomainRepository) FindByNameInCurrentSpace(name string) (domain cf.Domain, apiResponse net.ApiResponse) {
-	spacePath := fmt.Sprintf("%s/v2/spaces/%s/domains?inline-relations-depth=1&q=name%%3A%s", repo.config.Target, repo.config.Space.Guid, name)
+	spacePath := fmt.Sprintf("/v2/spaces/%s/domains?inline-relations-depth=1&q=name%%3A%s", repo.config.Space.Guid, name)
 	return repo.findOneWithPaths(spacePath, name)
 }
 
 func (repo CloudControllerDomainRepository) FindByNameInOrg(name string, org cf.Organization) (domain cf.Domain, apiResponse net.ApiResponse) {
-	orgPath := fmt.Sprintf("%s/v2/organizations/%s/domains?inline-relations-depth=1&q=name%%3A%s", repo.config.Target, org.Guid, name)
+	orgPath := fmt.Sprintf("/v2/organizations/%s/domains?inline-relations-depth=1&q=name%%3A%s", org.Guid, name)
 	return repo.findOneWithPaths(orgPath, name)
 }
 
@@ -151,7 +150,7 @@ func (repo CloudControllerDomainRepository) findOneWithPaths(scopedPath, name st
 	}
 
 	if len(domains) == 0 {
-		sharedPath := fmt.Sprintf("%s/v2/domains?inline-relations-depth=1&q=name%%3A%s", repo.config.Target, name)
+		sharedPath := fmt.Sprintf("/v2/domains?inline-relations-depth=1&q=name%%3A%s", name)
 		domains, apiResponse = repo.findAllWithPath(sharedPath)
 		if apiResponse.IsNotSuccessful() {
 			return
@@ -553,6 +553,7 @@ func createDomainRepo(t *testing.T, reqs []testnet.TestRequest) (ts *httptest.Se
 		Organization: cf.Organization{Guid: "my-org-guid"},
 	}
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	repo = NewCloudControllerDomainRepository(config, gateway)
 	return
 }
@@ -38,7 +38,7 @@ func (repo RemoteEndpointRepository) UpdateEndpoint(endpoint string) (finalEndpo
 		finalEndpoint = "https://" + endpoint
 		apiResponse = repo.doUpdateEndpoint(finalEndpoint)
 
-		if apiResponse.IsNotSuccessful() {
+		if apiResponse.IsNotSuccessful() && apiResponse.ErrorCode != net.INVALID_SSL_CERT_CODE {
 			finalEndpoint = "http://" + endpoint
 			apiResponse = repo.doUpdateEndpoint(finalEndpoint)
 		}
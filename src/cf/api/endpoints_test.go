@@ -130,6 +130,23 @@ func TestUpdateEndpointWhenUrlIsMissingSchemeAndHttpEndpointExists(t *testing.T)
 	assert.Equal(t, savedConfig.ApiVersion, "42.0.0")
 }
 
+func TestUpdateEndpointWithUntrustedCertificateDoesNotFallBackToHttp(t *testing.T) {
+	configRepo := testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	configRepo.Login()
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(validApiInfoEndpoint))
+	defer ts.Close()
+	repo := makeRepo(configRepo, nil)
+
+	schemelessURL := strings.Replace(ts.URL, "https://", "", 1)
+	_, apiResponse := repo.UpdateEndpoint(schemelessURL)
+
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, apiResponse.ErrorCode, net.INVALID_SSL_CERT_CODE)
+	assert.Contains(t, apiResponse.Message, "Invalid SSL Cert")
+}
+
 var notFoundApiEndpoint = func(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotFound)
 }
@@ -166,19 +183,22 @@ func createEndpointRepoForUpdate(configRepo testconfig.FakeConfigRepository, end
 	if endpoint != nil {
 		ts = httptest.NewTLSServer(http.HandlerFunc(endpoint))
 	}
-	return ts, makeRepo(configRepo)
+	return ts, makeRepo(configRepo, ts)
 }
 
 func createInsecureEndpointRepoForUpdate(configRepo testconfig.FakeConfigRepository, endpoint func(w http.ResponseWriter, r *http.Request)) (ts *httptest.Server, repo EndpointRepository) {
 	if endpoint != nil {
 		ts = httptest.NewServer(http.HandlerFunc(endpoint))
 	}
-	return ts, makeRepo(configRepo)
+	return ts, makeRepo(configRepo, ts)
 }
 
-func makeRepo(configRepo testconfig.FakeConfigRepository) (repo EndpointRepository) {
+func makeRepo(configRepo testconfig.FakeConfigRepository, ts *httptest.Server) (repo EndpointRepository) {
 	config, _ := configRepo.Get()
 	gateway := net.NewCloudControllerGateway()
+	if ts != nil && ts.TLS != nil {
+		gateway.SetTrustedCerts(ts.TLS.Certificates)
+	}
 	return NewEndpointRepository(config, gateway, configRepo)
 }
 
@@ -4,77 +4,139 @@ import (
 	"cf"
 	"cf/configuration"
 	"cf/net"
-	"cf/terminal"
 	"code.google.com/p/go.net/websocket"
-	"crypto/tls"
 	"errors"
 	"fmt"
 	"github.com/cloudfoundry/loggregatorlib/logmessage"
+	"sync"
 	"time"
 )
 
 type LogsRepository interface {
 	RecentLogsFor(app cf.Application, onConnect func(), logChan chan *logmessage.Message) (err error)
+	RecentLogsForApps(apps []cf.Application, onConnect func(), logChan chan *logmessage.Message) (err error)
 	TailLogsFor(app cf.Application, onConnect func(), logChan chan *logmessage.Message, stopLoggingChan chan bool, printInterval time.Duration) (err error)
+	TailLogsForApps(apps []cf.Application, onConnect func(), logChan chan *logmessage.Message, stopLoggingChan chan bool, printInterval time.Duration) (err error)
 }
 
 type LoggregatorLogsRepository struct {
 	config       *configuration.Configuration
+	gateway      net.Gateway
 	endpointRepo EndpointRepository
 }
 
-func NewLoggregatorLogsRepository(config *configuration.Configuration, endpointRepo EndpointRepository) (repo LoggregatorLogsRepository) {
+func NewLoggregatorLogsRepository(config *configuration.Configuration, gateway net.Gateway, endpointRepo EndpointRepository) (repo LoggregatorLogsRepository) {
 	repo.config = config
+	repo.gateway = gateway
 	repo.endpointRepo = endpointRepo
 	return
 }
 
 func (repo LoggregatorLogsRepository) RecentLogsFor(app cf.Application, onConnect func(), logChan chan *logmessage.Message) (err error) {
-	host, apiResponse := repo.endpointRepo.GetEndpoint(cf.LoggregatorEndpointKey)
-	if apiResponse.IsNotSuccessful() {
-		err = errors.New(apiResponse.Message)
+	return repo.RecentLogsForApps([]cf.Application{app}, onConnect, logChan)
+}
+
+func (repo LoggregatorLogsRepository) RecentLogsForApps(apps []cf.Application, onConnect func(), logChan chan *logmessage.Message) (err error) {
+	locations, err := repo.locationsFor("dump", apps)
+	if err != nil {
 		return
 	}
-	location := host + fmt.Sprintf("/dump/?app=%s", app.Guid)
 	stopLoggingChan := make(chan bool)
-	return repo.connectToWebsocket(location, app, onConnect, logChan, stopLoggingChan, 0*time.Nanosecond)
+	return repo.connectToWebsockets(locations, onConnect, logChan, stopLoggingChan, 0*time.Nanosecond)
 }
 
 func (repo LoggregatorLogsRepository) TailLogsFor(app cf.Application, onConnect func(), logChan chan *logmessage.Message, stopLoggingChan chan bool, printTimeBuffer time.Duration) error {
+	return repo.TailLogsForApps([]cf.Application{app}, onConnect, logChan, stopLoggingChan, printTimeBuffer)
+}
+
+func (repo LoggregatorLogsRepository) TailLogsForApps(apps []cf.Application, onConnect func(), logChan chan *logmessage.Message, stopLoggingChan chan bool, printTimeBuffer time.Duration) error {
+	locations, err := repo.locationsFor("tail", apps)
+	if err != nil {
+		return err
+	}
+	return repo.connectToWebsockets(locations, onConnect, logChan, stopLoggingChan, printTimeBuffer)
+}
+
+func (repo LoggregatorLogsRepository) locationsFor(path string, apps []cf.Application) (locations []string, err error) {
 	host, apiResponse := repo.endpointRepo.GetEndpoint(cf.LoggregatorEndpointKey)
 	if apiResponse.IsNotSuccessful() {
-		return errors.New(apiResponse.Message)
+		err = errors.New(apiResponse.Message)
+		return
+	}
+
+	for _, app := range apps {
+		locations = append(locations, host+fmt.Sprintf("/%s/?app=%s", path, app.Guid))
 	}
-	location := host + fmt.Sprintf("/tail/?app=%s", app.Guid)
-	return repo.connectToWebsocket(location, app, onConnect, logChan, stopLoggingChan, printTimeBuffer)
+	return
 }
 
-func (repo LoggregatorLogsRepository) connectToWebsocket(location string, app cf.Application, onConnect func(), outputChan chan *logmessage.Message, stopLoggingChan chan bool, printTimeBuffer time.Duration) (err error) {
-	if net.TraceEnabled() {
-		fmt.Printf("\n%s %s\n", terminal.HeaderColor("CONNECTING TO WEBSOCKET:"), location)
+// connectToWebsockets opens one connection per location and merges all of
+// their messages through a single sorter, so messages from several apps come
+// out in timestamp order. Logging stops once every connection has closed.
+func (repo LoggregatorLogsRepository) connectToWebsockets(locations []string, onConnect func(), outputChan chan *logmessage.Message, stopLoggingChan chan bool, printTimeBuffer time.Duration) (err error) {
+	connections := []*websocket.Conn{}
+	for _, location := range locations {
+		var ws *websocket.Conn
+		ws, err = repo.dialWebsocket(location)
+		if err != nil {
+			for _, connection := range connections {
+				connection.Close()
+			}
+			return
+		}
+		connections = append(connections, ws)
+	}
+
+	onConnect()
+
+	inputChan := make(chan *logmessage.Message, 1000)
+
+	listeners := &sync.WaitGroup{}
+	listeners.Add(len(connections))
+	for _, ws := range connections {
+		go repo.sendKeepAlive(ws)
+		go func(ws *websocket.Conn) {
+			defer listeners.Done()
+			repo.listenForMessages(ws, inputChan)
+		}(ws)
 	}
 
+	go func() {
+		listeners.Wait()
+		stopLoggingChan <- true
+	}()
+
+	go makeAndStartMessageSorter(inputChan, outputChan, stopLoggingChan, printTimeBuffer)
+
+	return
+}
+
+func (repo LoggregatorLogsRepository) dialWebsocket(location string) (ws *websocket.Conn, err error) {
 	config, err := websocket.NewConfig(location, "http://localhost")
 	if err != nil {
 		return
 	}
 
-	config.Header.Add("Authorization", repo.config.AccessToken)
-	config.TlsConfig = &tls.Config{InsecureSkipVerify: true}
+	token, apiResponse := repo.gateway.AccessToken()
+	if apiResponse.IsNotSuccessful() {
+		err = errors.New(apiResponse.Message)
+		return
+	}
 
-	ws, err := websocket.DialConfig(config)
+	config.Header.Add("Authorization", token)
+	config.TlsConfig, err = net.NewTLSConfig(nil, repo.config.SSLDisabled)
 	if err != nil {
 		return
 	}
 
-	onConnect()
-
-	inputChan := make(chan *logmessage.Message, 1000)
-
-	go repo.sendKeepAlive(ws)
-	go repo.listenForMessages(ws, inputChan, stopLoggingChan)
-	go makeAndStartMessageSorter(inputChan, outputChan, stopLoggingChan, printTimeBuffer)
-
+	startedAt := time.Now()
+	ws, err = websocket.DialConfig(config)
+	if net.TraceEnabled() {
+		net.TraceWebsocket(location, config.Header, startedAt, err)
+	}
+	if net.IsInvalidSSLCertError(err) {
+		err = errors.New(net.InvalidSSLCertMessage(location))
+	}
 	return
 }
 
@@ -125,11 +187,7 @@ func (repo LoggregatorLogsRepository) sendKeepAlive(ws *websocket.Conn) {
 	}
 }
 
-func (repo LoggregatorLogsRepository) listenForMessages(ws *websocket.Conn, msgChan chan<- *logmessage.Message, stopLoggingChan chan bool) {
-	defer func() {
-		stopLoggingChan <- true
-	}()
-
+func (repo LoggregatorLogsRepository) listenForMessages(ws *websocket.Conn, msgChan chan<- *logmessage.Message) {
 	for {
 		var data []byte
 		err := websocket.Message.Receive(ws, &data)
@@ -3,8 +3,11 @@ package api
 import (
 	"cf"
 	"cf/configuration"
+	"cf/net"
 	"code.google.com/p/go.net/websocket"
 	"code.google.com/p/gogoprotobuf/proto"
+	"encoding/base64"
+	"fmt"
 	"github.com/cloudfoundry/loggregatorlib/logmessage"
 	"github.com/stretchr/testify/assert"
 	"net/http/httptest"
@@ -40,13 +43,13 @@ func TestRecentLogsFor(t *testing.T) {
 	assert.NoError(t, err)
 
 	app := cf.Application{Name: "my-app", Guid: "my-app-guid"}
-	config := &configuration.Configuration{AccessToken: "BEARER my_access_token", Target: "https://localhost"}
+	config := &configuration.Configuration{AccessToken: "BEARER my_access_token", Target: "https://localhost", SSLDisabled: true}
 
 	endpointRepo := &testapi.FakeEndpointRepo{GetEndpointEndpoints: map[cf.EndpointType]string{
 		cf.LoggregatorEndpointKey: strings.Replace(websocketServer.URL, "https", "wss", 1),
 	}}
 
-	logsRepo := NewLoggregatorLogsRepository(config, endpointRepo)
+	logsRepo := NewLoggregatorLogsRepository(config, logsGateway(config, nil), endpointRepo)
 
 	connected := false
 	onConnect := func() {
@@ -95,12 +98,12 @@ func TestTailsLogsFor(t *testing.T) {
 	defer websocketServer.Close()
 
 	app := cf.Application{Name: "my-app", Guid: "my-app-guid"}
-	config := &configuration.Configuration{AccessToken: "BEARER my_access_token", Target: "https://localhost"}
+	config := &configuration.Configuration{AccessToken: "BEARER my_access_token", Target: "https://localhost", SSLDisabled: true}
 	endpointRepo := &testapi.FakeEndpointRepo{GetEndpointEndpoints: map[cf.EndpointType]string{
 		cf.LoggregatorEndpointKey: strings.Replace(websocketServer.URL, "https", "wss", 1),
 	}}
 
-	logsRepo := NewLoggregatorLogsRepository(config, endpointRepo)
+	logsRepo := NewLoggregatorLogsRepository(config, logsGateway(config, nil), endpointRepo)
 
 	connected := false
 	onConnect := func() {
@@ -141,6 +144,59 @@ func TestTailsLogsFor(t *testing.T) {
 	assert.Equal(t, actualMessage, messagesSent[0])
 }
 
+func TestTailLogsForAppsMergesMessagesInTimestampOrder(t *testing.T) {
+	messagesSent := map[string][][]byte{
+		"app=my-app-guid": {
+			marshalledLogMessageWithTime(t, "My message 1", int64(100000)),
+			marshalledLogMessageWithTime(t, "My message 3", int64(300000)),
+		},
+		"app=my-worker-guid": {
+			marshalledLogMessageWithTime(t, "My message 2", int64(200000)),
+		},
+	}
+
+	websocketEndpoint := func(conn *websocket.Conn) {
+		request := conn.Request()
+		assert.Equal(t, request.URL.Path, "/tail/")
+
+		for _, msg := range messagesSent[request.URL.RawQuery] {
+			conn.Write(msg)
+		}
+		time.Sleep(time.Duration(200) * time.Millisecond)
+		conn.Close()
+	}
+	websocketServer := httptest.NewTLSServer(websocket.Handler(websocketEndpoint))
+	defer websocketServer.Close()
+
+	apps := []cf.Application{
+		{Name: "my-app", Guid: "my-app-guid"},
+		{Name: "my-worker", Guid: "my-worker-guid"},
+	}
+	config := &configuration.Configuration{AccessToken: "BEARER my_access_token", Target: "https://localhost", SSLDisabled: true}
+	endpointRepo := &testapi.FakeEndpointRepo{GetEndpointEndpoints: map[cf.EndpointType]string{
+		cf.LoggregatorEndpointKey: strings.Replace(websocketServer.URL, "https", "wss", 1),
+	}}
+
+	logsRepo := NewLoggregatorLogsRepository(config, logsGateway(config, nil), endpointRepo)
+
+	connectCount := 0
+	onConnect := func() {
+		connectCount++
+	}
+
+	logChan := make(chan *logmessage.Message, 1000)
+	err := logsRepo.TailLogsForApps(apps, onConnect, logChan, make(chan bool), 100*time.Millisecond)
+	assert.NoError(t, err)
+
+	tailedMessages := []string{}
+	for msg := range logChan {
+		tailedMessages = append(tailedMessages, string(msg.GetLogMessage().GetMessage()))
+	}
+
+	assert.Equal(t, connectCount, 1)
+	assert.Equal(t, tailedMessages, []string{"My message 1", "My message 2", "My message 3"})
+}
+
 func TestMessageOutputTimesDuringNormalFlow(t *testing.T) {
 	// out of order messages we will send
 	startTime := time.Now()
@@ -168,12 +224,12 @@ func TestMessageOutputTimesDuringNormalFlow(t *testing.T) {
 	defer websocketServer.Close()
 
 	app := cf.Application{Name: "my-app", Guid: "my-app-guid"}
-	config := &configuration.Configuration{AccessToken: "BEARER my_access_token", Target: "https://localhost"}
+	config := &configuration.Configuration{AccessToken: "BEARER my_access_token", Target: "https://localhost", SSLDisabled: true}
 	endpointRepo := &testapi.FakeEndpointRepo{GetEndpointEndpoints: map[cf.EndpointType]string{
 		cf.LoggregatorEndpointKey: strings.Replace(websocketServer.URL, "https", "wss", 1),
 	}}
 
-	logsRepo := NewLoggregatorLogsRepository(config, endpointRepo)
+	logsRepo := NewLoggregatorLogsRepository(config, logsGateway(config, nil), endpointRepo)
 
 	logChan := make(chan *logmessage.Message, 1000)
 	controlChan := make(chan bool)
@@ -225,12 +281,12 @@ func TestMessageOutputWhenFlushingAfterServerDeath(t *testing.T) {
 	defer websocketServer.Close()
 
 	app := cf.Application{Name: "my-app", Guid: "my-app-guid"}
-	config := &configuration.Configuration{AccessToken: "BEARER my_access_token", Target: "https://localhost"}
+	config := &configuration.Configuration{AccessToken: "BEARER my_access_token", Target: "https://localhost", SSLDisabled: true}
 	endpointRepo := &testapi.FakeEndpointRepo{GetEndpointEndpoints: map[cf.EndpointType]string{
 		cf.LoggregatorEndpointKey: strings.Replace(websocketServer.URL, "https", "wss", 1),
 	}}
 
-	logsRepo := NewLoggregatorLogsRepository(config, endpointRepo)
+	logsRepo := NewLoggregatorLogsRepository(config, logsGateway(config, nil), endpointRepo)
 
 	firstMessageTime := time.Now().Add(-10 * time.Second).UnixNano()
 
@@ -256,6 +312,64 @@ func TestMessageOutputWhenFlushingAfterServerDeath(t *testing.T) {
 	}
 }
 
+func TestExpiringTokenIsRefreshedBeforeConnectingToLogs(t *testing.T) {
+	websocketEndpoint := func(conn *websocket.Conn) {
+		assert.Equal(t, conn.Request().Header.Get("Authorization"), "BEARER new_access_token")
+		conn.Close()
+	}
+	websocketServer := httptest.NewTLSServer(websocket.Handler(websocketEndpoint))
+	defer websocketServer.Close()
+
+	claims := fmt.Sprintf(`{"exp":%d}`, time.Now().Add(time.Minute).Unix())
+	expiringToken := "BEARER eyJhbGciOiJSUzI1NiJ9." + base64.StdEncoding.EncodeToString([]byte(claims)) + ".signature"
+
+	app := cf.Application{Name: "my-app", Guid: "my-app-guid"}
+	config := &configuration.Configuration{AccessToken: expiringToken, Target: "https://localhost", SSLDisabled: true}
+	auth := &testapi.FakeAuthenticationRepository{AccessToken: "BEARER new_access_token"}
+
+	endpointRepo := &testapi.FakeEndpointRepo{GetEndpointEndpoints: map[cf.EndpointType]string{
+		cf.LoggregatorEndpointKey: strings.Replace(websocketServer.URL, "https", "wss", 1),
+	}}
+
+	logsRepo := NewLoggregatorLogsRepository(config, logsGateway(config, auth), endpointRepo)
+
+	logChan := make(chan *logmessage.Message, 1000)
+	err := logsRepo.RecentLogsFor(app, func() {}, logChan)
+	assert.NoError(t, err)
+	for _ = range logChan {
+	}
+
+	assert.True(t, auth.RefreshTokenCalled)
+}
+
+func TestLogsFailWhenTheExpiringTokenCannotBeRefreshed(t *testing.T) {
+	claims := fmt.Sprintf(`{"exp":%d}`, time.Now().Add(time.Minute).Unix())
+	expiringToken := "BEARER eyJhbGciOiJSUzI1NiJ9." + base64.StdEncoding.EncodeToString([]byte(claims)) + ".signature"
+
+	app := cf.Application{Name: "my-app", Guid: "my-app-guid"}
+	config := &configuration.Configuration{AccessToken: expiringToken, Target: "https://localhost", SSLDisabled: true}
+	auth := &testapi.FakeAuthenticationRepository{RefreshTokenError: true}
+
+	endpointRepo := &testapi.FakeEndpointRepo{GetEndpointEndpoints: map[cf.EndpointType]string{
+		cf.LoggregatorEndpointKey: "wss://loggregator.example.com",
+	}}
+
+	logsRepo := NewLoggregatorLogsRepository(config, logsGateway(config, auth), endpointRepo)
+
+	err := logsRepo.RecentLogsFor(app, func() {}, make(chan *logmessage.Message, 1000))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Error refreshing auth token")
+}
+
+func logsGateway(config *configuration.Configuration, auth *testapi.FakeAuthenticationRepository) (gateway net.Gateway) {
+	gateway = net.NewCloudControllerGateway()
+	gateway.SetConfiguration(config)
+	if auth != nil {
+		gateway.SetTokenRefresher(auth)
+	}
+	return
+}
+
 func marshalledLogMessageWithTime(t *testing.T, messageString string, timestamp int64) []byte {
 	messageType := logmessage.LogMessage_OUT
 	sourceType := logmessage.LogMessage_DEA
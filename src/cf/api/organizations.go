@@ -8,22 +8,20 @@ import (
 	"strings"
 )
 
-type PaginatedOrganizationResources struct {
-	Resources []OrganizationResource
-}
-
 type OrganizationResource struct {
 	Resource
 	Entity OrganizationEntity
 }
 
 type OrganizationEntity struct {
-	Name    string
-	Spaces  []Resource
-	Domains []Resource
+	Name                string
+	Spaces              []Resource
+	Domains             []Resource
+	QuotaDefinitionGuid string `json:"quota_definition_guid"`
 }
 
 type OrganizationRepository interface {
+	ListOrgs(cb func(cf.Organization) bool) (apiResponse net.ApiResponse)
 	FindAll() (orgs []cf.Organization, apiResponse net.ApiResponse)
 	FindByName(name string) (org cf.Organization, apiResponse net.ApiResponse)
 	Create(name string) (apiResponse net.ApiResponse)
@@ -42,13 +40,16 @@ func NewCloudControllerOrganizationRepository(config *configuration.Configuratio
 	return
 }
 
+func (repo CloudControllerOrganizationRepository) ListOrgs(cb func(cf.Organization) bool) (apiResponse net.ApiResponse) {
+	return repo.listOrgsWithPath("/v2/organizations", cb)
+}
+
 func (repo CloudControllerOrganizationRepository) FindAll() (orgs []cf.Organization, apiResponse net.ApiResponse) {
-	path := repo.config.Target + "/v2/organizations"
-	return repo.findAllWithPath(path)
+	return repo.findAllWithPath("/v2/organizations")
 }
 
 func (repo CloudControllerOrganizationRepository) FindByName(name string) (org cf.Organization, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/organizations?q=name%s&inline-relations-depth=1", repo.config.Target, "%3A"+strings.ToLower(name))
+	path := fmt.Sprintf("/v2/organizations?q=name%s&inline-relations-depth=1", "%3A"+strings.ToLower(name))
 
 	orgs, apiResponse := repo.findAllWithPath(path)
 	if apiResponse.IsNotSuccessful() {
@@ -65,31 +66,36 @@ func (repo CloudControllerOrganizationRepository) FindByName(name string) (org c
 }
 
 func (repo CloudControllerOrganizationRepository) findAllWithPath(path string) (orgs []cf.Organization, apiResponse net.ApiResponse) {
-	orgResources := new(PaginatedOrganizationResources)
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, orgResources)
-	if apiResponse.IsNotSuccessful() {
-		return
-	}
+	apiResponse = repo.listOrgsWithPath(path, func(org cf.Organization) bool {
+		orgs = append(orgs, org)
+		return true
+	})
+	return
+}
 
-	for _, r := range orgResources.Resources {
-		spaces := []cf.Space{}
-		for _, s := range r.Entity.Spaces {
-			spaces = append(spaces, cf.Space{Name: s.Entity.Name, Guid: s.Metadata.Guid})
-		}
-
-		domains := []cf.Domain{}
-		for _, d := range r.Entity.Domains {
-			domains = append(domains, cf.Domain{Name: d.Entity.Name, Guid: d.Metadata.Guid})
-		}
-
-		orgs = append(orgs, cf.Organization{
-			Name:    r.Entity.Name,
-			Guid:    r.Metadata.Guid,
-			Spaces:  spaces,
-			Domains: domains,
+func (repo CloudControllerOrganizationRepository) listOrgsWithPath(path string, cb func(cf.Organization) bool) (apiResponse net.ApiResponse) {
+	return repo.gateway.ListPaginatedResources(repo.config.Target, repo.config.AccessToken, path, OrganizationResource{},
+		func(resource interface{}) bool {
+			r := resource.(OrganizationResource)
+
+			spaces := []cf.Space{}
+			for _, s := range r.Entity.Spaces {
+				spaces = append(spaces, cf.Space{Name: s.Entity.Name, Guid: s.Metadata.Guid})
+			}
+
+			domains := []cf.Domain{}
+			for _, d := range r.Entity.Domains {
+				domains = append(domains, cf.Domain{Name: d.Entity.Name, Guid: d.Metadata.Guid})
+			}
+
+			return cb(cf.Organization{
+				Name:      r.Entity.Name,
+				Guid:      r.Metadata.Guid,
+				Spaces:    spaces,
+				Domains:   domains,
+				QuotaGuid: r.Entity.QuotaDefinitionGuid,
+			})
 		})
-	}
-	return
 }
 
 func (repo CloudControllerOrganizationRepository) Create(name string) (apiResponse net.ApiResponse) {
@@ -45,6 +45,58 @@ func TestOrganizationsFindAll(t *testing.T) {
 	assert.Equal(t, secondOrg.Guid, "org2-guid")
 }
 
+var firstPageOrganizationsRequest = testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+	Method: "GET",
+	Path:   "/v2/organizations",
+	Response: testnet.TestResponse{Status: http.StatusOK, Body: `{
+		"next_url": "/v2/organizations?page=2",
+		"resources": [
+			{
+			  "metadata": { "guid": "org1-guid" },
+			  "entity": { "name": "Org1" }
+			}
+		]
+	}`},
+})
+
+var secondPageOrganizationsRequest = testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+	Method: "GET",
+	Path:   "/v2/organizations?page=2",
+	Response: testnet.TestResponse{Status: http.StatusOK, Body: `{"resources": [
+		{
+		  "metadata": { "guid": "org2-guid" },
+		  "entity": { "name": "Org2" }
+		}
+	]}`},
+})
+
+func TestOrganizationsFindAllFollowsNextUrl(t *testing.T) {
+	ts, handler, repo := createOrganizationRepo(t, firstPageOrganizationsRequest, secondPageOrganizationsRequest)
+	defer ts.Close()
+
+	organizations, apiResponse := repo.FindAll()
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, len(organizations), 2)
+	assert.Equal(t, organizations[0].Guid, "org1-guid")
+	assert.Equal(t, organizations[1].Guid, "org2-guid")
+}
+
+func TestOrganizationsListOrgsStopsWhenCallbackReturnsFalse(t *testing.T) {
+	ts, handler, repo := createOrganizationRepo(t, firstPageOrganizationsRequest, secondPageOrganizationsRequest)
+	defer ts.Close()
+
+	names := []string{}
+	apiResponse := repo.ListOrgs(func(org cf.Organization) bool {
+		names = append(names, org.Name)
+		return false
+	})
+
+	assert.False(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, names, []string{"Org1"})
+}
+
 func TestOrganizationsFindByName(t *testing.T) {
 	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
 		Method: "GET",
@@ -149,14 +201,15 @@ func TestDeleteOrganization(t *testing.T) {
 	assert.False(t, apiResponse.IsNotSuccessful())
 }
 
-func createOrganizationRepo(t *testing.T, req testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo OrganizationRepository) {
-	ts, handler = testnet.NewTLSServer(t, []testnet.TestRequest{req})
+func createOrganizationRepo(t *testing.T, reqs ...testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo OrganizationRepository) {
+	ts, handler = testnet.NewTLSServer(t, reqs)
 
 	config := &configuration.Configuration{
 		AccessToken: "BEARER my_access_token",
 		Target:      ts.URL,
 	}
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	repo = NewCloudControllerOrganizationRepository(config, gateway)
 	return
 }
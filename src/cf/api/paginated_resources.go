@@ -1,9 +1,5 @@
 package api
 
-type PaginatedResources struct {
-	Resources []Resource
-}
-
 type Resource struct {
 	Metadata Metadata
 	Entity   Entity
@@ -67,6 +67,7 @@ func createPasswordRepo(t *testing.T, req testnet.TestRequest, accessToken strin
 		AccessToken: accessToken,
 	}
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(passwordServer.TLS.Certificates)
 	repo = NewCloudControllerPasswordRepository(config, gateway, endpointRepo)
 	return
 }
@@ -1,31 +1,62 @@
 package api
 
 import (
+	"bytes"
 	"cf"
 	"cf/configuration"
 	"cf/net"
+	"encoding/json"
 	"fmt"
 	"strings"
 )
 
-type PaginatedQuotaResources struct {
-	Resources []QuotaResource
-}
-
 type QuotaResource struct {
 	Resource
 	Entity QuotaEntity
 }
 
 type QuotaEntity struct {
-	Name        string
-	MemoryLimit uint64 `json:"memory_limit"`
+	Name                    string `json:"name"`
+	MemoryLimit             uint64 `json:"memory_limit"`
+	InstanceMemoryLimit     int64  `json:"instance_memory_limit"`
+	RoutesLimit             int    `json:"total_routes"`
+	ServicesLimit           int    `json:"total_services"`
+	NonBasicServicesAllowed bool   `json:"non_basic_services_allowed"`
+	AppInstanceLimit        int    `json:"app_instance_limit"`
+}
+
+func newQuotaEntity(quota cf.Quota) QuotaEntity {
+	return QuotaEntity{
+		Name:                    quota.Name,
+		MemoryLimit:             quota.MemoryLimit,
+		InstanceMemoryLimit:     quota.InstanceMemoryLimit,
+		RoutesLimit:             quota.RoutesLimit,
+		ServicesLimit:           quota.ServicesLimit,
+		NonBasicServicesAllowed: quota.NonBasicServicesAllowed,
+		AppInstanceLimit:        quota.AppInstanceLimit,
+	}
+}
+
+func (resource QuotaResource) ToModel() (quota cf.Quota) {
+	quota.Guid = resource.Metadata.Guid
+	quota.Name = resource.Entity.Name
+	quota.MemoryLimit = resource.Entity.MemoryLimit
+	quota.InstanceMemoryLimit = resource.Entity.InstanceMemoryLimit
+	quota.RoutesLimit = resource.Entity.RoutesLimit
+	quota.ServicesLimit = resource.Entity.ServicesLimit
+	quota.NonBasicServicesAllowed = resource.Entity.NonBasicServicesAllowed
+	quota.AppInstanceLimit = resource.Entity.AppInstanceLimit
+	return
 }
 
 type QuotaRepository interface {
 	FindAll() (quotas []cf.Quota, apiResponse net.ApiResponse)
 	FindByName(name string) (quota cf.Quota, apiResponse net.ApiResponse)
-	Update(org cf.Organization, quota cf.Quota) (apiResponse net.ApiResponse)
+	FindByGuid(guid string) (quota cf.Quota, apiResponse net.ApiResponse)
+	Create(quota cf.Quota) (apiResponse net.ApiResponse)
+	Update(quota cf.Quota) (apiResponse net.ApiResponse)
+	Delete(quota cf.Quota) (apiResponse net.ApiResponse)
+	AssignQuotaToOrg(org cf.Organization, quota cf.Quota) (apiResponse net.ApiResponse)
 }
 
 type CloudControllerQuotaRepository struct {
@@ -40,32 +71,20 @@ func NewCloudControllerQuotaRepository(config *configuration.Configuration, gate
 }
 
 func (repo CloudControllerQuotaRepository) findAllWithPath(path string) (quotas []cf.Quota, apiResponse net.ApiResponse) {
-	resources := new(PaginatedQuotaResources)
-
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resources)
-	if apiResponse.IsNotSuccessful() {
-		return
-	}
-
-	for _, r := range resources.Resources {
-		quota := cf.Quota{
-			Guid:        r.Metadata.Guid,
-			Name:        r.Entity.Name,
-			MemoryLimit: r.Entity.MemoryLimit,
-		}
-		quotas = append(quotas, quota)
-	}
-
+	apiResponse = repo.gateway.ListPaginatedResources(repo.config.Target, repo.config.AccessToken, path, QuotaResource{},
+		func(resource interface{}) bool {
+			quotas = append(quotas, resource.(QuotaResource).ToModel())
+			return true
+		})
 	return
 }
 
 func (repo CloudControllerQuotaRepository) FindAll() (quotas []cf.Quota, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/quota_definitions", repo.config.Target)
-	return repo.findAllWithPath(path)
+	return repo.findAllWithPath("/v2/quota_definitions")
 }
 
 func (repo CloudControllerQuotaRepository) FindByName(name string) (quota cf.Quota, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/quota_definitions?q=name%%3A%s", repo.config.Target, name)
+	path := fmt.Sprintf("/v2/quota_definitions?q=name%%3A%s", name)
 	quotas, apiResponse := repo.findAllWithPath(path)
 	if apiResponse.IsNotSuccessful() {
 		return
@@ -80,7 +99,44 @@ func (repo CloudControllerQuotaRepository) FindByName(name string) (quota cf.Quo
 	return
 }
 
-func (repo CloudControllerQuotaRepository) Update(org cf.Organization, quota cf.Quota) (apiResponse net.ApiResponse) {
+func (repo CloudControllerQuotaRepository) FindByGuid(guid string) (quota cf.Quota, apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/quota_definitions/%s", repo.config.Target, guid)
+	resource := new(QuotaResource)
+	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resource)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	quota = resource.ToModel()
+	return
+}
+
+func (repo CloudControllerQuotaRepository) Create(quota cf.Quota) (apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/quota_definitions", repo.config.Target)
+	body, err := json.Marshal(newQuotaEntity(quota))
+	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Error encoding quota", err)
+		return
+	}
+	return repo.gateway.CreateResource(path, repo.config.AccessToken, bytes.NewReader(body))
+}
+
+func (repo CloudControllerQuotaRepository) Update(quota cf.Quota) (apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/quota_definitions/%s", repo.config.Target, quota.Guid)
+	body, err := json.Marshal(newQuotaEntity(quota))
+	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Error encoding quota", err)
+		return
+	}
+	return repo.gateway.UpdateResource(path, repo.config.AccessToken, bytes.NewReader(body))
+}
+
+func (repo CloudControllerQuotaRepository) Delete(quota cf.Quota) (apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/quota_definitions/%s", repo.config.Target, quota.Guid)
+	return repo.gateway.DeleteResource(path, repo.config.AccessToken)
+}
+
+func (repo CloudControllerQuotaRepository) AssignQuotaToOrg(org cf.Organization, quota cf.Quota) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/organizations/%s", repo.config.Target, org.Guid)
 	data := fmt.Sprintf(`{"quota_definition_guid":"%s"}`, quota.Guid)
 	return repo.gateway.UpdateResource(path, repo.config.AccessToken, strings.NewReader(data))
@@ -35,7 +35,166 @@ func TestFindQuotaByName(t *testing.T) {
 	assert.Equal(t, quota, cf.Quota{Guid: "my-quota-guid", Name: "my-remote-quota", MemoryLimit: 1024})
 }
 
+func TestFindQuotaByNameWithAllLimits(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/quota_definitions?q=name%3Amy-quota",
+		Response: testnet.TestResponse{
+			Status: http.StatusOK,
+			Body: `{"resources": [
+				{
+				  "metadata": { "guid": "my-quota-guid" },
+				  "entity": {
+				    "name": "my-quota",
+				    "memory_limit": 10240,
+				    "instance_memory_limit": -1,
+				    "total_routes": 100,
+				    "total_services": 20,
+				    "non_basic_services_allowed": true,
+				    "app_instance_limit": 50
+				  }
+				}
+			]}`},
+	})
+
+	ts, handler, repo := createQuotaRepo(t, req)
+	defer ts.Close()
+
+	quota, apiResponse := repo.FindByName("my-quota")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.False(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, quota, cf.Quota{
+		Guid:                    "my-quota-guid",
+		Name:                    "my-quota",
+		MemoryLimit:             10240,
+		InstanceMemoryLimit:     cf.UnlimitedQuota,
+		RoutesLimit:             100,
+		ServicesLimit:           20,
+		NonBasicServicesAllowed: true,
+		AppInstanceLimit:        50,
+	})
+}
+
+func TestFindQuotaByNameWhenNotFound(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "GET",
+		Path:     "/v2/quota_definitions?q=name%3Amy-quota",
+		Response: testnet.TestResponse{Status: http.StatusOK, Body: `{"resources": []}`},
+	})
+
+	ts, handler, repo := createQuotaRepo(t, req)
+	defer ts.Close()
+
+	_, apiResponse := repo.FindByName("my-quota")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsNotFound())
+}
+
+func TestFindQuotaByGuid(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/quota_definitions/my-quota-guid",
+		Response: testnet.TestResponse{
+			Status: http.StatusOK,
+			Body: `{
+			  "metadata": { "guid": "my-quota-guid" },
+			  "entity": { "name": "my-quota", "memory_limit": 1024, "total_routes": 10, "total_services": 5 }
+			}`},
+	})
+
+	ts, handler, repo := createQuotaRepo(t, req)
+	defer ts.Close()
+
+	quota, apiResponse := repo.FindByGuid("my-quota-guid")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.False(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, quota.Guid, "my-quota-guid")
+	assert.Equal(t, quota.Name, "my-quota")
+	assert.Equal(t, quota.MemoryLimit, uint64(1024))
+	assert.Equal(t, quota.RoutesLimit, 10)
+	assert.Equal(t, quota.ServicesLimit, 5)
+}
+
+func TestCreateQuota(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "POST",
+		Path:   "/v2/quota_definitions",
+		Matcher: testnet.RequestBodyMatcher(`{
+			"name":"my-quota",
+			"memory_limit":10240,
+			"instance_memory_limit":-1,
+			"total_routes":100,
+			"total_services":20,
+			"non_basic_services_allowed":true,
+			"app_instance_limit":50
+		}`),
+		Response: testnet.TestResponse{Status: http.StatusCreated},
+	})
+
+	ts, handler, repo := createQuotaRepo(t, req)
+	defer ts.Close()
+
+	apiResponse := repo.Create(cf.Quota{
+		Name:                    "my-quota",
+		MemoryLimit:             10240,
+		InstanceMemoryLimit:     cf.UnlimitedQuota,
+		RoutesLimit:             100,
+		ServicesLimit:           20,
+		NonBasicServicesAllowed: true,
+		AppInstanceLimit:        50,
+	})
+	assert.True(t, handler.AllRequestsCalled())
+	assert.False(t, apiResponse.IsNotSuccessful())
+}
+
 func TestUpdateQuota(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "PUT",
+		Path:   "/v2/quota_definitions/my-quota-guid",
+		Matcher: testnet.RequestBodyMatcher(`{
+			"name":"my-renamed-quota",
+			"memory_limit":2048,
+			"instance_memory_limit":512,
+			"total_routes":10,
+			"total_services":5,
+			"non_basic_services_allowed":false,
+			"app_instance_limit":-1
+		}`),
+		Response: testnet.TestResponse{Status: http.StatusCreated},
+	})
+
+	ts, handler, repo := createQuotaRepo(t, req)
+	defer ts.Close()
+
+	apiResponse := repo.Update(cf.Quota{
+		Guid:                "my-quota-guid",
+		Name:                "my-renamed-quota",
+		MemoryLimit:         2048,
+		InstanceMemoryLimit: 512,
+		RoutesLimit:         10,
+		ServicesLimit:       5,
+		AppInstanceLimit:    cf.UnlimitedQuota,
+	})
+	assert.True(t, handler.AllRequestsCalled())
+	assert.False(t, apiResponse.IsNotSuccessful())
+}
+
+func TestDeleteQuota(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "DELETE",
+		Path:     "/v2/quota_definitions/my-quota-guid",
+		Response: testnet.TestResponse{Status: http.StatusNoContent},
+	})
+
+	ts, handler, repo := createQuotaRepo(t, req)
+	defer ts.Close()
+
+	apiResponse := repo.Delete(cf.Quota{Guid: "my-quota-guid"})
+	assert.True(t, handler.AllRequestsCalled())
+	assert.False(t, apiResponse.IsNotSuccessful())
+}
+
+func TestAssignQuotaToOrg(t *testing.T) {
 	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
 		Method:   "PUT",
 		Path:     "/v2/organizations/my-org-guid",
@@ -48,7 +207,7 @@ func TestUpdateQuota(t *testing.T) {
 
 	quota := cf.Quota{Guid: "my-quota-guid"}
 	org := cf.Organization{Guid: "my-org-guid"}
-	apiResponse := repo.Update(org, quota)
+	apiResponse := repo.AssignQuotaToOrg(org, quota)
 	assert.True(t, handler.AllRequestsCalled())
 	assert.False(t, apiResponse.IsNotSuccessful())
 }
@@ -61,6 +220,7 @@ func createQuotaRepo(t *testing.T, req testnet.TestRequest) (ts *httptest.Server
 		Target:      ts.URL,
 	}
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	repo = NewCloudControllerQuotaRepository(config, gateway)
 	return
 }
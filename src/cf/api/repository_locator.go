@@ -11,6 +11,7 @@ type RepositoryLocator struct {
 	endpointRepo                    RemoteEndpointRepository
 	organizationRepo                CloudControllerOrganizationRepository
 	quotaRepo                       CloudControllerQuotaRepository
+	spaceQuotaRepo                  CloudControllerSpaceQuotaRepository
 	spaceRepo                       CloudControllerSpaceRepository
 	appRepo                         CloudControllerApplicationRepository
 	appBitsRepo                     CloudControllerApplicationBitsRepository
@@ -31,6 +32,7 @@ type RepositoryLocator struct {
 	userProvidedServiceInstanceRepo CCUserProvidedServiceInstanceRepository
 	buildpackRepo                   CloudControllerBuildpackRepository
 	buildpackBitsRepo               CloudControllerBuildpackBitsRepository
+	curlRepo                        CloudControllerCurlRepository
 }
 
 func NewRepositoryLocator(config *configuration.Configuration, configRepo configuration.ConfigurationRepository, gatewaysByName map[string]net.Gateway) (loc RepositoryLocator) {
@@ -38,6 +40,11 @@ func NewRepositoryLocator(config *configuration.Configuration, configRepo config
 	cloudControllerGateway := gatewaysByName["cloud-controller"]
 	uaaGateway := gatewaysByName["uaa"]
 
+	// every gateway follows the SSL validation choice saved by 'api' and 'login'
+	authGateway.SetConfiguration(config)
+	cloudControllerGateway.SetConfiguration(config)
+	uaaGateway.SetConfiguration(config)
+
 	loc.authRepo = NewUAAAuthenticationRepository(authGateway, configRepo)
 
 	// ensure gateway refreshers are set before passing them by value to repositories
@@ -52,10 +59,11 @@ func NewRepositoryLocator(config *configuration.Configuration, configRepo config
 	loc.authTokenRepo = NewCloudControllerServiceAuthTokenRepository(config, cloudControllerGateway)
 	loc.domainRepo = NewCloudControllerDomainRepository(config, cloudControllerGateway)
 	loc.endpointRepo = NewEndpointRepository(config, cloudControllerGateway, configRepo)
-	loc.logsRepo = NewLoggregatorLogsRepository(config, loc.endpointRepo)
+	loc.logsRepo = NewLoggregatorLogsRepository(config, cloudControllerGateway, loc.endpointRepo)
 	loc.organizationRepo = NewCloudControllerOrganizationRepository(config, cloudControllerGateway)
 	loc.passwordRepo = NewCloudControllerPasswordRepository(config, uaaGateway, loc.endpointRepo)
 	loc.quotaRepo = NewCloudControllerQuotaRepository(config, cloudControllerGateway)
+	loc.spaceQuotaRepo = NewCloudControllerSpaceQuotaRepository(config, cloudControllerGateway)
 	loc.routeRepo = NewCloudControllerRouteRepository(config, cloudControllerGateway, loc.domainRepo)
 	loc.stackRepo = NewCloudControllerStackRepository(config, cloudControllerGateway)
 	loc.serviceRepo = NewCloudControllerServiceRepository(config, cloudControllerGateway)
@@ -67,6 +75,7 @@ func NewRepositoryLocator(config *configuration.Configuration, configRepo config
 	loc.userRepo = NewCloudControllerUserRepository(config, uaaGateway, cloudControllerGateway, loc.endpointRepo)
 	loc.buildpackRepo = NewCloudControllerBuildpackRepository(config, cloudControllerGateway)
 	loc.buildpackBitsRepo = NewCloudControllerBuildpackBitsRepository(config, cloudControllerGateway, cf.ApplicationZipper{})
+	loc.curlRepo = NewCloudControllerCurlRepository(config, cloudControllerGateway)
 
 	return
 }
@@ -87,6 +96,10 @@ func (locator RepositoryLocator) GetQuotaRepository() QuotaRepository {
 	return locator.quotaRepo
 }
 
+func (locator RepositoryLocator) GetSpaceQuotaRepository() SpaceQuotaRepository {
+	return locator.spaceQuotaRepo
+}
+
 func (locator RepositoryLocator) GetSpaceRepository() SpaceRepository {
 	return locator.spaceRepo
 }
@@ -166,3 +179,7 @@ func (locator RepositoryLocator) GetBuildpackRepository() BuildpackRepository {
 func (locator RepositoryLocator) GetBuildpackBitsRepository() BuildpackBitsRepository {
 	return locator.buildpackBitsRepo
 }
+
+func (locator RepositoryLocator) GetCurlRepository() CurlRepository {
+	return locator.curlRepo
+}
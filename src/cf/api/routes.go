@@ -8,10 +8,6 @@ import (
 	"strings"
 )
 
-type PaginatedRouteResources struct {
-	Resources []RouteResource `json:"resources"`
-}
-
 type RouteResource struct {
 	Resource
 	Entity RouteEntity
@@ -49,12 +45,11 @@ func NewCloudControllerRouteRepository(config *configuration.Configuration, gate
 }
 
 func (repo CloudControllerRouteRepository) FindAll() (routes []cf.Route, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/routes?inline-relations-depth=1", repo.config.Target)
-	return repo.findAllWithPath(path)
+	return repo.findAllWithPath("/v2/routes?inline-relations-depth=1")
 }
 
 func (repo CloudControllerRouteRepository) FindByHost(host string) (route cf.Route, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/routes?inline-relations-depth=1&q=host%s", repo.config.Target, "%3A"+host)
+	path := fmt.Sprintf("/v2/routes?inline-relations-depth=1&q=host%s", "%3A"+host)
 	return repo.findOneWithPath(path)
 }
 
@@ -64,7 +59,7 @@ func (repo CloudControllerRouteRepository) FindByHostAndDomain(host, domainName
 		return
 	}
 
-	path := fmt.Sprintf("%s/v2/routes?inline-relations-depth=1&q=host%%3A%s%%3Bdomain_guid%%3A%s", repo.config.Target, host, domain.Guid)
+	path := fmt.Sprintf("/v2/routes?inline-relations-depth=1&q=host%%3A%s%%3Bdomain_guid%%3A%s", host, domain.Guid)
 	route, apiResponse = repo.findOneWithPath(path)
 	if apiResponse.IsNotSuccessful() {
 		return
@@ -90,37 +85,34 @@ func (repo CloudControllerRouteRepository) findOneWithPath(path string) (route c
 }
 
 func (repo CloudControllerRouteRepository) findAllWithPath(path string) (routes []cf.Route, apiResponse net.ApiResponse) {
-	routesResources := new(PaginatedRouteResources)
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, routesResources)
-	if apiResponse.IsNotSuccessful() {
-		return
-	}
-
-	for _, routeResponse := range routesResources.Resources {
-		domainResource := routeResponse.Entity.Domain
-		spaceResource := routeResponse.Entity.Space
-		appNames := []string{}
-
-		for _, appResource := range routeResponse.Entity.Apps {
-			appNames = append(appNames, appResource.Entity.Name)
-		}
-
-		routes = append(routes,
-			cf.Route{
-				Host: routeResponse.Entity.Host,
-				Guid: routeResponse.Metadata.Guid,
-				Domain: cf.Domain{
-					Name: domainResource.Entity.Name,
-					Guid: domainResource.Metadata.Guid,
+	apiResponse = repo.gateway.ListPaginatedResources(repo.config.Target, repo.config.AccessToken, path, RouteResource{},
+		func(resource interface{}) bool {
+			routeResponse := resource.(RouteResource)
+			domainResource := routeResponse.Entity.Domain
+			spaceResource := routeResponse.Entity.Space
+			appNames := []string{}
+
+			for _, appResource := range routeResponse.Entity.Apps {
+				appNames = append(appNames, appResource.Entity.Name)
+			}
+
+			routes = append(routes,
+				cf.Route{
+					Host: routeResponse.Entity.Host,
+					Guid: routeResponse.Metadata.Guid,
+					Domain: cf.Domain{
+						Name: domainResource.Entity.Name,
+						Guid: domainResource.Metadata.Guid,
+					},
+					Space: cf.Space{
+						Name: spaceResource.Entity.Name,
+						Guid: spaceResource.Metadata.Guid,
+					},
+					AppNames: appNames,
 				},
-				Space: cf.Space{
-					Name: spaceResource.Entity.Name,
-					Guid: spaceResource.Metadata.Guid,
-				},
-				AppNames: appNames,
-			},
-		)
-	}
+			)
+			return true
+		})
 	return
 }
 
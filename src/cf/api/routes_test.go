@@ -323,6 +323,7 @@ func createRoutesRepo(t *testing.T, request testnet.TestRequest) (ts *httptest.S
 	}
 
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	domainRepo = &testapi.FakeDomainRepository{}
 
 	repo = NewCloudControllerRouteRepository(config, gateway, domainRepo)
@@ -8,10 +8,6 @@ import (
 	"strings"
 )
 
-type PaginatedAuthTokenResources struct {
-	Resources []AuthTokenResource
-}
-
 type AuthTokenResource struct {
 	Resource
 	Entity AuthTokenEntity
@@ -42,12 +38,11 @@ func NewCloudControllerServiceAuthTokenRepository(config *configuration.Configur
 }
 
 func (repo CloudControllerServiceAuthTokenRepository) FindAll() (authTokens []cf.ServiceAuthToken, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/service_auth_tokens", repo.config.Target)
-	return repo.findAllWithPath(path)
+	return repo.findAllWithPath("/v2/service_auth_tokens")
 }
 
 func (repo CloudControllerServiceAuthTokenRepository) FindByLabelAndProvider(label, provider string) (authToken cf.ServiceAuthToken, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/service_auth_tokens?q=label:%s;provider:%s", repo.config.Target, label, provider)
+	path := fmt.Sprintf("/v2/service_auth_tokens?q=label:%s;provider:%s", label, provider)
 	authTokens, apiResponse := repo.findAllWithPath(path)
 	if apiResponse.IsNotSuccessful() {
 		return
@@ -63,20 +58,16 @@ func (repo CloudControllerServiceAuthTokenRepository) FindByLabelAndProvider(lab
 }
 
 func (repo CloudControllerServiceAuthTokenRepository) findAllWithPath(path string) (authTokens []cf.ServiceAuthToken, apiResponse net.ApiResponse) {
-	resources := new(PaginatedAuthTokenResources)
-
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resources)
-	if apiResponse.IsNotSuccessful() {
-		return
-	}
-
-	for _, resource := range resources.Resources {
-		authTokens = append(authTokens, cf.ServiceAuthToken{
-			Guid:     resource.Metadata.Guid,
-			Label:    resource.Entity.Label,
-			Provider: resource.Entity.Provider,
+	apiResponse = repo.gateway.ListPaginatedResources(repo.config.Target, repo.config.AccessToken, path, AuthTokenResource{},
+		func(resource interface{}) bool {
+			r := resource.(AuthTokenResource)
+			authTokens = append(authTokens, cf.ServiceAuthToken{
+				Guid:     r.Metadata.Guid,
+				Label:    r.Entity.Label,
+				Provider: r.Entity.Provider,
+			})
+			return true
 		})
-	}
 	return
 }
 
@@ -160,6 +160,7 @@ func createServiceAuthTokenRepo(t *testing.T, request testnet.TestRequest) (ts *
 		AccessToken: "BEARER my_access_token",
 	}
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 
 	repo = NewCloudControllerServiceAuthTokenRepository(config, gateway)
 	return
@@ -11,6 +11,7 @@ import (
 type ServiceBindingRepository interface {
 	Create(instance cf.ServiceInstance, app cf.Application) (apiResponse net.ApiResponse)
 	Delete(instance cf.ServiceInstance, app cf.Application) (found bool, apiResponse net.ApiResponse)
+	ListForInstance(instance cf.ServiceInstance) (bindings []cf.ServiceBinding, apiResponse net.ApiResponse)
 }
 
 type CloudControllerServiceBindingRepository struct {
@@ -52,3 +53,22 @@ func (repo CloudControllerServiceBindingRepository) Delete(instance cf.ServiceIn
 	apiResponse = repo.gateway.DeleteResource(path, repo.config.AccessToken)
 	return
 }
+
+func (repo CloudControllerServiceBindingRepository) ListForInstance(instance cf.ServiceInstance) (bindings []cf.ServiceBinding, apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("/v2/service_instances/%s/service_bindings?inline-relations-depth=1", instance.Guid)
+
+	apiResponse = repo.gateway.ListPaginatedResources(repo.config.Target, repo.config.AccessToken, path, ServiceBindingResource{},
+		func(resource interface{}) bool {
+			r := resource.(ServiceBindingResource)
+			bindings = append(bindings, cf.ServiceBinding{
+				Guid:        r.Metadata.Guid,
+				Url:         r.Metadata.Url,
+				AppGuid:     r.Entity.AppGuid,
+				AppName:     r.Entity.App.Entity.Name,
+				Credentials: r.Entity.Credentials,
+			})
+			return true
+		})
+
+	return
+}
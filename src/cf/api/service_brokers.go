@@ -8,10 +8,6 @@ import (
 	"strings"
 )
 
-type PaginatedServiceBrokerResources struct {
-	ServiceBrokers []ServiceBrokerResource `json:"resources"`
-}
-
 type ServiceBrokerResource struct {
 	Resource
 	Entity ServiceBrokerEntity
@@ -46,11 +42,11 @@ func NewCloudControllerServiceBrokerRepository(config *configuration.Configurati
 }
 
 func (repo CloudControllerServiceBrokerRepository) FindAll() (serviceBrokers []cf.ServiceBroker, apiResponse net.ApiResponse) {
-	return repo.findAllWithPath(fmt.Sprintf("%s/v2/service_brokers", repo.config.Target))
+	return repo.findAllWithPath("/v2/service_brokers")
 }
 
 func (repo CloudControllerServiceBrokerRepository) FindByName(name string) (serviceBroker cf.ServiceBroker, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/service_brokers?q=name%%3A%s", repo.config.Target, name)
+	path := fmt.Sprintf("/v2/service_brokers?q=name%%3A%s", name)
 	serviceBrokers, apiResponse := repo.findAllWithPath(path)
 	if apiResponse.IsNotSuccessful() {
 		return
@@ -66,24 +62,18 @@ func (repo CloudControllerServiceBrokerRepository) FindByName(name string) (serv
 }
 
 func (repo CloudControllerServiceBrokerRepository) findAllWithPath(path string) (serviceBrokers []cf.ServiceBroker, apiResponse net.ApiResponse) {
-	resources := new(PaginatedServiceBrokerResources)
-
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resources)
-	if apiResponse.IsNotSuccessful() {
-		return
-	}
-
-	for _, resource := range resources.ServiceBrokers {
-		serviceBroker := cf.ServiceBroker{
-			Name:     resource.Entity.Name,
-			Guid:     resource.Metadata.Guid,
-			Url:      resource.Entity.Url,
-			Username: resource.Entity.Username,
-			Password: resource.Entity.Password,
-		}
-
-		serviceBrokers = append(serviceBrokers, serviceBroker)
-	}
+	apiResponse = repo.gateway.ListPaginatedResources(repo.config.Target, repo.config.AccessToken, path, ServiceBrokerResource{},
+		func(resource interface{}) bool {
+			r := resource.(ServiceBrokerResource)
+			serviceBrokers = append(serviceBrokers, cf.ServiceBroker{
+				Name:     r.Entity.Name,
+				Guid:     r.Metadata.Guid,
+				Url:      r.Entity.Url,
+				Username: r.Entity.Username,
+				Password: r.Entity.Password,
+			})
+			return true
+		})
 	return
 }
 
@@ -225,6 +225,7 @@ func createServiceBrokerRepo(t *testing.T, request testnet.TestRequest) (ts *htt
 	}
 
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	repo = NewCloudControllerServiceBrokerRepository(config, gateway)
 	return
 }
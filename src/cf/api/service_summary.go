@@ -36,6 +36,7 @@ type ServiceOfferingSummary struct {
 
 type ServiceSummaryRepository interface {
 	GetSummariesInCurrentSpace() (instances []cf.ServiceInstance, apiResponse net.ApiResponse)
+	GetSummariesInSpace(space cf.Space) (instances []cf.ServiceInstance, apiResponse net.ApiResponse)
 }
 
 type CloudControllerServiceSummaryRepository struct {
@@ -50,7 +51,11 @@ func NewCloudControllerServiceSummaryRepository(config *configuration.Configurat
 }
 
 func (repo CloudControllerServiceSummaryRepository) GetSummariesInCurrentSpace() (instances []cf.ServiceInstance, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/spaces/%s/summary", repo.config.Target, repo.config.Space.Guid)
+	return repo.GetSummariesInSpace(repo.config.Space)
+}
+
+func (repo CloudControllerServiceSummaryRepository) GetSummariesInSpace(space cf.Space) (instances []cf.ServiceInstance, apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/spaces/%s/summary", repo.config.Target, space.Guid)
 	response := new(ServiceInstancesSummaries)
 
 	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, response)
@@ -83,6 +83,7 @@ func createServiceSummaryRepo(t *testing.T, req testnet.TestRequest) (ts *httpte
 		Space:       cf.Space{Guid: "my-space-guid"},
 	}
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	repo = NewCloudControllerServiceSummaryRepository(config, gateway)
 	return
 }
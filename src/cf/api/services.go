@@ -1,17 +1,16 @@
 package api
 
 import (
+	"bytes"
 	"cf"
 	"cf/configuration"
 	"cf/net"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 )
 
-type PaginatedServiceOfferingResources struct {
-	Resources []ServiceOfferingResource
-}
-
 type ServiceOfferingResource struct {
 	Metadata Metadata
 	Entity   ServiceOfferingEntity
@@ -49,6 +48,24 @@ type ServiceInstanceEntity struct {
 	Name            string
 	ServiceBindings []ServiceBindingResource `json:"service_bindings"`
 	ServicePlan     ServicePlanResource      `json:"service_plan"`
+	LastOperation   LastOperationEntity      `json:"last_operation"`
+}
+
+type LastOperationEntity struct {
+	Type        string
+	State       string
+	Description string
+}
+
+func (entity LastOperationEntity) ToModel() cf.LastOperation {
+	return cf.LastOperation{Type: entity.Type, State: entity.State, Description: entity.Description}
+}
+
+type serviceInstanceRequest struct {
+	Name            string                 `json:"name,omitempty"`
+	ServicePlanGuid string                 `json:"service_plan_guid,omitempty"`
+	SpaceGuid       string                 `json:"space_guid,omitempty"`
+	Parameters      map[string]interface{} `json:"parameters,omitempty"`
 }
 
 type ServiceBindingResource struct {
@@ -57,17 +74,25 @@ type ServiceBindingResource struct {
 }
 
 type ServiceBindingEntity struct {
-	AppGuid string `json:"app_guid"`
+	AppGuid     string                 `json:"app_guid"`
+	App         ApplicationResource    `json:"app"`
+	Credentials map[string]interface{} `json:"credentials"`
 }
 
 type ServiceRepository interface {
 	GetServiceOfferings() (offerings []cf.ServiceOffering, apiResponse net.ApiResponse)
 	FindInstanceByName(name string) (instance cf.ServiceInstance, apiResponse net.ApiResponse)
-	CreateServiceInstance(name string, plan cf.ServicePlan) (identicalAlreadyExists bool, apiResponse net.ApiResponse)
+	CreateServiceInstance(name string, plan cf.ServicePlan, params map[string]interface{}, onProgress func(cf.LastOperation)) (identicalAlreadyExists bool, apiResponse net.ApiResponse)
+	UpdateServiceInstance(instance cf.ServiceInstance, plan cf.ServicePlan, params map[string]interface{}, onProgress func(cf.LastOperation)) (apiResponse net.ApiResponse)
 	RenameService(instance cf.ServiceInstance, newName string) (apiResponse net.ApiResponse)
 	DeleteService(instance cf.ServiceInstance) (apiResponse net.ApiResponse)
 }
 
+var (
+	lastOperationPollInterval = 5 * time.Second
+	lastOperationTimeout      = 30 * time.Minute
+)
+
 type CloudControllerServiceRepository struct {
 	config  *configuration.Configuration
 	gateway net.Gateway
@@ -80,33 +105,30 @@ func NewCloudControllerServiceRepository(config *configuration.Configuration, ga
 }
 
 func (repo CloudControllerServiceRepository) GetServiceOfferings() (offerings []cf.ServiceOffering, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/services?inline-relations-depth=1", repo.config.Target)
+	path := "/v2/services?inline-relations-depth=1"
 	spaceGuid := repo.config.Space.Guid
 
 	if spaceGuid != "" {
-		path = fmt.Sprintf("%s/v2/spaces/%s/services?inline-relations-depth=1", repo.config.Target, spaceGuid)
+		path = fmt.Sprintf("/v2/spaces/%s/services?inline-relations-depth=1", spaceGuid)
 	}
 
-	resources := new(PaginatedServiceOfferingResources)
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resources)
-	if apiResponse.IsNotSuccessful() {
-		return
-	}
-
-	for _, r := range resources.Resources {
-		plans := []cf.ServicePlan{}
-		for _, p := range r.Entity.ServicePlans {
-			plans = append(plans, cf.ServicePlan{Name: p.Entity.Name, Guid: p.Metadata.Guid})
-		}
-		offerings = append(offerings, cf.ServiceOffering{
-			Label:       r.Entity.Label,
-			Version:     r.Entity.Version,
-			Provider:    r.Entity.Provider,
-			Description: r.Entity.Description,
-			Guid:        r.Metadata.Guid,
-			Plans:       plans,
+	apiResponse = repo.gateway.ListPaginatedResources(repo.config.Target, repo.config.AccessToken, path, ServiceOfferingResource{},
+		func(resource interface{}) bool {
+			r := resource.(ServiceOfferingResource)
+			plans := []cf.ServicePlan{}
+			for _, p := range r.Entity.ServicePlans {
+				plans = append(plans, cf.ServicePlan{Name: p.Entity.Name, Guid: p.Metadata.Guid})
+			}
+			offerings = append(offerings, cf.ServiceOffering{
+				Label:       r.Entity.Label,
+				Version:     r.Entity.Version,
+				Provider:    r.Entity.Provider,
+				Description: r.Entity.Description,
+				Guid:        r.Metadata.Guid,
+				Plans:       plans,
+			})
+			return true
 		})
-	}
 
 	return
 }
@@ -138,14 +160,16 @@ func (repo CloudControllerServiceRepository) FindInstanceByName(name string) (in
 	instance.ServicePlan.ServiceOffering.Label = serviceOfferingEntity.Label
 	instance.ServicePlan.ServiceOffering.DocumentationUrl = serviceOfferingEntity.DocumentationUrl
 	instance.ServicePlan.ServiceOffering.Description = serviceOfferingEntity.Description
+	instance.LastOperation = resource.Entity.LastOperation.ToModel()
 
 	instance.ServiceBindings = []cf.ServiceBinding{}
 
 	for _, bindingResource := range resource.Entity.ServiceBindings {
 		newBinding := cf.ServiceBinding{
-			Url:     bindingResource.Metadata.Url,
-			Guid:    bindingResource.Metadata.Guid,
-			AppGuid: bindingResource.Entity.AppGuid,
+			Url:         bindingResource.Metadata.Url,
+			Guid:        bindingResource.Metadata.Guid,
+			AppGuid:     bindingResource.Entity.AppGuid,
+			Credentials: bindingResource.Entity.Credentials,
 		}
 		instance.ServiceBindings = append(instance.ServiceBindings, newBinding)
 	}
@@ -153,14 +177,25 @@ func (repo CloudControllerServiceRepository) FindInstanceByName(name string) (in
 	return
 }
 
-func (repo CloudControllerServiceRepository) CreateServiceInstance(name string, plan cf.ServicePlan) (identicalAlreadyExists bool, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/service_instances", repo.config.Target)
-	data := fmt.Sprintf(
-		`{"name":"%s","service_plan_guid":"%s","space_guid":"%s"}`,
-		name, plan.Guid, repo.config.Space.Guid,
-	)
+func (repo CloudControllerServiceRepository) CreateServiceInstance(name string, plan cf.ServicePlan, params map[string]interface{}, onProgress func(cf.LastOperation)) (identicalAlreadyExists bool, apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/service_instances?accepts_incomplete=true", repo.config.Target)
+	body, err := json.Marshal(serviceInstanceRequest{
+		Name:            name,
+		ServicePlanGuid: plan.Guid,
+		SpaceGuid:       repo.config.Space.Guid,
+		Parameters:      params,
+	})
+	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Error encoding service parameters", err)
+		return
+	}
 
-	apiResponse = repo.gateway.CreateResource(path, repo.config.AccessToken, strings.NewReader(data))
+	resource := new(ServiceInstanceResource)
+	apiResponse = repo.gateway.CreateResourceForResponse(path, repo.config.AccessToken, bytes.NewReader(body), resource)
+	if apiResponse.IsSuccessful() {
+		apiResponse = repo.waitForLastOperation(*resource, onProgress)
+		return
+	}
 
 	if apiResponse.IsNotSuccessful() && apiResponse.ErrorCode == cf.SERVICE_INSTANCE_NAME_TAKEN {
 
@@ -176,6 +211,62 @@ func (repo CloudControllerServiceRepository) CreateServiceInstance(name string,
 	return
 }
 
+func (repo CloudControllerServiceRepository) UpdateServiceInstance(instance cf.ServiceInstance, plan cf.ServicePlan, params map[string]interface{}, onProgress func(cf.LastOperation)) (apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/service_instances/%s?accepts_incomplete=true", repo.config.Target, instance.Guid)
+	body, err := json.Marshal(serviceInstanceRequest{
+		ServicePlanGuid: plan.Guid,
+		Parameters:      params,
+	})
+	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Error encoding service parameters", err)
+		return
+	}
+
+	resource := new(ServiceInstanceResource)
+	apiResponse = repo.gateway.UpdateResourceForResponse(path, repo.config.AccessToken, bytes.NewReader(body), resource)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	return repo.waitForLastOperation(*resource, onProgress)
+}
+
+// waitForLastOperation polls an instance whose broker accepted the request
+// asynchronously until the operation either succeeds or fails
+func (repo CloudControllerServiceRepository) waitForLastOperation(resource ServiceInstanceResource, onProgress func(cf.LastOperation)) (apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/service_instances/%s", repo.config.Target, resource.Metadata.Guid)
+	startedAt := time.Now()
+
+	for {
+		lastOperation := resource.Entity.LastOperation.ToModel()
+		if onProgress != nil && lastOperation.State != "" {
+			onProgress(lastOperation)
+		}
+
+		switch lastOperation.State {
+		case cf.LastOperationFailed:
+			apiResponse = net.NewApiResponseWithMessage("Service broker failed to %s the service instance: %s", lastOperation.Type, lastOperation.Description)
+			return
+		case cf.LastOperationInProgress:
+		default:
+			return
+		}
+
+		if time.Since(startedAt) > lastOperationTimeout {
+			apiResponse = net.NewApiResponseWithMessage("Timed out after %s waiting for the service broker to %s the service instance.", lastOperationTimeout, lastOperation.Type)
+			return
+		}
+
+		time.Sleep(lastOperationPollInterval)
+
+		resource = ServiceInstanceResource{}
+		apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, &resource)
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+	}
+}
+
 func (repo CloudControllerServiceRepository) RenameService(instance cf.ServiceInstance, newName string) (apiResponse net.ApiResponse) {
 	body := fmt.Sprintf(`{"name":"%s"}`, newName)
 	path := fmt.Sprintf("%s/v2/service_instances/%s", repo.config.Target, instance.Guid)
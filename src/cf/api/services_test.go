@@ -4,12 +4,14 @@ import (
 	"cf"
 	"cf/configuration"
 	"cf/net"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
 	testapi "testhelpers/api"
 	testnet "testhelpers/net"
 	"testing"
+	"time"
 )
 
 var multipleOfferingsResponse = testnet.TestResponse{Status: http.StatusOK, Body: `
@@ -113,19 +115,154 @@ func TestGetServiceOfferingsWhenTargetingASpace(t *testing.T) {
 
 func TestCreateServiceInstance(t *testing.T) {
 	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
-		Method:   "POST",
-		Path:     "/v2/service_instances",
-		Matcher:  testnet.RequestBodyMatcher(`{"name":"instance-name","service_plan_guid":"plan-guid","space_guid":"my-space-guid"}`),
-		Response: testnet.TestResponse{Status: http.StatusCreated},
+		Method:  "POST",
+		Path:    "/v2/service_instances?accepts_incomplete=true",
+		Matcher: testnet.RequestBodyMatcher(`{"name":"instance-name","service_plan_guid":"plan-guid","space_guid":"my-space-guid"}`),
+		Response: testnet.TestResponse{Status: http.StatusCreated, Body: `{
+			"metadata": { "guid": "instance-guid" },
+			"entity": { "name": "instance-name", "last_operation": { "type": "create", "state": "succeeded" } }
+		}`},
 	})
 
 	ts, handler, repo := createServiceRepo(t, []testnet.TestRequest{req})
 	defer ts.Close()
 
-	identicalAlreadyExists, apiResponse := repo.CreateServiceInstance("instance-name", cf.ServicePlan{Guid: "plan-guid"})
+	operations := []cf.LastOperation{}
+	identicalAlreadyExists, apiResponse := repo.CreateServiceInstance("instance-name", cf.ServicePlan{Guid: "plan-guid"}, nil, func(op cf.LastOperation) {
+		operations = append(operations, op)
+	})
 	assert.True(t, handler.AllRequestsCalled())
 	assert.True(t, apiResponse.IsSuccessful())
 	assert.Equal(t, identicalAlreadyExists, false)
+	assert.Equal(t, operations, []cf.LastOperation{{Type: "create", State: "succeeded"}})
+}
+
+func TestCreateServiceInstanceWithParameters(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "POST",
+		Path:     "/v2/service_instances?accepts_incomplete=true",
+		Matcher:  testnet.RequestBodyMatcher(`{"name":"instance-name","service_plan_guid":"plan-guid","space_guid":"my-space-guid","parameters":{"storage_gb":20,"version":"9.3"}}`),
+		Response: testnet.TestResponse{Status: http.StatusCreated, Body: `{"metadata": { "guid": "instance-guid" }, "entity": {}}`},
+	})
+
+	ts, handler, repo := createServiceRepo(t, []testnet.TestRequest{req})
+	defer ts.Close()
+
+	params := map[string]interface{}{"storage_gb": 20, "version": "9.3"}
+	_, apiResponse := repo.CreateServiceInstance("instance-name", cf.ServicePlan{Guid: "plan-guid"}, params, nil)
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
+func lastOperationReq(state, description string) testnet.TestRequest {
+	return testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/service_instances/instance-guid",
+		Response: testnet.TestResponse{Status: http.StatusOK, Body: fmt.Sprintf(`{
+			"metadata": { "guid": "instance-guid" },
+			"entity": { "last_operation": { "type": "create", "state": "%s", "description": "%s" } }
+		}`, state, description)},
+	})
+}
+
+var asyncCreateServiceInstanceReq = testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+	Method: "POST",
+	Path:   "/v2/service_instances?accepts_incomplete=true",
+	Response: testnet.TestResponse{Status: http.StatusAccepted, Body: `{
+		"metadata": { "guid": "instance-guid" },
+		"entity": { "last_operation": { "type": "create", "state": "in progress", "description": "queued" } }
+	}`},
+})
+
+func TestCreateServiceInstancePollsAsynchronousOperations(t *testing.T) {
+	lastOperationPollInterval = 0
+	defer func() { lastOperationPollInterval = 5 * time.Second }()
+
+	ts, handler, repo := createServiceRepo(t, []testnet.TestRequest{
+		asyncCreateServiceInstanceReq,
+		lastOperationReq("in progress", "provisioning"),
+		lastOperationReq("succeeded", "done"),
+	})
+	defer ts.Close()
+
+	descriptions := []string{}
+	_, apiResponse := repo.CreateServiceInstance("instance-name", cf.ServicePlan{Guid: "plan-guid"}, nil, func(op cf.LastOperation) {
+		descriptions = append(descriptions, op.Description)
+	})
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, descriptions, []string{"queued", "provisioning", "done"})
+}
+
+func TestCreateServiceInstanceWhenAsynchronousOperationFails(t *testing.T) {
+	lastOperationPollInterval = 0
+	defer func() { lastOperationPollInterval = 5 * time.Second }()
+
+	ts, handler, repo := createServiceRepo(t, []testnet.TestRequest{
+		asyncCreateServiceInstanceReq,
+		lastOperationReq("failed", "out of capacity"),
+	})
+	defer ts.Close()
+
+	_, apiResponse := repo.CreateServiceInstance("instance-name", cf.ServicePlan{Guid: "plan-guid"}, nil, nil)
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Contains(t, apiResponse.Message, "out of capacity")
+}
+
+func TestCreateServiceInstanceTimesOutWaitingForOperation(t *testing.T) {
+	lastOperationTimeout = 0
+	defer func() { lastOperationTimeout = 30 * time.Minute }()
+
+	ts, handler, repo := createServiceRepo(t, []testnet.TestRequest{asyncCreateServiceInstanceReq})
+	defer ts.Close()
+
+	_, apiResponse := repo.CreateServiceInstance("instance-name", cf.ServicePlan{Guid: "plan-guid"}, nil, nil)
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Contains(t, apiResponse.Message, "Timed out")
+}
+
+func TestUpdateServiceInstance(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:  "PUT",
+		Path:    "/v2/service_instances/instance-guid?accepts_incomplete=true",
+		Matcher: testnet.RequestBodyMatcher(`{"service_plan_guid":"bigger-plan-guid","parameters":{"storage_gb":50}}`),
+		Response: testnet.TestResponse{Status: http.StatusCreated, Body: `{
+			"metadata": { "guid": "instance-guid" },
+			"entity": { "last_operation": { "type": "update", "state": "succeeded" } }
+		}`},
+	})
+
+	ts, handler, repo := createServiceRepo(t, []testnet.TestRequest{req})
+	defer ts.Close()
+
+	instance := cf.ServiceInstance{Guid: "instance-guid"}
+	params := map[string]interface{}{"storage_gb": 50}
+	apiResponse := repo.UpdateServiceInstance(instance, cf.ServicePlan{Guid: "bigger-plan-guid"}, params, nil)
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
+func TestUpdateServiceInstanceWithOnlyParameters(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "PUT",
+		Path:     "/v2/service_instances/instance-guid?accepts_incomplete=true",
+		Matcher:  testnet.RequestBodyMatcher(`{"parameters":{"storage_gb":50}}`),
+		Response: testnet.TestResponse{Status: http.StatusCreated, Body: `{"metadata": { "guid": "instance-guid" }, "entity": {}}`},
+	})
+
+	ts, handler, repo := createServiceRepo(t, []testnet.TestRequest{req})
+	defer ts.Close()
+
+	instance := cf.ServiceInstance{Guid: "instance-guid"}
+	params := map[string]interface{}{"storage_gb": 50}
+	apiResponse := repo.UpdateServiceInstance(instance, cf.ServicePlan{}, params, nil)
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
 }
 
 func TestCreateServiceInstanceWhenIdenticalServiceAlreadyExists(t *testing.T) {
@@ -143,7 +280,7 @@ func TestCreateServiceInstanceWhenIdenticalServiceAlreadyExists(t *testing.T) {
 	defer ts.Close()
 
 	servicePlan := cf.ServicePlan{Guid: "plan-guid", Name: "plan-name"}
-	identicalAlreadyExists, apiResponse := repo.CreateServiceInstance("my-service", servicePlan)
+	identicalAlreadyExists, apiResponse := repo.CreateServiceInstance("my-service", servicePlan, nil, nil)
 
 	assert.True(t, handler.AllRequestsCalled())
 	assert.False(t, apiResponse.IsNotSuccessful())
@@ -165,7 +302,7 @@ func TestCreateServiceInstanceWhenDifferentServiceAlreadyExists(t *testing.T) {
 	defer ts.Close()
 
 	servicePlan := cf.ServicePlan{Guid: "different-plan-guid", Name: "plan-name"}
-	identicalAlreadyExists, apiResponse := repo.CreateServiceInstance("my-service", servicePlan)
+	identicalAlreadyExists, apiResponse := repo.CreateServiceInstance("my-service", servicePlan, nil, nil)
 
 	assert.True(t, handler.AllRequestsCalled())
 	assert.True(t, apiResponse.IsNotSuccessful())
@@ -342,6 +479,9 @@ func createServiceRepoWithConfig(t *testing.T, reqs []testnet.TestRequest, confi
 	}
 
 	gateway := net.NewCloudControllerGateway()
+	if ts != nil {
+		gateway.SetTrustedCerts(ts.TLS.Certificates)
+	}
 	repo = NewCloudControllerServiceRepository(config, gateway)
 	return
 }
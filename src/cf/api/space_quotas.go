@@ -0,0 +1,99 @@
+package api
+
+import (
+	"bytes"
+	"cf"
+	"cf/configuration"
+	"cf/net"
+	"encoding/json"
+	"fmt"
+)
+
+type SpaceQuotaResource struct {
+	Resource
+	Entity SpaceQuotaEntity
+}
+
+type SpaceQuotaEntity struct {
+	QuotaEntity
+	OrganizationGuid string `json:"organization_guid"`
+}
+
+func (resource SpaceQuotaResource) ToModel() (quota cf.SpaceQuota) {
+	quota.Quota = QuotaResource{Resource: resource.Resource, Entity: resource.Entity.QuotaEntity}.ToModel()
+	quota.OrgGuid = resource.Entity.OrganizationGuid
+	return
+}
+
+type SpaceQuotaRepository interface {
+	FindByName(name string) (quota cf.SpaceQuota, apiResponse net.ApiResponse)
+	FindByGuid(guid string) (quota cf.SpaceQuota, apiResponse net.ApiResponse)
+	Create(quota cf.SpaceQuota) (apiResponse net.ApiResponse)
+	AssignQuotaToSpace(space cf.Space, quota cf.SpaceQuota) (apiResponse net.ApiResponse)
+}
+
+type CloudControllerSpaceQuotaRepository struct {
+	config  *configuration.Configuration
+	gateway net.Gateway
+}
+
+func NewCloudControllerSpaceQuotaRepository(config *configuration.Configuration, gateway net.Gateway) (repo CloudControllerSpaceQuotaRepository) {
+	repo.config = config
+	repo.gateway = gateway
+	return
+}
+
+// FindByName looks for the space quota among those owned by the targeted org
+func (repo CloudControllerSpaceQuotaRepository) FindByName(name string) (quota cf.SpaceQuota, apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("/v2/organizations/%s/space_quota_definitions", repo.config.Organization.Guid)
+	found := false
+
+	apiResponse = repo.gateway.ListPaginatedResources(repo.config.Target, repo.config.AccessToken, path, SpaceQuotaResource{},
+		func(resource interface{}) bool {
+			r := resource.(SpaceQuotaResource)
+			if r.Entity.Name != name {
+				return true
+			}
+			quota = r.ToModel()
+			found = true
+			return false
+		})
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	if !found {
+		apiResponse = net.NewNotFoundApiResponse("Space quota %s not found", name)
+	}
+	return
+}
+
+func (repo CloudControllerSpaceQuotaRepository) FindByGuid(guid string) (quota cf.SpaceQuota, apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/space_quota_definitions/%s", repo.config.Target, guid)
+	resource := new(SpaceQuotaResource)
+	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resource)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	quota = resource.ToModel()
+	return
+}
+
+func (repo CloudControllerSpaceQuotaRepository) Create(quota cf.SpaceQuota) (apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/space_quota_definitions", repo.config.Target)
+	body, err := json.Marshal(SpaceQuotaEntity{
+		QuotaEntity:      newQuotaEntity(quota.Quota),
+		OrganizationGuid: quota.OrgGuid,
+	})
+	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Error encoding space quota", err)
+		return
+	}
+	return repo.gateway.CreateResource(path, repo.config.AccessToken, bytes.NewReader(body))
+}
+
+func (repo CloudControllerSpaceQuotaRepository) AssignQuotaToSpace(space cf.Space, quota cf.SpaceQuota) (apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/space_quota_definitions/%s/spaces/%s", repo.config.Target, quota.Guid, space.Guid)
+	return repo.gateway.UpdateResource(path, repo.config.AccessToken, nil)
+}
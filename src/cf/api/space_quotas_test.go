@@ -0,0 +1,167 @@
+package api
+
+import (
+	"cf"
+	"cf/configuration"
+	"cf/net"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	testapi "testhelpers/api"
+	testnet "testhelpers/net"
+	"testing"
+)
+
+func TestFindSpaceQuotaByName(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/organizations/my-org-guid/space_quota_definitions",
+		Response: testnet.TestResponse{
+			Status: http.StatusOK,
+			Body: `{"resources": [
+				{
+				  "metadata": { "guid": "other-space-quota-guid" },
+				  "entity": { "name": "other-space-quota", "organization_guid": "my-org-guid" }
+				},
+				{
+				  "metadata": { "guid": "my-space-quota-guid" },
+				  "entity": {
+				    "name": "my-space-quota",
+				    "organization_guid": "my-org-guid",
+				    "memory_limit": 2048,
+				    "instance_memory_limit": -1,
+				    "total_routes": 10,
+				    "total_services": 5,
+				    "non_basic_services_allowed": false,
+				    "app_instance_limit": -1
+				  }
+				}
+			]}`},
+	})
+
+	ts, handler, repo := createSpaceQuotaRepo(t, req)
+	defer ts.Close()
+
+	quota, apiResponse := repo.FindByName("my-space-quota")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.False(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, quota, cf.SpaceQuota{
+		Quota: cf.Quota{
+			Guid:                "my-space-quota-guid",
+			Name:                "my-space-quota",
+			MemoryLimit:         2048,
+			InstanceMemoryLimit: cf.UnlimitedQuota,
+			RoutesLimit:         10,
+			ServicesLimit:       5,
+			AppInstanceLimit:    cf.UnlimitedQuota,
+		},
+		OrgGuid: "my-org-guid",
+	})
+}
+
+func TestFindSpaceQuotaByNameWhenNotFound(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "GET",
+		Path:     "/v2/organizations/my-org-guid/space_quota_definitions",
+		Response: testnet.TestResponse{Status: http.StatusOK, Body: `{"resources": []}`},
+	})
+
+	ts, handler, repo := createSpaceQuotaRepo(t, req)
+	defer ts.Close()
+
+	_, apiResponse := repo.FindByName("my-space-quota")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsNotFound())
+}
+
+func TestFindSpaceQuotaByGuid(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/space_quota_definitions/my-space-quota-guid",
+		Response: testnet.TestResponse{
+			Status: http.StatusOK,
+			Body: `{
+			  "metadata": { "guid": "my-space-quota-guid" },
+			  "entity": { "name": "my-space-quota", "organization_guid": "my-org-guid", "memory_limit": 2048, "app_instance_limit": 8 }
+			}`},
+	})
+
+	ts, handler, repo := createSpaceQuotaRepo(t, req)
+	defer ts.Close()
+
+	quota, apiResponse := repo.FindByGuid("my-space-quota-guid")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.False(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, quota.Guid, "my-space-quota-guid")
+	assert.Equal(t, quota.Name, "my-space-quota")
+	assert.Equal(t, quota.OrgGuid, "my-org-guid")
+	assert.Equal(t, quota.MemoryLimit, uint64(2048))
+	assert.Equal(t, quota.AppInstanceLimit, 8)
+}
+
+func TestCreateSpaceQuota(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "POST",
+		Path:   "/v2/space_quota_definitions",
+		Matcher: testnet.RequestBodyMatcher(`{
+			"name":"my-space-quota",
+			"memory_limit":2048,
+			"instance_memory_limit":-1,
+			"total_routes":10,
+			"total_services":5,
+			"non_basic_services_allowed":true,
+			"app_instance_limit":-1,
+			"organization_guid":"my-org-guid"
+		}`),
+		Response: testnet.TestResponse{Status: http.StatusCreated},
+	})
+
+	ts, handler, repo := createSpaceQuotaRepo(t, req)
+	defer ts.Close()
+
+	apiResponse := repo.Create(cf.SpaceQuota{
+		Quota: cf.Quota{
+			Name:                    "my-space-quota",
+			MemoryLimit:             2048,
+			InstanceMemoryLimit:     cf.UnlimitedQuota,
+			RoutesLimit:             10,
+			ServicesLimit:           5,
+			NonBasicServicesAllowed: true,
+			AppInstanceLimit:        cf.UnlimitedQuota,
+		},
+		OrgGuid: "my-org-guid",
+	})
+	assert.True(t, handler.AllRequestsCalled())
+	assert.False(t, apiResponse.IsNotSuccessful())
+}
+
+func TestAssignQuotaToSpace(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "PUT",
+		Path:     "/v2/space_quota_definitions/my-space-quota-guid/spaces/my-space-guid",
+		Response: testnet.TestResponse{Status: http.StatusCreated},
+	})
+
+	ts, handler, repo := createSpaceQuotaRepo(t, req)
+	defer ts.Close()
+
+	quota := cf.SpaceQuota{Quota: cf.Quota{Guid: "my-space-quota-guid"}}
+	space := cf.Space{Guid: "my-space-guid"}
+	apiResponse := repo.AssignQuotaToSpace(space, quota)
+	assert.True(t, handler.AllRequestsCalled())
+	assert.False(t, apiResponse.IsNotSuccessful())
+}
+
+func createSpaceQuotaRepo(t *testing.T, req testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo SpaceQuotaRepository) {
+	ts, handler = testnet.NewTLSServer(t, []testnet.TestRequest{req})
+
+	config := &configuration.Configuration{
+		AccessToken:  "BEARER my_access_token",
+		Target:       ts.URL,
+		Organization: cf.Organization{Guid: "my-org-guid"},
+	}
+	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
+	repo = NewCloudControllerSpaceQuotaRepository(config, gateway)
+	return
+}
@@ -8,10 +8,6 @@ import (
 	"strings"
 )
 
-type PaginatedSpaceResources struct {
-	Resources []SpaceResource
-}
-
 type SpaceResource struct {
 	Metadata Metadata
 	Entity   SpaceEntity
@@ -23,9 +19,11 @@ type SpaceEntity struct {
 	Applications     []Resource `json:"apps"`
 	Domains          []Resource
 	ServiceInstances []Resource `json:"service_instances"`
+	SpaceQuotaGuid   string     `json:"space_quota_definition_guid"`
 }
 
 type SpaceRepository interface {
+	ListSpaces(cb func(cf.Space) bool) (apiResponse net.ApiResponse)
 	FindAll() (spaces []cf.Space, apiResponse net.ApiResponse)
 	FindByName(name string) (space cf.Space, apiResponse net.ApiResponse)
 	FindByNameInOrg(name string, org cf.Organization) (space cf.Space, apiResponse net.ApiResponse)
@@ -45,8 +43,13 @@ func NewCloudControllerSpaceRepository(config *configuration.Configuration, gate
 	return
 }
 
+func (repo CloudControllerSpaceRepository) ListSpaces(cb func(cf.Space) bool) (apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("/v2/organizations/%s/spaces", repo.config.Organization.Guid)
+	return repo.listSpacesWithPath(path, cb)
+}
+
 func (repo CloudControllerSpaceRepository) FindAll() (spaces []cf.Space, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/organizations/%s/spaces", repo.config.Target, repo.config.Organization.Guid)
+	path := fmt.Sprintf("/v2/organizations/%s/spaces", repo.config.Organization.Guid)
 	return repo.findAllWithPath(path)
 }
 
@@ -55,8 +58,8 @@ func (repo CloudControllerSpaceRepository) FindByName(name string) (space cf.Spa
 }
 
 func (repo CloudControllerSpaceRepository) FindByNameInOrg(name string, org cf.Organization) (space cf.Space, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/organizations/%s/spaces?q=name%%3A%s&inline-relations-depth=1",
-		repo.config.Target, org.Guid, strings.ToLower(name))
+	path := fmt.Sprintf("/v2/organizations/%s/spaces?q=name%%3A%s&inline-relations-depth=1",
+		org.Guid, strings.ToLower(name))
 
 	spaces, apiResponse := repo.findAllWithPath(path)
 	if apiResponse.IsNotSuccessful() {
@@ -73,44 +76,48 @@ func (repo CloudControllerSpaceRepository) FindByNameInOrg(name string, org cf.O
 }
 
 func (repo CloudControllerSpaceRepository) findAllWithPath(path string) (spaces []cf.Space, apiResponse net.ApiResponse) {
-	resources := new(PaginatedSpaceResources)
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resources)
-	if apiResponse.IsNotSuccessful() {
-		return
-	}
-
-	for _, r := range resources.Resources {
-		apps := []cf.Application{}
-		for _, app := range r.Entity.Applications {
-			apps = append(apps, cf.Application{Name: app.Entity.Name, Guid: app.Metadata.Guid})
-		}
-
-		domains := []cf.Domain{}
-		for _, domain := range r.Entity.Domains {
-			domains = append(domains, cf.Domain{Name: domain.Entity.Name, Guid: domain.Metadata.Guid})
-		}
-
-		services := []cf.ServiceInstance{}
-		for _, service := range r.Entity.ServiceInstances {
-			services = append(services, cf.ServiceInstance{Name: service.Entity.Name, Guid: service.Metadata.Guid})
-		}
-		space := cf.Space{
-			Name: r.Entity.Name,
-			Guid: r.Metadata.Guid,
-			Organization: cf.Organization{
-				Name: r.Entity.Organization.Entity.Name,
-				Guid: r.Entity.Organization.Metadata.Guid,
-			},
-			Applications:     apps,
-			Domains:          domains,
-			ServiceInstances: services,
-		}
-
+	apiResponse = repo.listSpacesWithPath(path, func(space cf.Space) bool {
 		spaces = append(spaces, space)
-	}
+		return true
+	})
 	return
 }
 
+func (repo CloudControllerSpaceRepository) listSpacesWithPath(path string, cb func(cf.Space) bool) (apiResponse net.ApiResponse) {
+	return repo.gateway.ListPaginatedResources(repo.config.Target, repo.config.AccessToken, path, SpaceResource{},
+		func(resource interface{}) bool {
+			r := resource.(SpaceResource)
+
+			apps := []cf.Application{}
+			for _, app := range r.Entity.Applications {
+				apps = append(apps, cf.Application{Name: app.Entity.Name, Guid: app.Metadata.Guid})
+			}
+
+			domains := []cf.Domain{}
+			for _, domain := range r.Entity.Domains {
+				domains = append(domains, cf.Domain{Name: domain.Entity.Name, Guid: domain.Metadata.Guid})
+			}
+
+			services := []cf.ServiceInstance{}
+			for _, service := range r.Entity.ServiceInstances {
+				services = append(services, cf.ServiceInstance{Name: service.Entity.Name, Guid: service.Metadata.Guid})
+			}
+
+			return cb(cf.Space{
+				Name: r.Entity.Name,
+				Guid: r.Metadata.Guid,
+				Organization: cf.Organization{
+					Name: r.Entity.Organization.Entity.Name,
+					Guid: r.Entity.Organization.Metadata.Guid,
+				},
+				Applications:     apps,
+				Domains:          domains,
+				ServiceInstances: services,
+				SpaceQuotaGuid:   r.Entity.SpaceQuotaGuid,
+			})
+		})
+}
+
 func (repo CloudControllerSpaceRepository) Create(name string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/spaces", repo.config.Target)
 	body := fmt.Sprintf(`{"name":"%s","organization_guid":"%s"}`, name, repo.config.Organization.Guid)
@@ -273,6 +273,7 @@ func createSpacesRepo(t *testing.T, req testnet.TestRequest) (ts *httptest.Serve
 		Space:        cf.Space{Guid: "my-space-guid"},
 	}
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	repo = NewCloudControllerSpaceRepository(config, gateway)
 	return
 }
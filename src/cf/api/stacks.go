@@ -7,10 +7,6 @@ import (
 	"fmt"
 )
 
-type PaginatedStackResources struct {
-	Resources []StackResource
-}
-
 type StackResource struct {
 	Resource
 	Entity StackEntity
@@ -38,7 +34,7 @@ func NewCloudControllerStackRepository(config *configuration.Configuration, gate
 }
 
 func (repo CloudControllerStackRepository) FindByName(name string) (stack cf.Stack, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/stacks?q=name%s", repo.config.Target, "%3A"+name)
+	path := fmt.Sprintf("/v2/stacks?q=name%s", "%3A"+name)
 	stacks, apiResponse := repo.findAllWithPath(path)
 	if apiResponse.IsNotSuccessful() {
 		return
@@ -54,19 +50,15 @@ func (repo CloudControllerStackRepository) FindByName(name string) (stack cf.Sta
 }
 
 func (repo CloudControllerStackRepository) FindAll() (stacks []cf.Stack, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/stacks", repo.config.Target)
-	return repo.findAllWithPath(path)
+	return repo.findAllWithPath("/v2/stacks")
 }
 
 func (repo CloudControllerStackRepository) findAllWithPath(path string) (stacks []cf.Stack, apiResponse net.ApiResponse) {
-	resources := new(PaginatedStackResources)
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resources)
-	if apiResponse.IsNotSuccessful() {
-		return
-	}
-
-	for _, r := range resources.Resources {
-		stacks = append(stacks, cf.Stack{Guid: r.Metadata.Guid, Name: r.Entity.Name, Description: r.Entity.Description})
-	}
+	apiResponse = repo.gateway.ListPaginatedResources(repo.config.Target, repo.config.AccessToken, path, StackResource{},
+		func(resource interface{}) bool {
+			r := resource.(StackResource)
+			stacks = append(stacks, cf.Stack{Guid: r.Metadata.Guid, Name: r.Entity.Name, Description: r.Entity.Description})
+			return true
+		})
 	return
 }
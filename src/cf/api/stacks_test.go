@@ -91,6 +91,7 @@ func createStackRepo(t *testing.T, req testnet.TestRequest) (ts *httptest.Server
 		Target:      ts.URL,
 	}
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	repo = NewCloudControllerStackRepository(config, gateway)
 	return
 }
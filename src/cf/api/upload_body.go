@@ -0,0 +1,129 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"time"
+)
+
+// how often the upload progress callback is called while bytes are being sent
+const uploadProgressInterval = 500 * time.Millisecond
+
+// uploadBody streams the multipart upload straight from the zip file. Only the form
+// headers around the zip are held in memory, and seeking back to the start lets the
+// gateway send the same body again.
+type uploadBody struct {
+	prefix   []byte
+	suffix   []byte
+	zipFile  *os.File
+	zipSize  int64
+	boundary string
+
+	reader     io.Reader
+	onProgress func(sent, total int64, elapsed time.Duration)
+	sent       int64
+	startedAt  time.Time
+	reportedAt time.Time
+}
+
+func newUploadBody(zipFile *os.File, resourcesJson []byte, onProgress func(sent, total int64, elapsed time.Duration)) (body *uploadBody, err error) {
+	zipStats, err := zipFile.Stat()
+	if err != nil {
+		return
+	}
+
+	buffer := &bytes.Buffer{}
+	writer := multipart.NewWriter(buffer)
+
+	part, err := writer.CreateFormField("resources")
+	if err != nil {
+		return
+	}
+
+	_, err = part.Write(resourcesJson)
+	if err != nil {
+		return
+	}
+
+	body = &uploadBody{
+		boundary:   writer.Boundary(),
+		onProgress: onProgress,
+	}
+
+	if zipStats.Size() > 0 {
+		_, err = createZipPartWriter(zipStats, writer)
+		if err != nil {
+			return
+		}
+		body.zipFile = zipFile
+		body.zipSize = zipStats.Size()
+	}
+
+	body.prefix = append([]byte{}, buffer.Bytes()...)
+	buffer.Reset()
+
+	err = writer.Close()
+	if err != nil {
+		return
+	}
+	body.suffix = buffer.Bytes()
+
+	_, err = body.Seek(0, 0)
+	return
+}
+
+func (body *uploadBody) Len() int64 {
+	return int64(len(body.prefix)) + body.zipSize + int64(len(body.suffix))
+}
+
+func (body *uploadBody) ContentType() string {
+	return fmt.Sprintf("multipart/form-data; boundary=%s", body.boundary)
+}
+
+func (body *uploadBody) Read(p []byte) (n int, err error) {
+	n, err = body.reader.Read(p)
+	body.sent += int64(n)
+
+	if body.onProgress != nil && n > 0 && (body.sent == body.Len() || time.Since(body.reportedAt) >= uploadProgressInterval) {
+		body.onProgress(body.sent, body.Len(), time.Since(body.startedAt))
+		body.reportedAt = time.Now()
+	}
+	return
+}
+
+func (body *uploadBody) Seek(offset int64, whence int) (position int64, err error) {
+	if offset != 0 || whence != 0 {
+		err = errors.New("upload body can only be rewound to the start")
+		return
+	}
+
+	readers := []io.Reader{bytes.NewReader(body.prefix)}
+	if body.zipFile != nil {
+		_, err = body.zipFile.Seek(0, 0)
+		if err != nil {
+			return
+		}
+		readers = append(readers, body.zipFile)
+	}
+	readers = append(readers, bytes.NewReader(body.suffix))
+
+	body.reader = io.MultiReader(readers...)
+	body.sent = 0
+	body.startedAt = time.Now()
+	body.reportedAt = body.startedAt
+	return
+}
+
+func createZipPartWriter(zipStats os.FileInfo, writer *multipart.Writer) (io.Writer, error) {
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", `form-data; name="application"; filename="application.zip"`)
+	h.Set("Content-Type", "application/zip")
+	h.Set("Content-Length", fmt.Sprintf("%d", zipStats.Size()))
+	h.Set("Content-Transfer-Encoding", "binary")
+	return writer.CreatePart(h)
+}
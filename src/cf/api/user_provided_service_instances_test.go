@@ -130,6 +130,7 @@ func createUserProvidedServiceInstanceRepo(t *testing.T, req testnet.TestRequest
 	}
 
 	gateway := net.NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	repo = NewCCUserProvidedServiceInstanceRepository(config, gateway)
 	return
 }
@@ -9,11 +9,6 @@ import (
 	"strings"
 )
 
-type PaginatedUserResources struct {
-	NextUrl   string `json:"next_url"`
-	Resources []UserResource
-}
-
 type UserResource struct {
 	Resource
 	Entity UserEntity
@@ -129,17 +124,13 @@ func (repo CloudControllerUserRepository) FindAllInSpaceByRole(space cf.Space) (
 func (repo CloudControllerUserRepository) findAllWithPath(path string) (users []cf.User, apiResponse net.ApiResponse) {
 	allUserResources := []UserResource{}
 
-	for path != "" {
-		paginatedResources := new(PaginatedUserResources)
-		url := fmt.Sprintf("%s%s", repo.config.Target, path)
-
-		apiResponse = repo.ccGateway.GetResource(url, repo.config.AccessToken, paginatedResources)
-		if apiResponse.IsNotSuccessful() {
-			return
-		}
-
-		allUserResources = append(allUserResources, paginatedResources.Resources...)
-		path = paginatedResources.NextUrl
+	apiResponse = repo.ccGateway.ListPaginatedResources(repo.config.Target, repo.config.AccessToken, path, UserResource{},
+		func(resource interface{}) bool {
+			allUserResources = append(allUserResources, resource.(UserResource))
+			return true
+		})
+	if apiResponse.IsNotSuccessful() {
+		return
 	}
 
 	if len(allUserResources) == 0 {
@@ -448,6 +448,12 @@ func createUsersRepo(t *testing.T, ccReqs []testnet.TestRequest, uaaReqs []testn
 	}
 	ccGateway := net.NewCloudControllerGateway()
 	uaaGateway := net.NewUAAGateway()
+	if cc != nil {
+		ccGateway.SetTrustedCerts(cc.TLS.Certificates)
+	}
+	if uaa != nil {
+		uaaGateway.SetTrustedCerts(uaa.TLS.Certificates)
+	}
 	endpointRepo := &testapi.FakeEndpointRepo{GetEndpointEndpoints: map[cf.EndpointType]string{
 		cf.UaaEndpointKey: uaaTarget,
 	}}
@@ -3,12 +3,15 @@ package app
 import (
 	"cf"
 	"cf/commands"
+	"cf/plugin"
 	"cf/terminal"
 	"fmt"
 	"github.com/codegangsta/cli"
 )
 
-func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
+func NewApp(cmdRunner commands.Runner, plugins []plugin.Plugin) (app *cli.App, err error) {
+	var installedCommands []cli.Command
+
 	helpCommand := cli.Command{
 		Name:        "help",
 		ShortName:   "h",
@@ -17,9 +20,13 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 		Action: func(c *cli.Context) {
 			args := c.Args()
 			if len(args) > 0 {
+				if command := findCommand(installedCommands, args[0]); command != nil {
+					showPluginCommandHelp(*command)
+					return
+				}
 				cli.ShowCommandHelp(c, args[0])
 			} else {
-				showAppHelp(c.App)
+				showAppHelp(c.App, installedCommands)
 			}
 		},
 	}
@@ -27,13 +34,47 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 	app = cli.NewApp()
 	app.Usage = cf.Usage
 	app.Version = cf.Version
-	app.Action = helpCommand.Action
+	app.Action = func(c *cli.Context) {
+		args := c.Args()
+		if len(args) > 0 {
+			// not a built-in command, so it may be a plugin command or a user defined alias
+			cmdRunner.RunCmdByName(args[0], c)
+		} else {
+			showAppHelp(c.App, installedCommands)
+		}
+	}
+	app.Flags = []cli.Flag{
+		cli.StringFlag{Name: "output", Value: terminal.TableOutput, Usage: "Output format for listing commands: table, json or yaml"},
+	}
 	app.Commands = []cli.Command{
 		helpCommand,
+		{
+			Name:        "alias",
+			Description: "Create a shortcut for a command and its default options",
+			Usage: fmt.Sprintf("%s alias ALIAS \"COMMAND [ARGS] [OPTIONS]\"\n\n", cf.Name()) +
+				"EXAMPLE:\n" +
+				fmt.Sprintf("   %s alias deploy \"push -m 512M -i 2\"\n", cf.Name()) +
+				fmt.Sprintf("   %s deploy my-app (runs: %s push -m 512M -i 2 my-app)\n", cf.Name(), cf.Name()) +
+				fmt.Sprintf("   %s alias rake \"push -c 'bundle exec rake'\"", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("alias", c)
+			},
+		},
+		{
+			Name:        "aliases",
+			Description: "List command aliases",
+			Usage:       fmt.Sprintf("%s aliases", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("aliases", c)
+			},
+		},
 		{
 			Name:        "api",
 			Description: "Set or view target api url",
-			Usage:       fmt.Sprintf("%s api [URL]", cf.Name()),
+			Usage:       fmt.Sprintf("%s api [URL] [--skip-ssl-validation]", cf.Name()),
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "skip-ssl-validation", Usage: "Do not verify the API endpoint's SSL certificate (insecure)"},
+			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("api", c)
 			},
@@ -41,7 +82,11 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 		{
 			Name:        "app",
 			Description: "Display health and status for app",
-			Usage:       fmt.Sprintf("%s app APP", cf.Name()),
+			Usage:       fmt.Sprintf("%s app APP [--watch] [--interval SECONDS]", cf.Name()),
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "watch", Usage: "Redraw the instance table until interrupted, failing if an instance crashes"},
+				cli.IntFlag{Name: "interval", Value: 2, Usage: "Seconds between redraws when watching"},
+			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("app", c)
 			},
@@ -58,11 +103,16 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 		{
 			Name:        "auth",
 			Description: "Authenticate user non-interactively",
-			Usage: fmt.Sprintf("%s auth USERNAME PASSWORD\n\n", cf.Name()) +
+			Usage: fmt.Sprintf("%s auth USERNAME PASSWORD\n", cf.Name()) +
+				fmt.Sprintf("   %s auth --client-credentials CLIENT_ID CLIENT_SECRET\n\n", cf.Name()) +
 				terminal.WarningColor("WARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history\n\n") +
 				"EXAMPLE:\n" +
 				fmt.Sprintf("   %s auth name@example.com \"my password\" (use quotes for passwords with a space)\n", cf.Name()) +
-				fmt.Sprintf("   %s auth name@example.com \"\\\"password\\\"\" (escape quotes if used in password)", cf.Name()),
+				fmt.Sprintf("   %s auth name@example.com \"\\\"password\\\"\" (escape quotes if used in password)\n", cf.Name()) +
+				fmt.Sprintf("   %s auth --client-credentials ci-client ci-secret (authenticate as a service account)", cf.Name()),
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "client-credentials", Usage: "Authenticate as an OAuth client with its id and secret"},
+			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("auth", c)
 			},
@@ -76,6 +126,26 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("bind-service", c)
 			},
 		},
+		{
+			Name:        "bind-services",
+			Description: "Bind service instances to apps, optionally unbinding the ones not listed",
+			Usage: fmt.Sprintf("%s bind-services [-f MANIFEST] [-b APP:SERVICE_INSTANCE]... [--prune] [--restart]\n\n", cf.Name()) +
+				"EXAMPLE:\n" +
+				fmt.Sprintf("   %s bind-services -b my-app:my-db -b my-app:my-cache -b my-worker:my-db\n", cf.Name()) +
+				fmt.Sprintf("   %s bind-services -f bindings.yml --prune --restart\n\n", cf.Name()) +
+				"TIP:\n" +
+				"   With --prune, services not listed for an app are unbound from it. Use 'APP:' with --prune to unbind all services from an app.\n" +
+				"   Apps in the manifest without a 'services' key are left unchanged.",
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "f", Value: "", Usage: "Path to a manifest listing each app's services"},
+				cli.StringSliceFlag{Name: "b", Value: &cli.StringSlice{}, Usage: "Service instance to bind, as APP:SERVICE_INSTANCE (may be repeated)"},
+				cli.BoolFlag{Name: "prune", Usage: "Unbind services that are not listed for each app"},
+				cli.BoolFlag{Name: "restart", Usage: "Restart each app whose bindings changed"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("bind-services", c)
+			},
+		},
 		{
 			Name:        "buildpacks",
 			Description: "List all buildpacks",
@@ -109,6 +179,24 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("create-org", c)
 			},
 		},
+		{
+			Name:        "create-quota",
+			Description: "Define a new resource quota",
+			Usage: fmt.Sprintf("%s create-quota QUOTA [-m TOTAL_MEMORY] [-i INSTANCE_MEMORY] [-r ROUTES] [-s SERVICE_INSTANCES] [-a APP_INSTANCES] [--allow-paid-service-plans]\n\n", cf.Name()) +
+				"EXAMPLE:\n" +
+				fmt.Sprintf("   %s create-quota medium -m 10G -i 1G -r 100 -s 20 --allow-paid-service-plans", cf.Name()),
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "m", Value: "", Usage: "Total amount of memory (e.g. 1024M, 1G, 10G)"},
+				cli.StringFlag{Name: "i", Value: "", Usage: "Maximum amount of memory an app instance can have (e.g. 1024M, 1G), -1 for unlimited"},
+				cli.StringFlag{Name: "r", Value: "", Usage: "Total number of routes"},
+				cli.StringFlag{Name: "s", Value: "", Usage: "Total number of service instances"},
+				cli.StringFlag{Name: "a", Value: "", Usage: "Total number of app instances, -1 for unlimited"},
+				cli.BoolFlag{Name: "allow-paid-service-plans", Usage: "Allow service instances of paid plans"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("create-quota", c)
+			},
+		},
 		{
 			Name:        "create-route",
 			Description: "Create a url route in a space for later use",
@@ -124,11 +212,16 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 			Name:        "create-service",
 			ShortName:   "cs",
 			Description: "Create a service instance",
-			Usage: fmt.Sprintf("%s create-service SERVICE PLAN SERVICE_INSTANCE\n\n", cf.Name()) +
+			Usage: fmt.Sprintf("%s create-service SERVICE PLAN SERVICE_INSTANCE [-c PARAMETERS_AS_JSON]\n\n", cf.Name()) +
 				"EXAMPLE:\n" +
-				fmt.Sprintf("   %s create-service cleardb spark clear-db-mine\n\n", cf.Name()) +
+				fmt.Sprintf("   %s create-service cleardb spark clear-db-mine\n", cf.Name()) +
+				fmt.Sprintf("   %s create-service postgres large my-db -c '{\"storage_gb\":20}'\n", cf.Name()) +
+				fmt.Sprintf("   %s create-service postgres large my-db -c ~/params.json\n\n", cf.Name()) +
 				"TIP:\n" +
 				"   Use 'cf create-user-provided-service' to make user-provided services available to cf apps",
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "c", Value: "", Usage: "Provisioning parameters as a JSON object, or the path to a file containing one"},
+			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("create-service", c)
 			},
@@ -157,6 +250,22 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("create-space", c)
 			},
 		},
+		{
+			Name:        "create-space-quota",
+			Description: "Define a new space quota in the targeted org",
+			Usage:       fmt.Sprintf("%s create-space-quota SPACE_QUOTA [-m TOTAL_MEMORY] [-i INSTANCE_MEMORY] [-r ROUTES] [-s SERVICE_INSTANCES] [-a APP_INSTANCES] [--allow-paid-service-plans]", cf.Name()),
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "m", Value: "", Usage: "Total amount of memory (e.g. 1024M, 1G, 10G)"},
+				cli.StringFlag{Name: "i", Value: "", Usage: "Maximum amount of memory an app instance can have (e.g. 1024M, 1G), -1 for unlimited"},
+				cli.StringFlag{Name: "r", Value: "", Usage: "Total number of routes"},
+				cli.StringFlag{Name: "s", Value: "", Usage: "Total number of service instances"},
+				cli.StringFlag{Name: "a", Value: "", Usage: "Total number of app instances, -1 for unlimited"},
+				cli.BoolFlag{Name: "allow-paid-service-plans", Usage: "Allow service instances of paid plans"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("create-space-quota", c)
+			},
+		},
 		{
 			Name:        "create-user",
 			Description: "Create a new user",
@@ -187,6 +296,26 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("create-user-provided-service", c)
 			},
 		},
+		{
+			Name:        "curl",
+			Description: "Execute a raw request, content-type set to application/json by default",
+			Usage: fmt.Sprintf("%s curl PATH [-X METHOD] [-H HEADER] [-d DATA] [-i]\n\n", cf.Name()) +
+				"   By default 'cf curl' will perform a GET to the specified PATH. If data\n" +
+				"   is provided via -d, a POST will be performed instead.\n\n" +
+				"EXAMPLE:\n" +
+				"   cf curl /v2/events\n" +
+				"   cf curl /v2/service_plans -H 'Accept-Language: en'\n" +
+				"   cf curl /v2/apps/APP_GUID -X PUT -d '{\"instances\":2}'",
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "X", Value: "", Usage: "HTTP method (GET,POST,PUT,DELETE,etc)"},
+				cli.StringSliceFlag{Name: "H", Value: &cli.StringSlice{}, Usage: "Custom header to include in the request, as 'NAME: VALUE' (may be repeated)"},
+				cli.StringFlag{Name: "d", Value: "", Usage: "HTTP data to include in the request body"},
+				cli.BoolFlag{Name: "i", Usage: "Include response headers in the output"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("curl", c)
+			},
+		},
 		{
 			Name:        "delete",
 			ShortName:   "d",
@@ -232,6 +361,28 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("delete-org", c)
 			},
 		},
+		{
+			Name:        "delete-profile",
+			Description: "Delete a named profile",
+			Usage:       fmt.Sprintf("%s delete-profile PROFILE", cf.Name()),
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "f", Usage: "Force deletion without confirmation"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("delete-profile", c)
+			},
+		},
+		{
+			Name:        "delete-quota",
+			Description: "Delete a quota",
+			Usage:       fmt.Sprintf("%s delete-quota QUOTA", cf.Name()),
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "f", Usage: "Force deletion without confirmation"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("delete-quota", c)
+			},
+		},
 		{
 			Name:        "delete-route",
 			Description: "Delete a route",
@@ -334,23 +485,34 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("files", c)
 			},
 		},
+		{
+			Name:        "install-plugin",
+			Description: "Install a plugin executable as new cf commands",
+			Usage:       fmt.Sprintf("%s install-plugin PATH_TO_PLUGIN", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("install-plugin", c)
+			},
+		},
 		{
 			Name:        "login",
 			ShortName:   "l",
 			Description: "Log user in",
-			Usage: fmt.Sprintf("%s login [-a API_URL] [-u USERNAME] [-p PASSWORD] [-o ORG] [-s SPACE]\n\n", cf.Name()) +
+			Usage: fmt.Sprintf("%s login [-a API_URL] [-u USERNAME] [-p PASSWORD] [-o ORG] [-s SPACE] [--sso] [--skip-ssl-validation]\n\n", cf.Name()) +
 				terminal.WarningColor("WARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history\n\n") +
 				"EXAMPLE:\n" +
 				fmt.Sprintf("   %s login (omit username and password to login interactively -- %s will prompt for both)\n", cf.Name(), cf.Name()) +
 				fmt.Sprintf("   %s login -u name@example.com -p pa55woRD (specify username and password as arguments)\n", cf.Name()) +
 				fmt.Sprintf("   %s login -u name@example.com -p \"my password\" (use quotes for passwords with a space)\n", cf.Name()) +
-				fmt.Sprintf("   %s login -u name@example.com -p \"\\\"password\\\"\" (escape quotes if used in password)", cf.Name()),
+				fmt.Sprintf("   %s login -u name@example.com -p \"\\\"password\\\"\" (escape quotes if used in password)\n", cf.Name()) +
+				fmt.Sprintf("   %s login --sso (prompt for a one time code from your single sign-on provider)", cf.Name()),
 			Flags: []cli.Flag{
 				cli.StringFlag{Name: "a", Value: "", Usage: "API endpoint (for example: https://api.example.com)"},
+				cli.BoolFlag{Name: "skip-ssl-validation", Usage: "Do not verify the API endpoint's SSL certificate (insecure)"},
 				cli.StringFlag{Name: "u", Value: "", Usage: "Username"},
 				cli.StringFlag{Name: "p", Value: "", Usage: "Password"},
 				cli.StringFlag{Name: "o", Value: "", Usage: "Org"},
 				cli.StringFlag{Name: "s", Value: "", Usage: "Space"},
+				cli.BoolFlag{Name: "sso", Usage: "Log in with a one time code instead of a username and password"},
 			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("login", c)
@@ -367,10 +529,22 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 		},
 		{
 			Name:        "logs",
-			Description: "Tail or show recent logs for an app",
-			Usage:       fmt.Sprintf("%s logs APP", cf.Name()),
+			Description: "Tail or show recent logs for one or more apps",
+			Usage: fmt.Sprintf("%s logs (APP... | --space) [--recent [--since DURATION]] [--source TYPE] [--instance INDEX] [--stdout|--stderr] [--grep REGEX] [--format json|raw]\n\n", cf.Name()) +
+				"EXAMPLE:\n" +
+				fmt.Sprintf("   %s logs my-app --recent --since 15m --source App --stderr\n", cf.Name()) +
+				fmt.Sprintf("   %s logs frontend orders payments\n", cf.Name()) +
+				fmt.Sprintf("   %s logs my-app --source RTR --grep ' 5[0-9][0-9] ' --format raw", cf.Name()),
 			Flags: []cli.Flag{
 				cli.BoolFlag{Name: "recent", Usage: "dump recent logs instead of tailing"},
+				cli.BoolFlag{Name: "space", Usage: "show logs from every app in the target space"},
+				cli.StringFlag{Name: "since", Value: "", Usage: "with --recent, only show logs from the last DURATION (e.g. 30s, 10m, 1h)"},
+				cli.StringSliceFlag{Name: "source", Value: &cli.StringSlice{}, Usage: "only show logs from this source type (App, RTR, STG, API, LGR), can be repeated"},
+				cli.StringFlag{Name: "instance", Value: "", Usage: "only show app logs from the instance with this index"},
+				cli.BoolFlag{Name: "stdout", Usage: "only show logs written to stdout"},
+				cli.BoolFlag{Name: "stderr", Usage: "only show logs written to stderr"},
+				cli.StringFlag{Name: "grep", Value: "", Usage: "only show logs whose message matches this regular expression"},
+				cli.StringFlag{Name: "format", Value: "", Usage: "print each log as a json object (json) or only its message (raw)"},
 			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("logs", c)
@@ -404,6 +578,16 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("map-route", c)
 			},
 		},
+		{
+			Name:        "oauth-token",
+			Description: "Refresh and print the OAuth token for the current session",
+			Usage: fmt.Sprintf("%s oauth-token\n\n", cf.Name()) +
+				"EXAMPLE:\n" +
+				fmt.Sprintf("   curl -H \"Authorization: $(%s oauth-token)\" https://api.example.com/v2/info", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("oauth-token", c)
+			},
+		},
 		{
 			Name:        "org",
 			Description: "Show org info",
@@ -412,6 +596,14 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("org", c)
 			},
 		},
+		{
+			Name:        "org-usage",
+			Description: "Show memory, instances, routes and service instances used by an org against its quota",
+			Usage:       fmt.Sprintf("%s org-usage ORG", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("org-usage", c)
+			},
+		},
 		{
 			Name:        "org-users",
 			Description: "Show org users by role",
@@ -438,30 +630,60 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("passwd", c)
 			},
 		},
+		{
+			Name:        "plugins",
+			Description: "List installed plugins and their commands",
+			Usage:       fmt.Sprintf("%s plugins", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("plugins", c)
+			},
+		},
+		{
+			Name:        "profiles",
+			Description: "List named profiles",
+			Usage:       fmt.Sprintf("%s profiles", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("profiles", c)
+			},
+		},
 		{
 			Name:        "push",
 			ShortName:   "p",
 			Description: "Push a new app or sync changes to an existing app",
-			Usage: fmt.Sprintf("%s push APP [-b URL] [-c COMMAND] [-d DOMAIN] [-i NUM_INSTANCES]\n", cf.Name()) +
-				"               [-m MEMORY] [-n HOST] [-p PATH] [-s STACK]\n" +
-				"               [--no-hostname] [--no-route] [--no-start]",
+			Usage: fmt.Sprintf("%s push [APP] [-b URL] [-c COMMAND] [-d DOMAIN] [-f MANIFEST_PATH]\n", cf.Name()) +
+				"               [-i NUM_INSTANCES] [-m MEMORY] [-n HOST] [-p PATH] [-s STACK]\n" +
+				"               [--no-hostname] [--no-route] [--no-start] [--strategy STRATEGY]\n\n" +
+				"TIP:\n" +
+				"   Settings are read from manifest.yml in the app directory when it exists; flags override them.\n" +
+				"   Without APP, every application in the manifest is pushed in order, and per-app flags cannot be used.\n" +
+				"   With --strategy blue-green, an existing app keeps serving its routes until the new version is running.",
 			Flags: []cli.Flag{
 				cli.StringFlag{Name: "b", Value: "", Usage: "Custom buildpack URL (for example: https://github.com/heroku/heroku-buildpack-play.git)"},
 				cli.StringFlag{Name: "c", Value: "", Usage: "Startup command"},
 				cli.StringFlag{Name: "d", Value: "", Usage: "Domain (for example: example.com)"},
-				cli.IntFlag{Name: "i", Value: 1, Usage: "Number of instances"},
-				cli.StringFlag{Name: "m", Value: "128", Usage: "Memory limit (for example: 256, 1G, 1024M)"},
+				cli.StringFlag{Name: "f", Value: "", Usage: "Path to manifest file or directory containing manifest.yml"},
+				cli.IntFlag{Name: "i", Value: 0, Usage: "Number of instances (default: 1)"},
+				cli.StringFlag{Name: "m", Value: "", Usage: "Memory limit (for example: 256, 1G, 1024M) (default: 128M)"},
 				cli.StringFlag{Name: "n", Value: "", Usage: "Hostname (for example: my-subdomain)"},
 				cli.StringFlag{Name: "p", Value: "", Usage: "Path of app directory or zip file"},
 				cli.StringFlag{Name: "s", Value: "", Usage: "Stack to use"},
 				cli.BoolFlag{Name: "no-hostname", Usage: "Map the root domain to this app"},
 				cli.BoolFlag{Name: "no-route", Usage: "Do not map a route to this app"},
 				cli.BoolFlag{Name: "no-start", Usage: "Do not start an app after pushing"},
+				cli.StringFlag{Name: "strategy", Value: "", Usage: "Deployment strategy, 'blue-green' pushes to a temporary app and moves the routes once it starts"},
 			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("push", c)
 			},
 		},
+		{
+			Name:        "quota",
+			Description: "Show quota info",
+			Usage:       fmt.Sprintf("%s quota QUOTA", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("quota", c)
+			},
+		},
 		{
 			Name:        "quotas",
 			Description: "List available usage quotas ",
@@ -502,6 +724,14 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("rename-service-broker", c)
 			},
 		},
+		{
+			Name:        "rename-profile",
+			Description: "Rename a named profile",
+			Usage:       fmt.Sprintf("%s rename-profile PROFILE NEW_PROFILE", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("rename-profile", c)
+			},
+		},
 		{
 			Name:        "rename-space",
 			Description: "Rename a space",
@@ -564,6 +794,17 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("service-brokers", c)
 			},
 		},
+		{
+			Name:        "service-credentials",
+			Description: "Show the credentials bound apps receive from a service instance",
+			Usage:       fmt.Sprintf("%s service-credentials SERVICE_INSTANCE [APP] [--reveal]", cf.Name()),
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "reveal", Usage: "Show secret values instead of masking them"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("service-credentials", c)
+			},
+		},
 		{
 			Name:        "services",
 			ShortName:   "s",
@@ -599,11 +840,19 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 			Description: "Define the quota for an org",
 			Usage: fmt.Sprintf("%s set-quota ORG QUOTA\n\n", cf.Name()) +
 				"TIP:\n" +
-				"   Allowable quotas are 'free,' 'paid,' 'runaway,' and 'trial'",
+				fmt.Sprintf("   Use '%s quotas' to list the available quotas", cf.Name()),
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("set-quota", c)
 			},
 		},
+		{
+			Name:        "set-space-quota",
+			Description: "Assign a space quota to a space",
+			Usage:       fmt.Sprintf("%s set-space-quota SPACE SPACE_QUOTA", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("set-space-quota", c)
+			},
+		},
 		{
 			Name:        "set-space-role",
 			Description: "Assign a space role to a user",
@@ -632,6 +881,14 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("space", c)
 			},
 		},
+		{
+			Name:        "space-usage",
+			Description: "Show memory, instances, routes and service instances used by a space against its quota",
+			Usage:       fmt.Sprintf("%s space-usage SPACE", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("space-usage", c)
+			},
+		},
 		{
 			Name:        "space-users",
 			Description: "Show space users by role",
@@ -678,8 +935,9 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 			Name:        "target",
 			ShortName:   "t",
 			Description: "Set or view the targeted org or space",
-			Usage:       fmt.Sprintf("%s target [-o ORG] [-s SPACE]", cf.Name()),
+			Usage:       fmt.Sprintf("%s target [--profile PROFILE] [-o ORG] [-s SPACE]", cf.Name()),
 			Flags: []cli.Flag{
+				cli.StringFlag{Name: "profile", Value: "", Usage: "switch to a named profile, creating it if needed"},
 				cli.StringFlag{Name: "o", Value: "", Usage: "organization"},
 				cli.StringFlag{Name: "s", Value: "", Usage: "space"},
 			},
@@ -687,6 +945,14 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("target", c)
 			},
 		},
+		{
+			Name:        "unalias",
+			Description: "Delete a command alias",
+			Usage:       fmt.Sprintf("%s unalias ALIAS", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("unalias", c)
+			},
+		},
 		{
 			Name:        "unbind-service",
 			ShortName:   "us",
@@ -696,6 +962,14 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("unbind-service", c)
 			},
 		},
+		{
+			Name:        "uninstall-plugin",
+			Description: "Uninstall a plugin",
+			Usage:       fmt.Sprintf("%s uninstall-plugin PLUGIN", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("uninstall-plugin", c)
+			},
+		},
 		{
 			Name:        "unmap-domain",
 			Description: "Unmap a domain from a space",
@@ -751,6 +1025,24 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("update-buildpack", c)
 			},
 		},
+		{
+			Name:        "update-quota",
+			Description: "Update an existing resource quota",
+			Usage:       fmt.Sprintf("%s update-quota QUOTA [-n NEW_NAME] [-m TOTAL_MEMORY] [-i INSTANCE_MEMORY] [-r ROUTES] [-s SERVICE_INSTANCES] [-a APP_INSTANCES] [--allow-paid-service-plans | --disallow-paid-service-plans]", cf.Name()),
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "n", Value: "", Usage: "New name"},
+				cli.StringFlag{Name: "m", Value: "", Usage: "Total amount of memory (e.g. 1024M, 1G, 10G)"},
+				cli.StringFlag{Name: "i", Value: "", Usage: "Maximum amount of memory an app instance can have (e.g. 1024M, 1G), -1 for unlimited"},
+				cli.StringFlag{Name: "r", Value: "", Usage: "Total number of routes"},
+				cli.StringFlag{Name: "s", Value: "", Usage: "Total number of service instances"},
+				cli.StringFlag{Name: "a", Value: "", Usage: "Total number of app instances, -1 for unlimited"},
+				cli.BoolFlag{Name: "allow-paid-service-plans", Usage: "Allow service instances of paid plans"},
+				cli.BoolFlag{Name: "disallow-paid-service-plans", Usage: "Disallow service instances of paid plans"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("update-quota", c)
+			},
+		},
 		{
 			Name:        "update-service-broker",
 			Description: "Update a service broker",
@@ -767,6 +1059,21 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("update-service-auth-token", c)
 			},
 		},
+		{
+			Name:        "update-service",
+			Description: "Change the plan or parameters of a service instance",
+			Usage: fmt.Sprintf("%s update-service SERVICE_INSTANCE [-p NEW_PLAN] [-c PARAMETERS_AS_JSON]\n\n", cf.Name()) +
+				"EXAMPLE:\n" +
+				fmt.Sprintf("   %s update-service my-db -p larger\n", cf.Name()) +
+				fmt.Sprintf("   %s update-service my-db -c '{\"storage_gb\":50}'", cf.Name()),
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "p", Value: "", Usage: "Change the service plan"},
+				cli.StringFlag{Name: "c", Value: "", Usage: "Parameters as a JSON object, or the path to a file containing one"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("update-service", c)
+			},
+		},
 		{
 			Name:        "update-user-provided-service",
 			ShortName:   "uups",
@@ -785,5 +1092,43 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 			},
 		},
 	}
+
+	installedCommands = pluginCommands(app, plugins)
 	return
 }
+
+// pluginCommands are only used for help. They are not added to the app, so that
+// the cli does not parse their flags, and the app action runs them instead.
+// Clashes with built in names are rejected on install, but any left from
+// earlier versions are left out.
+func pluginCommands(app *cli.App, plugins []plugin.Plugin) (cliCommands []cli.Command) {
+	for _, installedPlugin := range plugins {
+		for _, command := range installedPlugin.Commands {
+			if app.Command(command.Name) != nil {
+				continue
+			}
+
+			cmdName := command.Name
+			usage := command.Usage
+			if usage == "" {
+				usage = fmt.Sprintf("%s %s", cf.Name(), cmdName)
+			}
+
+			cliCommands = append(cliCommands, cli.Command{
+				Name:        cmdName,
+				Description: command.Description,
+				Usage:       usage,
+			})
+		}
+	}
+	return
+}
+
+func findCommand(cliCommands []cli.Command, name string) *cli.Command {
+	for index := range cliCommands {
+		if cliCommands[index].HasName(name) {
+			return &cliCommands[index]
+		}
+	}
+	return nil
+}
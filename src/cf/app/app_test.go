@@ -5,6 +5,7 @@ import (
 	"cf/commands"
 	"cf/configuration"
 	"cf/net"
+	"cf/plugin"
 	"github.com/codegangsta/cli"
 	"github.com/stretchr/testify/assert"
 	"strings"
@@ -16,8 +17,8 @@ import (
 
 func availableCmdNames() (names []string) {
 	reqFactory := &testreq.FakeReqFactory{}
-	cmdRunner := commands.NewRunner(nil, reqFactory)
-	app, _ := NewApp(cmdRunner)
+	cmdRunner := commands.NewRunner(&testterm.FakeUI{}, nil, reqFactory)
+	app, _ := NewApp(cmdRunner, nil)
 
 	for _, cliCmd := range app.Commands {
 		if cliCmd.Name != "help" {
@@ -57,7 +58,7 @@ func TestCommands(t *testing.T) {
 
 		cmdFactory := commands.NewFactory(ui, config, configRepo, repoLocator)
 		cmdRunner := &FakeRunner{cmdFactory: cmdFactory, t: t}
-		app, _ := NewApp(cmdRunner)
+		app, _ := NewApp(cmdRunner, nil)
 		app.Run([]string{"", cmdName})
 
 		assert.Equal(t, cmdRunner.cmdName, cmdName)
@@ -66,9 +67,72 @@ func TestCommands(t *testing.T) {
 
 func TestUsageIncludesCommandName(t *testing.T) {
 	reqFactory := &testreq.FakeReqFactory{}
-	cmdRunner := commands.NewRunner(nil, reqFactory)
-	app, _ := NewApp(cmdRunner)
+	cmdRunner := commands.NewRunner(&testterm.FakeUI{}, nil, reqFactory)
+	app, _ := NewApp(cmdRunner, nil)
 	for _, cmd := range app.Commands {
 		assert.Contains(t, strings.Split(cmd.Usage, "\n")[0], cmd.Name)
 	}
 }
+
+func TestPluginCommandsAreAddedUnlessAlreadyDefined(t *testing.T) {
+	plugins := []plugin.Plugin{
+		plugin.Plugin{
+			Name: "hello",
+			Commands: []plugin.Command{
+				plugin.Command{Name: "hello", Description: "Say hello"},
+				plugin.Command{Name: "push", Description: "Not the real push"},
+			},
+		},
+	}
+
+	reqFactory := &testreq.FakeReqFactory{}
+	cmdRunner := commands.NewRunner(&testterm.FakeUI{}, nil, reqFactory)
+	app, _ := NewApp(cmdRunner, plugins)
+
+	assert.Nil(t, app.Command("hello"))
+
+	installed := pluginCommands(app, plugins)
+	assert.Equal(t, len(installed), 1)
+	assert.Equal(t, installed[0].Name, "hello")
+	assert.Equal(t, installed[0].Description, "Say hello")
+	assert.Contains(t, installed[0].Usage, "hello")
+
+	pushCmd := app.Command("push")
+	assert.NotEqual(t, pushCmd.Description, "Not the real push")
+}
+
+func TestPluginCommandsGetTheirFlagsUnparsed(t *testing.T) {
+	plugins := []plugin.Plugin{
+		plugin.Plugin{
+			Name:     "hello",
+			Commands: []plugin.Command{plugin.Command{Name: "hello"}},
+		},
+	}
+
+	cmdRunner := &recordingRunner{}
+	app, _ := NewApp(cmdRunner, plugins)
+	app.Run([]string{"", "hello", "world", "--loud"})
+
+	assert.Equal(t, cmdRunner.cmdName, "hello")
+	assert.Equal(t, cmdRunner.args, []string{"hello", "world", "--loud"})
+}
+
+type recordingRunner struct {
+	cmdName string
+	args    []string
+}
+
+func (runner *recordingRunner) RunCmdByName(cmdName string, c *cli.Context) (err error) {
+	runner.cmdName = cmdName
+	runner.args = c.Args()
+	return
+}
+
+func TestUnknownCommandsAreRunByName(t *testing.T) {
+	cmdRunner := &recordingRunner{}
+	app, _ := NewApp(cmdRunner, nil)
+	app.Run([]string{"", "deploy", "my-app"})
+
+	assert.Equal(t, cmdRunner.cmdName, "deploy")
+	assert.Equal(t, cmdRunner.args, []string{"deploy", "my-app"})
+}
@@ -26,6 +26,16 @@ var appHelpTemplate = `{{.Title "NAME:"}}
    {{end}}
 {{.Title "ENVIRONMENT VARIABLES:"}}
    CF_TRACE=true - will output HTTP requests and responses during command
+   CF_TRACE=path/to/trace.log - append the HTTP trace to this file instead
+   CF_TRACE_FORMAT=json - trace each request as a line of JSON
+   CF_PROFILE=prod - use a named profile for this command only
+   CF_UPLOAD_TIMEOUT=900 - seconds to wait for pushed app bits to be processed
+   CF_HOME=path/to/dir/ - keep the .cf config directory here instead of the home directory
+   CF_CA_CERTS=path/to/ca.pem - also trust the CA certificates in this PEM file
+   CF_CLIENT_ID=my-client - log in through this UAA client instead of cf
+   CF_CLIENT_SECRET=my-secret - secret of the UAA client set in CF_CLIENT_ID
+   CF_HTTP_RETRIES=3 - times to retry GET, PUT and DELETE requests that fail (0 to never retry)
+   CF_HTTP_TIMEOUT=120 - seconds to wait to connect and for each response
    HTTP_PROXY=http://proxy.example.com:8080 - set to your proxy
 `
 
@@ -44,9 +54,11 @@ type cmdPresenter struct {
 }
 
 func newCmdPresenter(app *cli.App, maxNameLen int, cmdName string) (presenter cmdPresenter) {
-	cmd := app.Command(cmdName)
+	return presentCmd(*app.Command(cmdName), maxNameLen)
+}
 
-	presenter.Name = presentCmdName(*cmd)
+func presentCmd(cmd cli.Command, maxNameLen int) (presenter cmdPresenter) {
+	presenter.Name = presentCmdName(cmd)
 	padding := strings.Repeat(" ", maxNameLen-len(presenter.Name))
 	presenter.Name = presenter.Name + padding
 
@@ -72,18 +84,20 @@ func (p appPresenter) Title(name string) string {
 	return terminal.HeaderColor(name)
 }
 
-func getMaxCmdNameLength(app *cli.App) (length int) {
-	for _, cmd := range app.Commands {
-		name := presentCmdName(cmd)
-		if len(name) > length {
-			length = len(name)
+func getMaxCmdNameLength(app *cli.App, pluginCommands []cli.Command) (length int) {
+	for _, cmds := range [][]cli.Command{app.Commands, pluginCommands} {
+		for _, cmd := range cmds {
+			name := presentCmdName(cmd)
+			if len(name) > length {
+				length = len(name)
+			}
 		}
 	}
 	return
 }
 
-func newAppPresenter(app *cli.App) (presenter appPresenter) {
-	maxNameLen := getMaxCmdNameLength(app)
+func newAppPresenter(app *cli.App, pluginCommands []cli.Command) (presenter appPresenter) {
+	maxNameLen := getMaxCmdNameLength(app, pluginCommands)
 
 	presenter.Name = app.Name
 	presenter.Usage = app.Usage
@@ -103,6 +117,11 @@ func newAppPresenter(app *cli.App) (presenter appPresenter) {
 				}, {
 					newCmdPresenter(app, maxNameLen, "api"),
 					newCmdPresenter(app, maxNameLen, "auth"),
+					newCmdPresenter(app, maxNameLen, "oauth-token"),
+				}, {
+					newCmdPresenter(app, maxNameLen, "profiles"),
+					newCmdPresenter(app, maxNameLen, "rename-profile"),
+					newCmdPresenter(app, maxNameLen, "delete-profile"),
 				},
 			},
 		}, {
@@ -139,12 +158,15 @@ func newAppPresenter(app *cli.App) (presenter appPresenter) {
 					newCmdPresenter(app, maxNameLen, "marketplace"),
 					newCmdPresenter(app, maxNameLen, "services"),
 					newCmdPresenter(app, maxNameLen, "service"),
+					newCmdPresenter(app, maxNameLen, "service-credentials"),
 				}, {
 					newCmdPresenter(app, maxNameLen, "create-service"),
+					newCmdPresenter(app, maxNameLen, "update-service"),
 					newCmdPresenter(app, maxNameLen, "delete-service"),
 					newCmdPresenter(app, maxNameLen, "rename-service"),
 				}, {
 					newCmdPresenter(app, maxNameLen, "bind-service"),
+					newCmdPresenter(app, maxNameLen, "bind-services"),
 					newCmdPresenter(app, maxNameLen, "unbind-service"),
 				}, {
 					newCmdPresenter(app, maxNameLen, "create-user-provided-service"),
@@ -229,7 +251,18 @@ func newAppPresenter(app *cli.App) (presenter appPresenter) {
 			CommandSubGroups: [][]cmdPresenter{
 				{
 					newCmdPresenter(app, maxNameLen, "quotas"),
+					newCmdPresenter(app, maxNameLen, "quota"),
 					newCmdPresenter(app, maxNameLen, "set-quota"),
+				}, {
+					newCmdPresenter(app, maxNameLen, "create-quota"),
+					newCmdPresenter(app, maxNameLen, "update-quota"),
+					newCmdPresenter(app, maxNameLen, "delete-quota"),
+				}, {
+					newCmdPresenter(app, maxNameLen, "create-space-quota"),
+					newCmdPresenter(app, maxNameLen, "set-space-quota"),
+				}, {
+					newCmdPresenter(app, maxNameLen, "org-usage"),
+					newCmdPresenter(app, maxNameLen, "space-usage"),
 				},
 			},
 		}, {
@@ -248,13 +281,49 @@ func newAppPresenter(app *cli.App) (presenter appPresenter) {
 					newCmdPresenter(app, maxNameLen, "rename-service-broker"),
 				},
 			},
+		}, {
+			Name: "ADVANCED",
+			CommandSubGroups: [][]cmdPresenter{
+				{
+					newCmdPresenter(app, maxNameLen, "curl"),
+				}, {
+					newCmdPresenter(app, maxNameLen, "aliases"),
+					newCmdPresenter(app, maxNameLen, "alias"),
+					newCmdPresenter(app, maxNameLen, "unalias"),
+				},
+			},
+		}, {
+			Name: "PLUGINS",
+			CommandSubGroups: [][]cmdPresenter{
+				{
+					newCmdPresenter(app, maxNameLen, "plugins"),
+					newCmdPresenter(app, maxNameLen, "install-plugin"),
+					newCmdPresenter(app, maxNameLen, "uninstall-plugin"),
+				},
+			},
 		},
 	}
+
+	if len(pluginCommands) > 0 {
+		group := []cmdPresenter{}
+		for _, command := range pluginCommands {
+			group = append(group, presentCmd(command, maxNameLen))
+		}
+
+		pluginGroup := &presenter.Commands[len(presenter.Commands)-1]
+		pluginGroup.CommandSubGroups = append(pluginGroup.CommandSubGroups, group)
+	}
 	return
 }
 
-func showAppHelp(app *cli.App) {
-	presenter := newAppPresenter(app)
+// plugin commands are not in the app, so the cli cannot show their help
+func showPluginCommandHelp(command cli.Command) {
+	t := template.Must(template.New("help").Parse(cli.CommandHelpTemplate))
+	t.Execute(os.Stdout, command)
+}
+
+func showAppHelp(app *cli.App, pluginCommands []cli.Command) {
+	presenter := newAppPresenter(app, pluginCommands)
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
 	t := template.Must(template.New("help").Parse(appHelpTemplate))
@@ -6,36 +6,90 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sync"
 )
 
-func AppFilesInDir(dir string) (appFiles []AppFile, err error) {
+// the number of files hashed at once, enough to keep a disk busy without running out of file handles
+const fingerprintWorkers = 8
+
+type appFileToHash struct {
+	fileName string
+	fullPath string
+	info     os.FileInfo
+}
+
+// AppFilesInDir fingerprints every file in dir, reusing the fingerprints recorded in
+// cacheFile for files that have not changed. An empty cacheFile disables the cache.
+func AppFilesInDir(dir, cacheFile string) (appFiles []AppFile, err error) {
+	filesToHash := []appFileToHash{}
 	err = walkAppFiles(dir, func(fileName string, fullPath string) {
-		fileInfo, err := os.Lstat(fullPath)
+		info, err := os.Lstat(fullPath)
 		if err != nil {
 			return
 		}
-		size := fileInfo.Size()
+		filesToHash = append(filesToHash, appFileToHash{fileName, fullPath, info})
+	})
+	if err != nil {
+		return
+	}
 
-		h := sha1.New()
-		file, err := os.Open(fullPath)
-		if err != nil {
-			return
-		}
+	cache := loadFingerprintCache(dir, cacheFile)
+
+	appFiles = make([]AppFile, len(filesToHash))
+	errs := make([]error, len(filesToHash))
+	indexes := make(chan int)
+	wg := &sync.WaitGroup{}
+
+	for i := 0; i < fingerprintWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				appFiles[index], errs[index] = fingerprintFile(cache, filesToHash[index])
+			}
+		}()
+	}
+
+	for index := range filesToHash {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
 
-		_, err = io.Copy(h, file)
+	for _, err = range errs {
 		if err != nil {
+			appFiles = nil
 			return
 		}
+	}
 
-		sha1Bytes := h.Sum(nil)
-		sha1 := fmt.Sprintf("%x", sha1Bytes)
+	cache.save()
+	return
+}
 
-		appFiles = append(appFiles, AppFile{
-			Path: fileName,
-			Sha1: sha1,
-			Size: size,
-		})
-	})
+func fingerprintFile(cache *fingerprintCache, file appFileToHash) (appFile AppFile, err error) {
+	appFile = AppFile{Path: file.fileName, Size: file.info.Size()}
+
+	cachedSha1, found := cache.lookup(file.fileName, file.info)
+	if found {
+		appFile.Sha1 = cachedSha1
+		return
+	}
+
+	f, err := os.Open(file.fullPath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	h := sha1.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return
+	}
+
+	appFile.Sha1 = fmt.Sprintf("%x", h.Sum(nil))
+	cache.store(file.fileName, file.info, appFile.Sha1)
 	return
 }
 
@@ -137,7 +191,10 @@ func IsDirEmpty(dir string) (isEmpty bool, err error) {
 type walkAppFileFunc func(fileName, fullPath string)
 
 func walkAppFiles(dir string, onEachFile walkAppFileFunc) (err error) {
-	exclusions := readCfIgnore(dir)
+	cfIgnore, err := readCfIgnore(dir)
+	if err != nil {
+		return
+	}
 
 	walkFunc := func(fullPath string, f os.FileInfo, inErr error) (err error) {
 		err = inErr
@@ -145,12 +202,19 @@ func walkAppFiles(dir string, onEachFile walkAppFileFunc) (err error) {
 			return
 		}
 
-		if f.IsDir() {
+		if fullPath == dir {
 			return
 		}
 
 		fileName, _ := filepath.Rel(dir, fullPath)
-		if fileShouldBeIgnored(exclusions, fileName) {
+		if cfIgnore.FileShouldBeIgnored(filepath.ToSlash(fileName), f.IsDir()) {
+			if f.IsDir() {
+				return filepath.SkipDir
+			}
+			return
+		}
+
+		if f.IsDir() {
 			return
 		}
 
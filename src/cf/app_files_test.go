@@ -0,0 +1,69 @@
+package cf
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppFilesInDir(t *testing.T) {
+	dir, err := os.Getwd()
+	assert.NoError(t, err)
+	dir = filepath.Join(dir, "../fixtures/example-app")
+
+	appFiles, err := AppFilesInDir(dir, "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, appFiles, []AppFile{
+		AppFile{Path: "Gemfile", Sha1: "d9c3a51de5c89c11331d3b90b972789f1a14699a", Size: 59},
+		AppFile{Path: "Gemfile.lock", Sha1: "345f999aef9070fb9a608e65cf221b7038156b6d", Size: 229},
+		AppFile{Path: "app.rb", Sha1: "2474735f5163ba7612ef641f438f4b5bee00127b", Size: 51},
+		AppFile{Path: "config.ru", Sha1: "f097424ce1fa66c6cb9f5e8a18c317376ec12e05", Size: 70},
+		AppFile{Path: "manifest.yml", Sha1: "19b5b4225dc64da3213b1ffaa1e1920ee5faf36c", Size: 111},
+	})
+}
+
+func TestAppFilesInDirUsesCachedFingerprints(t *testing.T) {
+	dir, err := ioutil.TempDir("", "app-files")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "hello.txt")
+	err = ioutil.WriteFile(path, []byte("hello"), 0644)
+	assert.NoError(t, err)
+
+	cacheDir, err := ioutil.TempDir("", "fingerprints")
+	assert.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+	cacheFile := filepath.Join(cacheDir, "fingerprints.json")
+
+	modTime := time.Now().Add(-time.Hour)
+	os.Chtimes(path, modTime, modTime)
+
+	appFiles, err := AppFilesInDir(dir, cacheFile)
+	assert.NoError(t, err)
+	assert.Equal(t, appFiles[0].Sha1, "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d")
+
+	info, err := os.Stat(cacheFile)
+	assert.NoError(t, err)
+	assert.Equal(t, info.Mode().Perm(), os.FileMode(0600))
+
+	// same size and modification time, so the cached fingerprint is trusted
+	err = ioutil.WriteFile(path, []byte("jello"), 0644)
+	assert.NoError(t, err)
+	os.Chtimes(path, modTime, modTime)
+
+	appFiles, err = AppFilesInDir(dir, cacheFile)
+	assert.NoError(t, err)
+	assert.Equal(t, appFiles[0].Sha1, "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d")
+
+	newModTime := modTime.Add(time.Minute)
+	os.Chtimes(path, newModTime, newModTime)
+
+	appFiles, err = AppFilesInDir(dir, cacheFile)
+	assert.NoError(t, err)
+	assert.Equal(t, appFiles[0].Sha1, "2ced3ee86f82bf91c15cc30605df6d3ddf0769ff")
+}
@@ -0,0 +1,153 @@
+package cf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// VCS metadata and the ignore file itself never belong in an upload, whatever .cfignore says,
+// so these come after the patterns from the file and win over them
+var defaultIgnorePatterns = []string{
+	".cfignore",
+	".git/",
+	".hg/",
+	".svn/",
+	"_darcs/",
+}
+
+type cfIgnore struct {
+	patterns []ignorePattern
+}
+
+type ignorePattern struct {
+	negated  bool
+	dirOnly  bool
+	anchored bool
+	regexp   *regexp.Regexp
+}
+
+func readCfIgnore(dir string) (ignore cfIgnore, err error) {
+	lines := []string{}
+
+	cfIgnoreFile, err := os.Open(filepath.Join(dir, ".cfignore"))
+	if err == nil {
+		defer cfIgnoreFile.Close()
+		lines = strings.Split(readFile(cfIgnoreFile), "\n")
+	}
+
+	return newCfIgnore(append(lines, defaultIgnorePatterns...))
+}
+
+func newCfIgnore(lines []string) (ignore cfIgnore, err error) {
+	for _, line := range lines {
+		var pattern ignorePattern
+		var ok bool
+		pattern, ok, err = parseIgnorePattern(line)
+		if err != nil {
+			return
+		}
+		if ok {
+			ignore.patterns = append(ignore.patterns, pattern)
+		}
+	}
+	return
+}
+
+// FileShouldBeIgnored follows gitignore: the last pattern matching path decides, and
+// path is slash separated and relative to the app directory.
+func (ignore cfIgnore) FileShouldBeIgnored(path string, isDir bool) (ignored bool) {
+	baseName := path[strings.LastIndex(path, "/")+1:]
+
+	for _, pattern := range ignore.patterns {
+		if pattern.dirOnly && !isDir {
+			continue
+		}
+
+		subject := baseName
+		if pattern.anchored {
+			subject = path
+		}
+
+		if pattern.regexp.MatchString(subject) {
+			ignored = !pattern.negated
+		}
+	}
+	return
+}
+
+func parseIgnorePattern(line string) (pattern ignorePattern, ok bool, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+
+	if strings.HasPrefix(line, "!") {
+		pattern.negated = true
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		pattern.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+
+	// a slash anywhere but the end ties the pattern to the app directory, otherwise
+	// it matches a file or directory name at any depth
+	if strings.Contains(line, "/") {
+		pattern.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+
+	if line == "" {
+		return
+	}
+
+	pattern.regexp, err = globToRegexp(line)
+	if err != nil {
+		err = fmt.Errorf("Invalid pattern %q in .cfignore: %s", line, err.Error())
+		return
+	}
+
+	ok = true
+	return
+}
+
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("^")
+
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			buf.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			buf.WriteString(".*")
+			i += 1
+		case glob[i] == '*':
+			buf.WriteString("[^/]*")
+		case glob[i] == '?':
+			buf.WriteString("[^/]")
+		case glob[i] == '[' && strings.Contains(glob[i:], "]"):
+			end := i + strings.Index(glob[i:], "]")
+			class := glob[i+1 : end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			buf.WriteString("[" + strings.Replace(class, `\`, `\\`, -1) + "]")
+			i = end
+		case glob[i] == '\\' && i+1 < len(glob):
+			buf.WriteString(regexp.QuoteMeta(glob[i+1 : i+2]))
+			i += 1
+		default:
+			buf.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+
+	buf.WriteString("$")
+	return regexp.Compile(buf.String())
+}
@@ -0,0 +1,112 @@
+package cf
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCfIgnoreMatchesNamesAtAnyDepth(t *testing.T) {
+	ignore, err := newCfIgnore([]string{"*.log", "node_modules"})
+	assert.NoError(t, err)
+
+	assert.True(t, ignore.FileShouldBeIgnored("dev.log", false))
+	assert.True(t, ignore.FileShouldBeIgnored("logs/dev.log", false))
+	assert.True(t, ignore.FileShouldBeIgnored("node_modules", true))
+	assert.True(t, ignore.FileShouldBeIgnored("web/node_modules", true))
+	assert.False(t, ignore.FileShouldBeIgnored("dev.log.txt", false))
+}
+
+func TestCfIgnoreAnchoredPatterns(t *testing.T) {
+	ignore, err := newCfIgnore([]string{"/tmp", "config/*.yml"})
+	assert.NoError(t, err)
+
+	assert.True(t, ignore.FileShouldBeIgnored("tmp", true))
+	assert.False(t, ignore.FileShouldBeIgnored("app/tmp", true))
+	assert.True(t, ignore.FileShouldBeIgnored("config/database.yml", false))
+	assert.False(t, ignore.FileShouldBeIgnored("app/config/database.yml", false))
+	assert.False(t, ignore.FileShouldBeIgnored("config/local/database.yml", false))
+}
+
+func TestCfIgnoreDoubleStarPatterns(t *testing.T) {
+	ignore, err := newCfIgnore([]string{"**/fixtures", "docs/**/*.pdf", "build/**"})
+	assert.NoError(t, err)
+
+	assert.True(t, ignore.FileShouldBeIgnored("fixtures", true))
+	assert.True(t, ignore.FileShouldBeIgnored("spec/support/fixtures", true))
+	assert.True(t, ignore.FileShouldBeIgnored("docs/manual.pdf", false))
+	assert.True(t, ignore.FileShouldBeIgnored("docs/a/b/manual.pdf", false))
+	assert.True(t, ignore.FileShouldBeIgnored("build/output/app.js", false))
+	assert.False(t, ignore.FileShouldBeIgnored("build", true))
+}
+
+func TestCfIgnoreDirectoryOnlyPatterns(t *testing.T) {
+	ignore, err := newCfIgnore([]string{"cache/"})
+	assert.NoError(t, err)
+
+	assert.True(t, ignore.FileShouldBeIgnored("cache", true))
+	assert.True(t, ignore.FileShouldBeIgnored("app/cache", true))
+	assert.False(t, ignore.FileShouldBeIgnored("cache", false))
+}
+
+func TestCfIgnoreNegatedPatterns(t *testing.T) {
+	ignore, err := newCfIgnore([]string{"*.log", "!important.log", "# a comment", "", "\\#notes"})
+	assert.NoError(t, err)
+
+	assert.True(t, ignore.FileShouldBeIgnored("dev.log", false))
+	assert.False(t, ignore.FileShouldBeIgnored("important.log", false))
+	assert.False(t, ignore.FileShouldBeIgnored("logs/important.log", false))
+	assert.True(t, ignore.FileShouldBeIgnored("#notes", false))
+	assert.False(t, ignore.FileShouldBeIgnored("# a comment", false))
+}
+
+func TestCfIgnoreCharacterClasses(t *testing.T) {
+	ignore, err := newCfIgnore([]string{"file?.txt", "[abc].md", "[!x]y.go"})
+	assert.NoError(t, err)
+
+	assert.True(t, ignore.FileShouldBeIgnored("file1.txt", false))
+	assert.False(t, ignore.FileShouldBeIgnored("file10.txt", false))
+	assert.True(t, ignore.FileShouldBeIgnored("b.md", false))
+	assert.False(t, ignore.FileShouldBeIgnored("d.md", false))
+	assert.True(t, ignore.FileShouldBeIgnored("ay.go", false))
+	assert.False(t, ignore.FileShouldBeIgnored("xy.go", false))
+}
+
+func TestReadCfIgnoreIncludesDefaults(t *testing.T) {
+	ignore, err := readCfIgnore("/a/dir/without/a/cfignore")
+	assert.NoError(t, err)
+
+	assert.True(t, ignore.FileShouldBeIgnored(".git", true))
+	assert.True(t, ignore.FileShouldBeIgnored("vendor/lib/.svn", true))
+	assert.True(t, ignore.FileShouldBeIgnored("_darcs", true))
+	assert.True(t, ignore.FileShouldBeIgnored(".hg", true))
+	assert.True(t, ignore.FileShouldBeIgnored(".cfignore", false))
+	assert.False(t, ignore.FileShouldBeIgnored("app.rb", false))
+}
+
+func TestReadCfIgnoreDefaultsCannotBeNegated(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cfignore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, ".cfignore"), []byte("!.git/\n!.cfignore\n*.log\n"), 0644)
+	assert.NoError(t, err)
+
+	ignore, err := readCfIgnore(dir)
+	assert.NoError(t, err)
+
+	assert.True(t, ignore.FileShouldBeIgnored(".git", true))
+	assert.True(t, ignore.FileShouldBeIgnored(".cfignore", false))
+	assert.True(t, ignore.FileShouldBeIgnored("dev.log", false))
+}
+
+func TestCfIgnoreRejectsInvalidPatterns(t *testing.T) {
+	_, err := newCfIgnore([]string{"*.log", "[z-a].txt"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `"[z-a].txt"`)
+
+	_, err = newCfIgnore([]string{"[]"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+	"strings"
+)
+
+type Alias struct {
+	ui         terminal.UI
+	configRepo configuration.ConfigurationRepository
+	isCommand  func(name string) bool
+}
+
+func NewAlias(ui terminal.UI, configRepo configuration.ConfigurationRepository, isCommand func(name string) bool) (cmd Alias) {
+	cmd.ui = ui
+	cmd.configRepo = configRepo
+	cmd.isCommand = isCommand
+	return
+}
+
+func (cmd Alias) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) < 2 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "alias")
+	}
+	return
+}
+
+func (cmd Alias) Run(c *cli.Context) {
+	name := c.Args()[0]
+	commandArgs, err := splitCommandLine(strings.Join(c.Args()[1:], " "))
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+	command := joinCommandLine(commandArgs)
+
+	cmd.ui.Say("Creating alias %s for %s...",
+		terminal.EntityNameColor(name),
+		terminal.CommandColor(command),
+	)
+
+	if strings.ContainsAny(name, " \t\n") || strings.HasPrefix(name, "-") {
+		cmd.ui.Failed("%s is not a valid alias name.", name)
+		return
+	}
+
+	if cmd.isCommand(name) || c.App.Command(name) != nil {
+		cmd.ui.Failed("%s is already a cf command and cannot be used as an alias.", name)
+		return
+	}
+
+	if len(commandArgs) == 0 || (!cmd.isCommand(commandArgs[0]) && c.App.Command(commandArgs[0]) == nil) {
+		cmd.ui.Failed("Aliases must start with a cf command.")
+		return
+	}
+
+	err = cmd.configRepo.SetAlias(name, command)
+	if err != nil {
+		cmd.ui.ConfigFailure(err)
+		return
+	}
+
+	cmd.ui.Ok()
+}
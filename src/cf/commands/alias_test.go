@@ -0,0 +1,107 @@
+package commands_test
+
+import (
+	. "cf/commands"
+	"cf/configuration"
+	"github.com/codegangsta/cli"
+	"github.com/stretchr/testify/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestAliasFailsWithUsage(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+
+	ui := callAlias([]string{}, configRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callAlias([]string{"deploy"}, configRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callAlias([]string{"deploy", "push"}, configRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestAlias(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	defer configRepo.Delete()
+
+	ui := callAlias([]string{"deploy", "push -m 512M  -i 2"}, configRepo)
+
+	assert.Contains(t, ui.Outputs[0], "Creating alias")
+	assert.Contains(t, ui.Outputs[0], "deploy")
+	assert.Contains(t, ui.Outputs[0], "push -m 512M -i 2")
+	assert.Contains(t, ui.Outputs[1], "OK")
+
+	config, _ := configRepo.Get()
+	assert.Equal(t, config.Aliases["deploy"], "push -m 512M -i 2")
+}
+
+func TestAliasKeepsQuotedArgsTogether(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	defer configRepo.Delete()
+
+	callAlias([]string{"deploy", `push -c "bundle exec rake"`}, configRepo)
+
+	config, _ := configRepo.Get()
+	assert.Equal(t, config.Aliases["deploy"], "push -c 'bundle exec rake'")
+
+	ui := callAlias([]string{"deploy", `push -c "bundle exec`}, configRepo)
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "Unterminated quote")
+}
+
+func TestAliasCannotShadowCommands(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	defer configRepo.Delete()
+
+	ui := callAlias([]string{"push", "apps"}, configRepo)
+
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "push is already a cf command")
+
+	ui = callAlias([]string{"p", "apps"}, configRepo)
+
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "p is already a cf command")
+
+	config, _ := configRepo.Get()
+	assert.Empty(t, config.Aliases)
+}
+
+func TestAliasMustStartWithACommand(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	defer configRepo.Delete()
+
+	ui := callAlias([]string{"deploy", "my-app"}, configRepo)
+
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "must start with a cf command")
+
+	ui = callAlias([]string{"my deploy", "push"}, configRepo)
+
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "not a valid alias name")
+
+	config, _ := configRepo.Get()
+	assert.Empty(t, config.Aliases)
+}
+
+func callAlias(args []string, configRepo configuration.ConfigurationRepository) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	isCommand := func(name string) bool {
+		return name == "push" || name == "apps"
+	}
+	cmd := NewAlias(ui, configRepo, isCommand)
+	ctxt := testcmd.NewContext("alias", args)
+	ctxt.App.Commands = []cli.Command{{Name: "push", ShortName: "p"}, {Name: "apps", ShortName: "a"}}
+	testcmd.RunCommand(cmd, ctxt, &testreq.FakeReqFactory{})
+	return
+}
@@ -40,6 +40,7 @@ func (cmd Api) Run(c *cli.Context) {
 		return
 	}
 
+	cmd.config.SSLDisabled = c.Bool("skip-ssl-validation")
 	cmd.SetApiEndpoint(c.Args()[0])
 }
 
@@ -61,6 +62,8 @@ func (cmd Api) SetApiEndpoint(endpoint string) {
 
 	if !strings.HasPrefix(endpoint, "https://") {
 		cmd.ui.Say(terminal.WarningColor("Warning: Insecure http API endpoint detected: secure https API endpoints are recommended\n"))
+	} else if cmd.config.SSLDisabled {
+		cmd.ui.Say(terminal.WarningColor("Warning: SSL validation is disabled for this API endpoint\n"))
 	}
 
 	cmd.ui.ShowConfiguration(cmd.config)
@@ -47,6 +47,27 @@ func TestApiWithTrailingSlash(t *testing.T) {
 	assert.Contains(t, ui.Outputs[1], "OK")
 }
 
+func TestApiWithSkipSSLValidation(t *testing.T) {
+	endpointRepo := &testapi.FakeEndpointRepo{}
+	config := &configuration.Configuration{}
+
+	ui := callApi([]string{"--skip-ssl-validation", "https://example.com"}, config, endpointRepo)
+
+	assert.True(t, config.SSLDisabled)
+	assert.Equal(t, endpointRepo.UpdateEndpointEndpoint, "https://example.com")
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[3], "SSL validation is disabled")
+}
+
+func TestApiWithoutSkipSSLValidationEnablesValidation(t *testing.T) {
+	endpointRepo := &testapi.FakeEndpointRepo{}
+	config := &configuration.Configuration{SSLDisabled: true}
+
+	callApi([]string{"https://example.com"}, config, endpointRepo)
+
+	assert.False(t, config.SSLDisabled)
+}
+
 func callApi(args []string, config *configuration.Configuration, endpointRepo *testapi.FakeEndpointRepo) (ui *testterm.FakeUI) {
 	ui = new(testterm.FakeUI)
 
@@ -10,6 +10,17 @@ import (
 	"strings"
 )
 
+type appRecord struct {
+	Name             string   `json:"name" yaml:"name"`
+	Guid             string   `json:"guid" yaml:"guid"`
+	State            string   `json:"state" yaml:"state"`
+	Instances        int      `json:"instances" yaml:"instances"`
+	RunningInstances int      `json:"running_instances" yaml:"running_instances"`
+	Memory           uint64   `json:"memory" yaml:"memory"`
+	DiskQuota        uint64   `json:"disk_quota" yaml:"disk_quota"`
+	Urls             []string `json:"urls" yaml:"urls"`
+}
+
 type ListApps struct {
 	ui             terminal.UI
 	config         *configuration.Configuration
@@ -51,9 +62,10 @@ func (cmd ListApps) Run(c *cli.Context) {
 	table := [][]string{
 		[]string{"name", "state", "instances", "memory", "disk", "urls"},
 	}
+	records := []appRecord{}
 
 	for _, app := range apps {
-		var urls []string
+		urls := []string{}
 		for _, route := range app.Routes {
 			urls = append(urls, route.URL())
 		}
@@ -66,7 +78,18 @@ func (cmd ListApps) Run(c *cli.Context) {
 			formatters.ByteSize(app.DiskQuota * formatters.MEGABYTE),
 			strings.Join(urls, ", "),
 		})
+
+		records = append(records, appRecord{
+			Name:             app.Name,
+			Guid:             app.Guid,
+			State:            app.State,
+			Instances:        app.Instances,
+			RunningInstances: app.RunningInstances,
+			Memory:           app.Memory,
+			DiskQuota:        app.DiskQuota,
+			Urls:             urls,
+		})
 	}
 
-	cmd.ui.DisplayTable(table)
+	cmd.ui.DisplayRecords(table, records)
 }
@@ -4,6 +4,7 @@ import (
 	"cf"
 	. "cf/commands/application"
 	"cf/configuration"
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	testapi "testhelpers/api"
 	testcmd "testhelpers/commands"
@@ -53,6 +54,11 @@ func TestApps(t *testing.T) {
 	assert.Contains(t, ui.Outputs[5], "256M")
 	assert.Contains(t, ui.Outputs[5], "1G")
 	assert.Contains(t, ui.Outputs[5], "app2.cfapps.io")
+
+	records, err := json.Marshal(ui.Records)
+	assert.NoError(t, err)
+	assert.Contains(t, string(records), `"name":"Application-1","guid":"","state":"started","instances":1,"running_instances":1,"memory":512,"disk_quota":1024,"urls":["app1.cfapps.io","app1.example.com"]`)
+	assert.Contains(t, string(records), `"name":"Application-2"`)
 }
 
 func TestAppsRequiresLogin(t *testing.T) {
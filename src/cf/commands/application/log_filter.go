@@ -0,0 +1,148 @@
+package application
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/cloudfoundry/loggregatorlib/logmessage"
+	"github.com/codegangsta/cli"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	logFormatJson = "json"
+	logFormatRaw  = "raw"
+)
+
+type logFilter struct {
+	sourceTypes []string
+	instance    string
+	stdout      bool
+	stderr      bool
+	pattern     *regexp.Regexp
+	since       time.Time
+}
+
+func newLogFilter(c *cli.Context) (filter logFilter, err error) {
+	filter.sourceTypes = c.StringSlice("source")
+	filter.instance = c.String("instance")
+	filter.stdout = c.Bool("stdout")
+	filter.stderr = c.Bool("stderr")
+
+	if c.String("grep") != "" {
+		filter.pattern, err = regexp.Compile(c.String("grep"))
+		if err != nil {
+			err = fmt.Errorf("Invalid regular expression '%s':\n%s", c.String("grep"), err.Error())
+			return
+		}
+	}
+
+	if c.String("since") != "" {
+		if !c.Bool("recent") {
+			err = errors.New("--since can only be used with --recent")
+			return
+		}
+
+		var duration time.Duration
+		duration, err = time.ParseDuration(c.String("since"))
+		if err != nil {
+			err = fmt.Errorf("Invalid duration '%s', expected a value such as 30s, 10m or 1h", c.String("since"))
+			return
+		}
+		filter.since = time.Now().Add(-duration)
+	}
+	return
+}
+
+// matches reports whether a message passes every filter that was given. The
+// instance filter only ever matches App messages, since only those carry an
+// instance index as their source id.
+func (filter logFilter) matches(msg *logmessage.Message) bool {
+	logMsg := msg.GetLogMessage()
+
+	if len(filter.sourceTypes) > 0 && !containsFold(filter.sourceTypes, msg.GetShortSourceTypeName()) {
+		return false
+	}
+
+	if filter.instance != "" {
+		if logMsg.GetSourceType() != logmessage.LogMessage_WARDEN_CONTAINER || logMsg.GetSourceId() != filter.instance {
+			return false
+		}
+	}
+
+	if filter.stdout != filter.stderr {
+		isStderr := logMsg.GetMessageType() == logmessage.LogMessage_ERR
+		if isStderr != filter.stderr {
+			return false
+		}
+	}
+
+	if filter.pattern != nil && !filter.pattern.Match(logMsg.GetMessage()) {
+		return false
+	}
+
+	if !filter.since.IsZero() && logMsg.GetTimestamp() < filter.since.UnixNano() {
+		return false
+	}
+
+	return true
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+type logMessageRecord struct {
+	App         string `json:"app"`
+	Timestamp   string `json:"timestamp"`
+	SourceType  string `json:"source_type"`
+	SourceId    string `json:"source_id"`
+	MessageType string `json:"message_type"`
+	Message     string `json:"message"`
+}
+
+func validateLogFormat(format string) (err error) {
+	switch format {
+	case "", logFormatJson, logFormatRaw:
+	default:
+		err = fmt.Errorf("Invalid log format %s. Valid formats are %s and %s.", format, logFormatJson, logFormatRaw)
+	}
+	return
+}
+
+// formatLogMessage renders a message as colored text, as one json object per
+// line, or as the bare message body for piping into other tools.
+func formatLogMessage(msg *logmessage.Message, format string, appName string) string {
+	switch format {
+	case logFormatJson:
+		logMsg := msg.GetLogMessage()
+		record := logMessageRecord{
+			App:         appName,
+			Timestamp:   time.Unix(0, logMsg.GetTimestamp()).Format(time.RFC3339Nano),
+			SourceType:  msg.GetShortSourceTypeName(),
+			SourceId:    logMsg.GetSourceId(),
+			MessageType: "OUT",
+			Message:     simpleLogMessageOutput(msg),
+		}
+		if logMsg.GetMessageType() == logmessage.LogMessage_ERR {
+			record.MessageType = "ERR"
+		}
+
+		data, err := json.Marshal(record)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	case logFormatRaw:
+		return simpleLogMessageOutput(msg)
+	}
+
+	return logMessageOutput(msg)
+}
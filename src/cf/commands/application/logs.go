@@ -1,69 +1,114 @@
 package application
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
 	"errors"
+	"fmt"
 	"github.com/cloudfoundry/loggregatorlib/logmessage"
 	"github.com/codegangsta/cli"
+	"strings"
 	"time"
 )
 
 type Logs struct {
-	ui       terminal.UI
-	config   *configuration.Configuration
-	logsRepo api.LogsRepository
-	appReq   requirements.ApplicationRequirement
+	ui             terminal.UI
+	config         *configuration.Configuration
+	logsRepo       api.LogsRepository
+	appRepo        api.ApplicationRepository
+	appSummaryRepo api.AppSummaryRepository
+	appReq         requirements.ApplicationRequirement
 }
 
-func NewLogs(ui terminal.UI, config *configuration.Configuration, logsRepo api.LogsRepository) (cmd *Logs) {
+func NewLogs(ui terminal.UI, config *configuration.Configuration, logsRepo api.LogsRepository, appRepo api.ApplicationRepository, appSummaryRepo api.AppSummaryRepository) (cmd *Logs) {
 	cmd = new(Logs)
 	cmd.ui = ui
 	cmd.config = config
 	cmd.logsRepo = logsRepo
+	cmd.appRepo = appRepo
+	cmd.appSummaryRepo = appSummaryRepo
 	return
 }
 
 func (cmd *Logs) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
-	if len(c.Args()) != 1 {
+	argCount := len(c.Args())
+	if (c.Bool("space") && argCount != 0) || (!c.Bool("space") && argCount == 0) {
 		cmd.ui.FailWithUsage(c, "logs")
 		err = errors.New("Incorrect Usage")
 		return
 	}
 
-	cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
-
 	reqs = []requirements.Requirement{
 		reqFactory.NewLoginRequirement(),
-		cmd.appReq,
+	}
+
+	if argCount == 1 {
+		cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
+		reqs = append(reqs, cmd.appReq)
+	} else {
+		cmd.appReq = nil
+		reqs = append(reqs, reqFactory.NewTargetedSpaceRequirement())
 	}
 
 	return
 }
 
 func (cmd *Logs) Run(c *cli.Context) {
-	app := cmd.appReq.GetApplication()
+	filter, err := newLogFilter(c)
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	format := c.String("format")
+	err = validateLogFormat(format)
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	apps, ok := cmd.findApps(c)
+	if !ok {
+		return
+	}
+
+	appNames := []string{}
+	for _, app := range apps {
+		appNames = append(appNames, app.Name)
+	}
+
+	description := "app " + terminal.EntityNameColor(appNames[0])
+	if len(apps) > 1 {
+		description = "apps " + terminal.EntityNameColor(strings.Join(appNames, ", "))
+	}
 
 	logChan := make(chan *logmessage.Message, 1000)
-	var err error
 
 	if c.Bool("recent") {
 		onConnect := func() {
-			cmd.ui.Say("Connected, dumping recent logs for app %s in org %s / space %s as %s...\n",
-				terminal.EntityNameColor(app.Name),
+			if format != "" {
+				return
+			}
+			cmd.ui.Say("Connected, dumping recent logs for %s in org %s / space %s as %s...\n",
+				description,
 				terminal.EntityNameColor(cmd.config.Organization.Name),
 				terminal.EntityNameColor(cmd.config.Space.Name),
 				terminal.EntityNameColor(cmd.config.Username()),
 			)
 		}
-		err = cmd.logsRepo.RecentLogsFor(app, onConnect, logChan)
+		err = cmd.logsRepo.RecentLogsForApps(apps, onConnect, logChan)
 	} else {
 
 		onConnect := func() {
-			cmd.ui.Say("Connected, tailing logs for app %s in org %s / space %s as %s...\n",
-				terminal.EntityNameColor(app.Name),
+			if format != "" {
+				return
+			}
+			cmd.ui.Say("Connected, tailing logs for %s in org %s / space %s as %s...\n",
+				description,
 				terminal.EntityNameColor(cmd.config.Organization.Name),
 				terminal.EntityNameColor(cmd.config.Space.Name),
 				terminal.EntityNameColor(cmd.config.Username()),
@@ -72,17 +117,75 @@ func (cmd *Logs) Run(c *cli.Context) {
 		// in this case we tail the logs forever, so we never send true on this channel
 		stopLoggingChan := make(chan bool)
 
-		err = cmd.logsRepo.TailLogsFor(app, onConnect, logChan, stopLoggingChan, 5*time.Second)
+		err = cmd.logsRepo.TailLogsForApps(apps, onConnect, logChan, stopLoggingChan, 5*time.Second)
 	}
 	if err != nil {
 		cmd.ui.Failed(err.Error())
 		return
 	}
-	cmd.displayLogMessages(logChan)
+	cmd.displayLogMessages(logChan, apps, filter, format)
+}
+
+func (cmd *Logs) findApps(c *cli.Context) (apps []cf.Application, ok bool) {
+	if cmd.appReq != nil {
+		apps = []cf.Application{cmd.appReq.GetApplication()}
+		ok = true
+		return
+	}
+
+	if c.Bool("space") {
+		var apiResponse net.ApiResponse
+		apps, apiResponse = cmd.appSummaryRepo.GetSummariesInCurrentSpace()
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+		if len(apps) == 0 {
+			cmd.ui.Failed("No apps found in space %s", cmd.config.Space.Name)
+			return
+		}
+		ok = true
+		return
+	}
+
+	for _, appName := range c.Args() {
+		app, apiResponse := cmd.appRepo.FindByName(appName)
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+		apps = append(apps, app)
+	}
+
+	ok = true
+	return
 }
 
-func (cmd *Logs) displayLogMessages(logChan chan *logmessage.Message) {
+// displayLogMessages prefixes each line with the name of its app when logs
+// from more than one app are merged.
+func (cmd *Logs) displayLogMessages(logChan chan *logmessage.Message, apps []cf.Application, filter logFilter, format string) {
+	appNames := map[string]string{}
+	longestName := 0
+	for _, app := range apps {
+		appNames[app.Guid] = app.Name
+		if len(app.Name) > longestName {
+			longestName = len(app.Name)
+		}
+	}
+
 	for msg := range logChan {
-		cmd.ui.Say(logMessageOutput(msg))
+		if !filter.matches(msg) {
+			continue
+		}
+
+		appName := appNames[msg.GetLogMessage().GetAppId()]
+		line := formatLogMessage(msg, format, appName)
+
+		if len(apps) > 1 && format == "" {
+			padding := strings.Repeat(" ", longestName-len(appName))
+			line = fmt.Sprintf("%s%s  %s", terminal.LogAppNameColor(appName), padding, line)
+		}
+
+		cmd.ui.Say("%s", line)
 	}
 }
@@ -4,7 +4,9 @@ import (
 	"cf"
 	. "cf/commands/application"
 	"cf/configuration"
+	"cf/terminal"
 	"code.google.com/p/gogoprotobuf/proto"
+	"encoding/json"
 	"github.com/cloudfoundry/loggregatorlib/logmessage"
 	"github.com/stretchr/testify/assert"
 	testapi "testhelpers/api"
@@ -119,13 +121,212 @@ func TestLogsTailsTheAppLogs(t *testing.T) {
 	assert.Contains(t, ui.Outputs[1], "Log Line 1")
 }
 
+func TestLogsFiltersBySourceInstanceStreamAndPattern(t *testing.T) {
+	reqFactory, logsRepo := getLogsDependencies()
+	logsRepo.RecentLogs = filterTestLogMessages(time.Now())
+
+	ui := callLogs(t, []string{"--recent", "--source", "app", "my-app"}, reqFactory, logsRepo)
+	assert.Equal(t, len(ui.Outputs), 3)
+	assert.Contains(t, ui.Outputs[1], "GET /foo")
+	assert.Contains(t, ui.Outputs[2], "boom")
+
+	ui = callLogs(t, []string{"--recent", "--source", "RTR", "--source", "API", "my-app"}, reqFactory, logsRepo)
+	assert.Equal(t, len(ui.Outputs), 3)
+	assert.Contains(t, ui.Outputs[1], "GET / 200")
+	assert.Contains(t, ui.Outputs[2], "staging failed")
+
+	ui = callLogs(t, []string{"--recent", "--instance", "1", "my-app"}, reqFactory, logsRepo)
+	assert.Equal(t, len(ui.Outputs), 2)
+	assert.Contains(t, ui.Outputs[1], "boom")
+
+	ui = callLogs(t, []string{"--recent", "--stderr", "my-app"}, reqFactory, logsRepo)
+	assert.Equal(t, len(ui.Outputs), 3)
+	assert.Contains(t, ui.Outputs[1], "boom")
+	assert.Contains(t, ui.Outputs[2], "staging failed")
+
+	ui = callLogs(t, []string{"--recent", "--stdout", "--grep", "^GET /[a-z]", "my-app"}, reqFactory, logsRepo)
+	assert.Equal(t, len(ui.Outputs), 2)
+	assert.Contains(t, ui.Outputs[1], "GET /foo")
+}
+
+func TestLogsRecentSince(t *testing.T) {
+	reqFactory, logsRepo := getLogsDependencies()
+	logsRepo.RecentLogs = append(filterTestLogMessages(time.Now().Add(-time.Hour)), logMessageForTest("recent line", logmessage.LogMessage_WARDEN_CONTAINER, "0", logmessage.LogMessage_OUT, time.Now()))
+
+	ui := callLogs(t, []string{"--recent", "--since", "10m", "my-app"}, reqFactory, logsRepo)
+	assert.Equal(t, len(ui.Outputs), 2)
+	assert.Contains(t, ui.Outputs[1], "recent line")
+
+	ui = callLogs(t, []string{"--since", "10m", "my-app"}, reqFactory, logsRepo)
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "--since can only be used with --recent")
+
+	ui = callLogs(t, []string{"--recent", "--since", "ten minutes", "my-app"}, reqFactory, logsRepo)
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "Invalid duration")
+}
+
+func TestLogsWithInvalidFilters(t *testing.T) {
+	reqFactory, logsRepo := getLogsDependencies()
+
+	ui := callLogs(t, []string{"--grep", "(unclosed", "my-app"}, reqFactory, logsRepo)
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "Invalid regular expression")
+
+	ui = callLogs(t, []string{"--format", "xml", "my-app"}, reqFactory, logsRepo)
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "Invalid log format xml")
+}
+
+func TestLogsFormatJsonAndRaw(t *testing.T) {
+	reqFactory, logsRepo := getLogsDependencies()
+	timestamp := time.Date(2014, 1, 2, 3, 4, 5, 0, time.UTC)
+	logsRepo.RecentLogs = []logmessage.LogMessage{
+		logMessageForTest("boom\n", logmessage.LogMessage_WARDEN_CONTAINER, "1", logmessage.LogMessage_ERR, timestamp),
+	}
+
+	ui := callLogs(t, []string{"--recent", "--format", "json", "my-app"}, reqFactory, logsRepo)
+	assert.Equal(t, len(ui.Outputs), 1)
+
+	record := map[string]string{}
+	err := json.Unmarshal([]byte(ui.Outputs[0]), &record)
+	assert.NoError(t, err)
+	assert.Equal(t, record, map[string]string{
+		"app":          "",
+		"timestamp":    timestamp.Local().Format(time.RFC3339Nano),
+		"source_type":  "App",
+		"source_id":    "1",
+		"message_type": "ERR",
+		"message":      "boom",
+	})
+
+	ui = callLogs(t, []string{"--recent", "--format", "raw", "my-app"}, reqFactory, logsRepo)
+	assert.Equal(t, ui.Outputs, []string{"boom"})
+}
+
+func TestLogsForMultipleAppsFailsWithUsage(t *testing.T) {
+	reqFactory, logsRepo := getLogsDependencies()
+
+	ui := callLogs(t, []string{"--space", "my-app"}, reqFactory, logsRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callLogs(t, []string{"--space"}, reqFactory, logsRepo)
+	assert.False(t, ui.FailedWithUsage)
+
+	ui = callLogs(t, []string{"my-app", "my-worker"}, reqFactory, logsRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestLogsForMultipleAppsRequiresATargetedSpace(t *testing.T) {
+	reqFactory, logsRepo := getLogsDependencies()
+	reqFactory.TargetedSpaceSuccess = false
+
+	callLogs(t, []string{"my-app", "my-worker"}, reqFactory, logsRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	callLogs(t, []string{"--space"}, reqFactory, logsRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory.TargetedSpaceSuccess = true
+	callLogs(t, []string{"--space"}, reqFactory, logsRepo)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestLogsForMultipleAppsPrefixesEachLineWithTheAppName(t *testing.T) {
+	reqFactory, logsRepo := getLogsDependencies()
+	logsRepo.TailLogMessages = multiAppLogMessages()
+
+	appRepo := &testapi.FakeApplicationRepository{
+		FindByNameApps: map[string]cf.Application{
+			"my-app":    {Name: "my-app", Guid: "my-app-guid"},
+			"my-worker": {Name: "my-worker", Guid: "my-worker-guid"},
+		},
+	}
+
+	ui := callLogsForApps(t, []string{"my-app", "my-worker"}, reqFactory, logsRepo, appRepo, &testapi.FakeAppSummaryRepo{})
+
+	assert.Equal(t, len(logsRepo.AppsLogged), 2)
+	assert.Equal(t, logsRepo.AppsLogged[0].Guid, "my-app-guid")
+	assert.Equal(t, logsRepo.AppsLogged[1].Guid, "my-worker-guid")
+
+	assert.Equal(t, len(ui.Outputs), 3)
+	assert.Contains(t, ui.Outputs[0], "Connected, tailing logs for apps")
+	assert.Contains(t, ui.Outputs[0], "my-app, my-worker")
+
+	assert.Contains(t, ui.Outputs[1], terminal.LogAppNameColor("my-app")+"     ")
+	assert.Contains(t, ui.Outputs[1], "request received")
+	assert.Contains(t, ui.Outputs[2], terminal.LogAppNameColor("my-worker")+"  ")
+	assert.Contains(t, ui.Outputs[2], "job done")
+
+	ui = callLogsForApps(t, []string{"my-app", "no-such-app"}, reqFactory, logsRepo, appRepo, &testapi.FakeAppSummaryRepo{})
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "no-such-app")
+}
+
+func TestLogsForTheWholeSpace(t *testing.T) {
+	reqFactory, logsRepo := getLogsDependencies()
+	logsRepo.RecentLogs = multiAppLogMessages()
+
+	appSummaryRepo := &testapi.FakeAppSummaryRepo{
+		GetSummariesInCurrentSpaceApps: []cf.Application{
+			{Name: "my-app", Guid: "my-app-guid"},
+			{Name: "my-worker", Guid: "my-worker-guid"},
+		},
+	}
+
+	ui := callLogsForApps(t, []string{"--recent", "--space", "--format", "json"}, reqFactory, logsRepo, &testapi.FakeApplicationRepository{}, appSummaryRepo)
+
+	assert.Equal(t, len(logsRepo.AppsLogged), 2)
+	assert.Equal(t, len(ui.Outputs), 2)
+	assert.Contains(t, ui.Outputs[0], `"app":"my-app"`)
+	assert.Contains(t, ui.Outputs[1], `"app":"my-worker"`)
+
+	ui = callLogsForApps(t, []string{"--space"}, reqFactory, logsRepo, &testapi.FakeApplicationRepository{}, &testapi.FakeAppSummaryRepo{})
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "No apps found in space my-space")
+}
+
+func multiAppLogMessages() []logmessage.LogMessage {
+	appMessage := logMessageForTest("request received", logmessage.LogMessage_WARDEN_CONTAINER, "0", logmessage.LogMessage_OUT, time.Now())
+	appMessage.AppId = proto.String("my-app-guid")
+
+	workerMessage := logMessageForTest("job done", logmessage.LogMessage_WARDEN_CONTAINER, "0", logmessage.LogMessage_OUT, time.Now())
+	workerMessage.AppId = proto.String("my-worker-guid")
+
+	return []logmessage.LogMessage{appMessage, workerMessage}
+}
+
+func filterTestLogMessages(timestamp time.Time) []logmessage.LogMessage {
+	return []logmessage.LogMessage{
+		logMessageForTest("GET /foo", logmessage.LogMessage_WARDEN_CONTAINER, "0", logmessage.LogMessage_OUT, timestamp),
+		logMessageForTest("boom", logmessage.LogMessage_WARDEN_CONTAINER, "1", logmessage.LogMessage_ERR, timestamp),
+		logMessageForTest("GET / 200", logmessage.LogMessage_ROUTER, "1", logmessage.LogMessage_OUT, timestamp),
+		logMessageForTest("staging failed", logmessage.LogMessage_CLOUD_CONTROLLER, "", logmessage.LogMessage_ERR, timestamp),
+	}
+}
+
+func logMessageForTest(message string, sourceType logmessage.LogMessage_SourceType, sourceId string, messageType logmessage.LogMessage_MessageType, timestamp time.Time) logmessage.LogMessage {
+	return logmessage.LogMessage{
+		Message:     []byte(message),
+		AppId:       proto.String("my-app"),
+		MessageType: &messageType,
+		SourceType:  &sourceType,
+		SourceId:    proto.String(sourceId),
+		Timestamp:   proto.Int64(timestamp.UnixNano()),
+	}
+}
+
 func getLogsDependencies() (reqFactory *testreq.FakeReqFactory, logsRepo *testapi.FakeLogsRepository) {
 	logsRepo = &testapi.FakeLogsRepository{}
-	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
 	return
 }
 
 func callLogs(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, logsRepo *testapi.FakeLogsRepository) (ui *testterm.FakeUI) {
+	return callLogsForApps(t, args, reqFactory, logsRepo, &testapi.FakeApplicationRepository{}, &testapi.FakeAppSummaryRepo{})
+}
+
+func callLogsForApps(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, logsRepo *testapi.FakeLogsRepository, appRepo *testapi.FakeApplicationRepository, appSummaryRepo *testapi.FakeAppSummaryRepo) (ui *testterm.FakeUI) {
 	ui = new(testterm.FakeUI)
 	ctxt := testcmd.NewContext("logs", args)
 
@@ -140,7 +341,7 @@ func callLogs(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, l
 		AccessToken:  token,
 	}
 
-	cmd := NewLogs(ui, config, logsRepo)
+	cmd := NewLogs(ui, config, logsRepo, appRepo, appSummaryRepo)
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 	return
 }
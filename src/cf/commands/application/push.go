@@ -4,39 +4,54 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/manifest"
 	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
+	"errors"
+	"fmt"
 	"github.com/codegangsta/cli"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
 
+const blueGreenStrategy = "blue-green"
+
 type Push struct {
-	ui          terminal.UI
-	config      *configuration.Configuration
-	starter     ApplicationStarter
-	stopper     ApplicationStopper
-	appRepo     api.ApplicationRepository
-	domainRepo  api.DomainRepository
-	routeRepo   api.RouteRepository
-	stackRepo   api.StackRepository
-	appBitsRepo api.ApplicationBitsRepository
+	ui                 terminal.UI
+	config             *configuration.Configuration
+	starter            ApplicationStarter
+	stopper            ApplicationStopper
+	manifestRepo       manifest.ManifestRepository
+	appRepo            api.ApplicationRepository
+	domainRepo         api.DomainRepository
+	routeRepo          api.RouteRepository
+	stackRepo          api.StackRepository
+	serviceRepo        api.ServiceRepository
+	serviceBindingRepo api.ServiceBindingRepository
+	serviceSummaryRepo api.ServiceSummaryRepository
+	appBitsRepo        api.ApplicationBitsRepository
 }
 
 func NewPush(ui terminal.UI, config *configuration.Configuration, starter ApplicationStarter, stopper ApplicationStopper,
-	aR api.ApplicationRepository, dR api.DomainRepository, rR api.RouteRepository, sR api.StackRepository,
-	appBitsRepo api.ApplicationBitsRepository) (cmd Push) {
+	manifestRepo manifest.ManifestRepository, aR api.ApplicationRepository, dR api.DomainRepository, rR api.RouteRepository,
+	sR api.StackRepository, serviceRepo api.ServiceRepository, serviceBindingRepo api.ServiceBindingRepository,
+	serviceSummaryRepo api.ServiceSummaryRepository, appBitsRepo api.ApplicationBitsRepository) (cmd Push) {
 
 	cmd.ui = ui
 	cmd.config = config
 	cmd.starter = starter
 	cmd.stopper = stopper
+	cmd.manifestRepo = manifestRepo
 	cmd.appRepo = aR
 	cmd.domainRepo = dR
 	cmd.routeRepo = rR
 	cmd.stackRepo = sR
+	cmd.serviceRepo = serviceRepo
+	cmd.serviceBindingRepo = serviceBindingRepo
+	cmd.serviceSummaryRepo = serviceSummaryRepo
 	cmd.appBitsRepo = appBitsRepo
 	return
 }
@@ -50,25 +65,226 @@ func (cmd Push) GetRequirements(reqFactory requirements.Factory, c *cli.Context)
 }
 
 func (cmd Push) Run(c *cli.Context) {
-	var (
-		apiResponse net.ApiResponse
-	)
-
-	if len(c.Args()) != 1 {
-		cmd.ui.FailWithUsage(c, "push")
+	appParams, ok := cmd.applicationsToPush(c)
+	if !ok {
 		return
 	}
 
-	app, didCreate := cmd.getApp(c)
+	for _, params := range appParams {
+		cmd.pushApp(params, c)
+	}
+}
 
-	domain := cmd.domain(c)
-	hostName := cmd.hostName(app, c)
+func (cmd Push) pushApp(appParams manifest.Application, c *cli.Context) {
+	app, didCreate := cmd.getApp(appParams)
+
+	if c.String("strategy") == blueGreenStrategy && !didCreate {
+		cmd.blueGreenPush(app, appParams, c)
+		return
+	}
+
+	domain := cmd.domain(appParams.Domain)
+	hostName := cmd.hostName(app, appParams.Host, c)
 	cmd.bindAppToRoute(app, domain, hostName, didCreate, c)
 
+	if !cmd.uploadApp(app, appParams) {
+		return
+	}
+
+	apiResponse := cmd.setEnvironmentVars(app, appParams.EnvironmentVars)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	apiResponse = cmd.bindServices(app, appParams.Services)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	cmd.restart(app, appParams.BuildpackUrl, c)
+}
+
+func (cmd Push) blueGreenPush(oldApp cf.Application, appParams manifest.Application, c *cli.Context) {
+	if c.String("n") != "" || c.String("d") != "" || c.Bool("no-hostname") {
+		cmd.ui.Failed("-n, -d and --no-hostname cannot be used with the %s strategy, the routes of %s are moved to the new version",
+			blueGreenStrategy, oldApp.Name)
+		return
+	}
+
+	newParams := appParams
+	newParams.Name = oldApp.Name + "-new"
+	if newParams.Instances == 0 {
+		newParams.Instances = oldApp.Instances
+	}
+	if newParams.Memory == "" {
+		newParams.Memory = fmt.Sprintf("%dM", oldApp.Memory)
+	}
+	if newParams.Command == "" {
+		newParams.Command = oldApp.Command
+	}
+	if newParams.BuildpackUrl == "" {
+		newParams.BuildpackUrl = oldApp.BuildpackUrl
+	}
+	if newParams.StackName == "" {
+		newParams.StackName = oldApp.Stack.Name
+	}
+
+	_, apiResponse := cmd.appRepo.FindByName(newParams.Name)
+	if apiResponse.IsSuccessful() {
+		cmd.ui.Failed("App %s already exists. Delete it before pushing %s again.", newParams.Name, oldApp.Name)
+		return
+	}
+	if apiResponse.IsError() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	serviceNames, ok := cmd.boundServiceNames(oldApp)
+	if !ok {
+		return
+	}
+
+	newApp, apiResponse := cmd.createApp(newParams)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	// until the old app is deleted, every failure removes the new one again so
+	// that the old app keeps serving and the next push can start over
+	err := cmd.prepareNewApp(oldApp, newApp, newParams, serviceNames, c)
+	if err != nil {
+		cmd.deleteTemporaryApp(newApp)
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	cmd.ui.Say("Deleting app %s...", terminal.EntityNameColor(oldApp.Name))
+
+	apiResponse = cmd.appRepo.Delete(oldApp)
+	if apiResponse.IsNotSuccessful() {
+		cmd.deleteTemporaryApp(newApp)
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	cmd.ui.Say("Renaming app %s to %s...", terminal.EntityNameColor(newApp.Name), terminal.EntityNameColor(oldApp.Name))
+
+	// the old app is gone by now, so the new one is kept for the user to rename
+	apiResponse = cmd.appRepo.Rename(newApp, oldApp.Name)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed("%s\nApp %s now serves the routes of %s. Use '%s rename %s %s' to finish the push.",
+			apiResponse.Message, newApp.Name, oldApp.Name, cf.Name(), newApp.Name, oldApp.Name)
+		return
+	}
+
+	cmd.ui.Ok()
+}
+
+// prepareNewApp uploads, configures and starts the new version of an app and
+// binds the old version's routes to it.
+func (cmd Push) prepareNewApp(oldApp, newApp cf.Application, newParams manifest.Application, serviceNames []string, c *cli.Context) (err error) {
+	cmd.ui.Say("Uploading %s...", terminal.EntityNameColor(newApp.Name))
+
+	apiResponse := cmd.appBitsRepo.UploadApp(newApp, cmd.appDir(newParams), cmd.ui.ShowProgress)
+	if apiResponse.IsNotSuccessful() {
+		return errors.New(apiResponse.Message)
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	apiResponse = cmd.setEnvironmentVars(newApp, mergeEnvironmentVars(oldApp.EnvironmentVars, newParams.EnvironmentVars))
+	if apiResponse.IsNotSuccessful() {
+		return errors.New(apiResponse.Message)
+	}
+
+	apiResponse = cmd.bindServices(newApp, mergeServiceNames(serviceNames, newParams.Services))
+	if apiResponse.IsNotSuccessful() {
+		return errors.New(apiResponse.Message)
+	}
+
+	if newParams.BuildpackUrl != "" {
+		newApp.BuildpackUrl = newParams.BuildpackUrl
+	}
+
+	_, err = cmd.starter.ApplicationStart(newApp)
+	if err != nil {
+		return
+	}
+
+	if c.Bool("no-route") {
+		return
+	}
+
+	// the routes stay bound to the old app too, until deleting it unbinds them
+	for _, route := range oldApp.Routes {
+		cmd.ui.Say("Binding %s to %s...", terminal.EntityNameColor(route.URL()), terminal.EntityNameColor(newApp.Name))
+
+		apiResponse = cmd.routeRepo.Bind(route, newApp)
+		if apiResponse.IsNotSuccessful() {
+			return errors.New(apiResponse.Message)
+		}
+
+		cmd.ui.Ok()
+		cmd.ui.Say("")
+	}
+	return
+}
+
+func (cmd Push) boundServiceNames(app cf.Application) (serviceNames []string, ok bool) {
+	instances, apiResponse := cmd.serviceSummaryRepo.GetSummariesInCurrentSpace()
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	for _, instance := range instances {
+		for _, appName := range instance.ApplicationNames {
+			if appName == app.Name {
+				serviceNames = append(serviceNames, instance.Name)
+				break
+			}
+		}
+	}
+
+	ok = true
+	return
+}
+
+func mergeServiceNames(existingNames, newNames []string) (mergedNames []string) {
+	seen := map[string]bool{}
+	for _, name := range append(existingNames, newNames...) {
+		if !seen[name] {
+			seen[name] = true
+			mergedNames = append(mergedNames, name)
+		}
+	}
+	return
+}
+
+func (cmd Push) deleteTemporaryApp(app cf.Application) {
+	cmd.ui.Say("Deleting app %s...", terminal.EntityNameColor(app.Name))
+
+	apiResponse := cmd.appRepo.Delete(app)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Warn("Could not delete app %s: %s", app.Name, apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+}
+
+func (cmd Push) uploadApp(app cf.Application, appParams manifest.Application) (ok bool) {
 	cmd.ui.Say("Uploading %s...", terminal.EntityNameColor(app.Name))
 
-	dir := cmd.path(c)
-	apiResponse = cmd.appBitsRepo.UploadApp(app, dir)
+	dir := cmd.appDir(appParams)
+	apiResponse := cmd.appBitsRepo.UploadApp(app, dir, cmd.ui.ShowProgress)
 	if apiResponse.IsNotSuccessful() {
 		cmd.ui.Failed(apiResponse.Message)
 		return
@@ -76,21 +292,178 @@ func (cmd Push) Run(c *cli.Context) {
 
 	cmd.ui.Ok()
 	cmd.ui.Say("")
+	ok = true
+	return
+}
+
+func (cmd Push) applicationsToPush(c *cli.Context) (apps []manifest.Application, ok bool) {
+	if len(c.Args()) > 1 {
+		cmd.ui.FailWithUsage(c, "push")
+		return
+	}
+
+	appManifest, ok := cmd.readManifest(c)
+	if !ok {
+		return
+	}
+
+	switch {
+	case appManifest == nil && len(c.Args()) == 1:
+		apps = []manifest.Application{{Name: c.Args()[0]}}
+	case appManifest == nil:
+	case len(c.Args()) == 0:
+		apps = appManifest.Applications
+	case len(appManifest.Applications) == 1:
+		app := appManifest.Applications[0]
+		app.Name = c.Args()[0]
+		apps = []manifest.Application{app}
+	default:
+		app, found := appManifest.FindApplication(c.Args()[0])
+		if !found {
+			cmd.ui.Failed("App %s not found in manifest", c.Args()[0])
+			ok = false
+			return
+		}
+		apps = []manifest.Application{app}
+	}
+
+	if len(apps) == 0 {
+		cmd.ui.FailWithUsage(c, "push")
+		ok = false
+		return
+	}
+
+	switch c.String("strategy") {
+	case "", blueGreenStrategy:
+	default:
+		cmd.ui.Failed("Unknown push strategy %s", c.String("strategy"))
+		ok = false
+		return
+	}
+
+	if c.String("strategy") == blueGreenStrategy && c.Bool("no-start") {
+		cmd.ui.Failed("The %s strategy cannot be used with --no-start", blueGreenStrategy)
+		ok = false
+		return
+	}
+
+	if len(apps) > 1 {
+		if flags := perAppFlagsUsed(c); len(flags) > 0 {
+			cmd.ui.Failed("Incorrect Usage: %s cannot be used when pushing every app in a manifest, give the name of the app to push",
+				strings.Join(flags, ", "))
+			ok = false
+			return
+		}
+	}
 
-	cmd.restart(app, c)
+	for index, app := range apps {
+		if app.Name == "" {
+			cmd.ui.Failed("Every application in the manifest must have a name")
+			ok = false
+			return
+		}
+		apps[index] = cmd.applyFlags(app, c)
+	}
+
+	return
 }
 
-func (cmd Push) getApp(c *cli.Context) (app cf.Application, didCreate bool) {
-	appName := c.Args()[0]
+func (cmd Push) readManifest(c *cli.Context) (appManifest *manifest.Manifest, ok bool) {
+	path := c.String("f")
+	isDefaultPath := path == ""
+	if isDefaultPath {
+		var err error
+		path, err = defaultManifestPath(c.String("p"))
+		if err != nil {
+			cmd.ui.Failed(err.Error())
+			return
+		}
+	}
+
+	appManifest, err := cmd.manifestRepo.ReadManifest(path)
+	if isDefaultPath && os.IsNotExist(err) {
+		ok = true
+		return
+	}
+	if err != nil {
+		cmd.ui.Failed("Error reading manifest file:\n%s", err.Error())
+		return
+	}
 
-	app, apiResponse := cmd.appRepo.FindByName(appName)
+	cmd.ui.Say("Using manifest file %s\n", terminal.EntityNameColor(path))
+	ok = true
+	return
+}
+
+func defaultManifestPath(appPath string) (path string, err error) {
+	dir := appPath
+	if dir == "" {
+		dir, err = os.Getwd()
+		if err != nil {
+			return
+		}
+	} else if fileInfo, statErr := os.Stat(dir); statErr == nil && !fileInfo.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	path = filepath.Join(dir, manifest.FileName)
+	return
+}
+
+// perAppFlagsUsed lists the flags given that set one app's settings, which
+// would otherwise be applied to every app in a manifest.
+func perAppFlagsUsed(c *cli.Context) (flags []string) {
+	for _, name := range []string{"b", "c", "d", "m", "n", "p", "s"} {
+		if c.String(name) != "" {
+			flags = append(flags, "-"+name)
+		}
+	}
+	if c.Int("i") > 0 {
+		flags = append(flags, "-i")
+	}
+	if c.Bool("no-hostname") {
+		flags = append(flags, "--no-hostname")
+	}
+	return
+}
+
+func (cmd Push) applyFlags(app manifest.Application, c *cli.Context) manifest.Application {
+	if c.String("b") != "" {
+		app.BuildpackUrl = c.String("b")
+	}
+	if c.String("c") != "" {
+		app.Command = c.String("c")
+	}
+	if c.String("d") != "" {
+		app.Domain = c.String("d")
+	}
+	if c.Int("i") > 0 {
+		app.Instances = c.Int("i")
+	}
+	if c.String("m") != "" {
+		app.Memory = c.String("m")
+	}
+	if c.String("n") != "" {
+		app.Host = c.String("n")
+	}
+	if c.String("p") != "" {
+		app.Path = c.String("p")
+	}
+	if c.String("s") != "" {
+		app.StackName = c.String("s")
+	}
+	return app
+}
+
+func (cmd Push) getApp(appParams manifest.Application) (app cf.Application, didCreate bool) {
+	app, apiResponse := cmd.appRepo.FindByName(appParams.Name)
 	if apiResponse.IsError() {
 		cmd.ui.Failed(apiResponse.Message)
 		return
 	}
 
 	if apiResponse.IsNotFound() {
-		app, apiResponse = cmd.createApp(appName, c)
+		app, apiResponse = cmd.createApp(appParams)
 		if apiResponse.IsNotSuccessful() {
 			cmd.ui.Failed(apiResponse.Message)
 			return
@@ -101,19 +474,22 @@ func (cmd Push) getApp(c *cli.Context) (app cf.Application, didCreate bool) {
 	return
 }
 
-func (cmd Push) createApp(appName string, c *cli.Context) (app cf.Application, apiResponse net.ApiResponse) {
+func (cmd Push) createApp(appParams manifest.Application) (app cf.Application, apiResponse net.ApiResponse) {
 	newApp := cf.Application{
-		Name:         appName,
-		Instances:    c.Int("i"),
-		Memory:       memoryLimit(c.String("m")),
-		BuildpackUrl: c.String("b"),
-		Command:      c.String("c"),
+		Name:         appParams.Name,
+		Instances:    appParams.Instances,
+		Memory:       memoryLimit(appParams.Memory),
+		BuildpackUrl: appParams.BuildpackUrl,
+		Command:      appParams.Command,
 	}
 
-	stackName := c.String("s")
-	if stackName != "" {
+	if newApp.Instances == 0 {
+		newApp.Instances = 1
+	}
+
+	if appParams.StackName != "" {
 		var stack cf.Stack
-		stack, apiResponse = cmd.stackRepo.FindByName(stackName)
+		stack, apiResponse = cmd.stackRepo.FindByName(appParams.StackName)
 
 		if apiResponse.IsNotSuccessful() {
 			cmd.ui.Failed(apiResponse.Message)
@@ -124,7 +500,7 @@ func (cmd Push) createApp(appName string, c *cli.Context) (app cf.Application, a
 	}
 
 	cmd.ui.Say("Creating app %s in org %s / space %s as %s...",
-		terminal.EntityNameColor(appName),
+		terminal.EntityNameColor(appParams.Name),
 		terminal.EntityNameColor(cmd.config.Organization.Name),
 		terminal.EntityNameColor(cmd.config.Space.Name),
 		terminal.EntityNameColor(cmd.config.Username()),
@@ -141,11 +517,9 @@ func (cmd Push) createApp(appName string, c *cli.Context) (app cf.Application, a
 	return
 }
 
-func (cmd Push) domain(c *cli.Context) (domain cf.Domain) {
+func (cmd Push) domain(domainName string) (domain cf.Domain) {
 	var apiResponse net.ApiResponse
 
-	domainName := c.String("d")
-
 	if domainName != "" {
 		domain, apiResponse = cmd.domainRepo.FindByNameInCurrentSpace(domainName)
 	} else {
@@ -158,9 +532,9 @@ func (cmd Push) domain(c *cli.Context) (domain cf.Domain) {
 	return
 }
 
-func (cmd Push) hostName(app cf.Application, c *cli.Context) (hostName string) {
+func (cmd Push) hostName(app cf.Application, host string, c *cli.Context) (hostName string) {
 	if !c.Bool("no-hostname") {
-		hostName = c.String("n")
+		hostName = host
 		if hostName == "" {
 			hostName = app.Name
 		}
@@ -220,8 +594,61 @@ func (cmd Push) bindAppToRoute(app cf.Application, domain cf.Domain, hostName st
 	cmd.ui.Say("")
 }
 
-func (cmd Push) path(c *cli.Context) (dir string) {
-	dir = c.String("p")
+func (cmd Push) setEnvironmentVars(app cf.Application, envVars map[string]string) (apiResponse net.ApiResponse) {
+	if len(envVars) == 0 {
+		return
+	}
+
+	cmd.ui.Say("Setting env variables for app %s...", terminal.EntityNameColor(app.Name))
+
+	apiResponse = cmd.appRepo.SetEnv(app, mergeEnvironmentVars(app.EnvironmentVars, envVars))
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return
+}
+
+func mergeEnvironmentVars(existingVars, newVars map[string]string) (mergedVars map[string]string) {
+	mergedVars = map[string]string{}
+	for name, value := range existingVars {
+		mergedVars[name] = value
+	}
+	for name, value := range newVars {
+		mergedVars[name] = value
+	}
+	return
+}
+
+func (cmd Push) bindServices(app cf.Application, serviceNames []string) (apiResponse net.ApiResponse) {
+	for _, serviceName := range serviceNames {
+		var instance cf.ServiceInstance
+		instance, apiResponse = cmd.serviceRepo.FindInstanceByName(serviceName)
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+
+		cmd.ui.Say("Binding service %s to app %s...",
+			terminal.EntityNameColor(instance.Name),
+			terminal.EntityNameColor(app.Name),
+		)
+
+		apiResponse = cmd.serviceBindingRepo.Create(instance, app)
+		if apiResponse.IsNotSuccessful() && apiResponse.ErrorCode != cf.APP_ALREADY_BOUND {
+			return
+		}
+		apiResponse = net.NewSuccessfulApiResponse()
+
+		cmd.ui.Ok()
+		cmd.ui.Say("")
+	}
+	return
+}
+
+func (cmd Push) appDir(appParams manifest.Application) (dir string) {
+	dir = appParams.Path
 	if dir == "" {
 		var err error
 		dir, err = os.Getwd()
@@ -233,16 +660,19 @@ func (cmd Push) path(c *cli.Context) (dir string) {
 	return
 }
 
-func (cmd Push) restart(app cf.Application, c *cli.Context) {
+func (cmd Push) restart(app cf.Application, buildpackUrl string, c *cli.Context) {
 	updatedApp, _ := cmd.stopper.ApplicationStop(app)
 
 	cmd.ui.Say("")
 
 	if !c.Bool("no-start") {
-		if c.String("b") != "" {
-			updatedApp.BuildpackUrl = c.String("b")
+		if buildpackUrl != "" {
+			updatedApp.BuildpackUrl = buildpackUrl
+		}
+		_, err := cmd.starter.ApplicationStart(updatedApp)
+		if err != nil {
+			cmd.ui.Failed(err.Error())
 		}
-		cmd.starter.ApplicationStart(updatedApp)
 	}
 }
 
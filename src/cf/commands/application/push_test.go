@@ -5,12 +5,17 @@ import (
 	"cf/api"
 	. "cf/commands/application"
 	"cf/configuration"
+	"cf/manifest"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"path/filepath"
+	"strings"
 	testapi "testhelpers/api"
 	testassert "testhelpers/assert"
 	testcmd "testhelpers/commands"
 	testconfig "testhelpers/configuration"
+	testmanifest "testhelpers/manifest"
 	testreq "testhelpers/requirements"
 	testterm "testhelpers/terminal"
 	"testing"
@@ -20,7 +25,11 @@ func TestPushingRequirements(t *testing.T) {
 	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
 	fakeUI := new(testterm.FakeUI)
 	config := &configuration.Configuration{}
-	cmd := NewPush(fakeUI, config, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+	manifestRepo := &testmanifest.FakeManifestRepository{}
+	serviceRepo := &testapi.FakeServiceRepo{}
+	serviceBindingRepo := &testapi.FakeServiceBindingRepo{}
+	serviceSummaryRepo := &testapi.FakeServiceSummaryRepo{}
+	cmd := NewPush(fakeUI, config, starter, stopper, manifestRepo, appRepo, domainRepo, routeRepo, stackRepo, serviceRepo, serviceBindingRepo, serviceSummaryRepo, appBitsRepo)
 	ctxt := testcmd.NewContext("push", []string{})
 
 	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
@@ -470,6 +479,477 @@ func TestPushingAppWithInvalidPath(t *testing.T) {
 	testassert.SliceContains(t, fakeUI.Outputs, []string{"Uploading", "FAILED"})
 }
 
+func TestPushingWithoutAppNameOrManifest(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	fakeUI := callPush(t, []string{}, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	assert.True(t, fakeUI.FailedWithUsage)
+	assert.Empty(t, appBitsRepo.UploadedApp.Guid)
+}
+
+func TestPushingLooksForManifestInAppDirectory(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+	appRepo.FindByNameApp = cf.Application{Name: "my-app", Guid: "my-app-guid"}
+
+	manifestRepo := &testmanifest.FakeManifestRepository{}
+	callPushWithManifest(t, []string{"my-app"}, manifestRepo, &testapi.FakeServiceRepo{}, &testapi.FakeServiceBindingRepo{},
+		starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.Equal(t, manifestRepo.ReadManifestPath, filepath.Join(cwd, "manifest.yml"))
+
+	manifestRepo = &testmanifest.FakeManifestRepository{}
+	callPushWithManifest(t, []string{"-p", "/some/app/dir", "my-app"}, manifestRepo, &testapi.FakeServiceRepo{}, &testapi.FakeServiceBindingRepo{},
+		starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	assert.Equal(t, manifestRepo.ReadManifestPath, filepath.Join("/some/app/dir", "manifest.yml"))
+}
+
+func TestPushingAppFromManifest(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	domainRepo.FindByNameDomain = cf.Domain{Name: "example.com", Guid: "example-domain-guid"}
+	routeRepo.FindByHostAndDomainErr = true
+	stackRepo.FindByNameStack = cf.Stack{Name: "lucid64", Guid: "lucid64-guid"}
+	appRepo.FindByNameNotFound = true
+
+	manifestRepo := &testmanifest.FakeManifestRepository{
+		ReadManifestManifest: &manifest.Manifest{
+			Applications: []manifest.Application{
+				{
+					Name:            "manifest-app",
+					Instances:       2,
+					Memory:          "512M",
+					BuildpackUrl:    "https://example.com/buildpack.git",
+					Command:         "bundle exec rackup",
+					Domain:          "example.com",
+					Host:            "manifest-host",
+					StackName:       "lucid64",
+					Path:            "/path/to/manifest-app",
+					EnvironmentVars: map[string]string{"RAILS_ENV": "production"},
+					Services:        []string{"manifest-db"},
+				},
+			},
+		},
+	}
+	serviceRepo := &testapi.FakeServiceRepo{
+		FindInstanceByNameServiceInstance: cf.ServiceInstance{Name: "manifest-db", Guid: "manifest-db-guid"},
+	}
+	serviceBindingRepo := &testapi.FakeServiceBindingRepo{}
+
+	fakeUI := callPushWithManifest(t, []string{}, manifestRepo, serviceRepo, serviceBindingRepo,
+		starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	testassert.SliceContains(t, fakeUI.Outputs, []string{
+		"Using manifest file",
+		"Creating app",
+		"Binding",
+		"Uploading",
+		"Setting env variables",
+		"Binding service",
+	})
+
+	assert.Equal(t, appRepo.CreatedApp.Name, "manifest-app")
+	assert.Equal(t, appRepo.CreatedApp.Instances, 2)
+	assert.Equal(t, appRepo.CreatedApp.Memory, uint64(512))
+	assert.Equal(t, appRepo.CreatedApp.BuildpackUrl, "https://example.com/buildpack.git")
+	assert.Equal(t, appRepo.CreatedApp.Command, "bundle exec rackup")
+	assert.Equal(t, appRepo.CreatedApp.Stack.Guid, "lucid64-guid")
+	assert.Equal(t, stackRepo.FindByNameName, "lucid64")
+
+	assert.Equal(t, domainRepo.FindByNameInCurrentSpaceName, "example.com")
+	assert.Equal(t, routeRepo.CreatedRoute.Host, "manifest-host")
+	assert.Equal(t, routeRepo.BoundApp.Name, "manifest-app")
+
+	assert.Equal(t, appBitsRepo.UploadedDir, "/path/to/manifest-app")
+
+	assert.Equal(t, appRepo.SetEnvApp.Guid, "manifest-app-guid")
+	assert.Equal(t, appRepo.SetEnvVars, map[string]string{"RAILS_ENV": "production"})
+
+	assert.Equal(t, serviceRepo.FindInstanceByNameName, "manifest-db")
+	assert.Equal(t, serviceBindingRepo.CreateServiceInstance.Guid, "manifest-db-guid")
+	assert.Equal(t, serviceBindingRepo.CreateApplication.Guid, "manifest-app-guid")
+
+	assert.Equal(t, starter.AppToStart.BuildpackUrl, "https://example.com/buildpack.git")
+}
+
+func TestPushingAppFromManifestWithFlagOverrides(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	domainRepo.FindByNameDomain = cf.Domain{Name: "override.com", Guid: "override-domain-guid"}
+	routeRepo.FindByHostAndDomainErr = true
+	appRepo.FindByNameNotFound = true
+
+	manifestRepo := &testmanifest.FakeManifestRepository{
+		ReadManifestManifest: &manifest.Manifest{
+			Applications: []manifest.Application{
+				{
+					Name:      "manifest-app",
+					Instances: 2,
+					Memory:    "512M",
+					Domain:    "example.com",
+					Host:      "manifest-host",
+					Path:      "/path/to/manifest-app",
+				},
+			},
+		},
+	}
+
+	callPushWithManifest(t, []string{
+		"-f", "/path/to/manifest.yml",
+		"-i", "5",
+		"-m", "1G",
+		"-d", "override.com",
+		"-n", "override-host",
+		"-p", "/path/to/override",
+		"override-app",
+	}, manifestRepo, &testapi.FakeServiceRepo{}, &testapi.FakeServiceBindingRepo{},
+		starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	assert.Equal(t, manifestRepo.ReadManifestPath, "/path/to/manifest.yml")
+
+	assert.Equal(t, appRepo.CreatedApp.Name, "override-app")
+	assert.Equal(t, appRepo.CreatedApp.Instances, 5)
+	assert.Equal(t, appRepo.CreatedApp.Memory, uint64(1024))
+	assert.Equal(t, domainRepo.FindByNameInCurrentSpaceName, "override.com")
+	assert.Equal(t, routeRepo.CreatedRoute.Host, "override-host")
+	assert.Equal(t, appBitsRepo.UploadedDir, "/path/to/override")
+}
+
+func TestPushingMultipleAppsFromManifest(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	domainRepo.DefaultAppDomain = cf.Domain{Name: "example.com", Guid: "example-domain-guid"}
+	routeRepo.FindByHostAndDomainErr = true
+	appRepo.FindByNameNotFound = true
+
+	manifestRepo := &testmanifest.FakeManifestRepository{
+		ReadManifestManifest: &manifest.Manifest{
+			Applications: []manifest.Application{
+				{Name: "app1", Path: "/path/to/app1"},
+				{Name: "app2", Path: "/path/to/app2"},
+			},
+		},
+	}
+
+	fakeUI := callPushWithManifest(t, []string{}, manifestRepo, &testapi.FakeServiceRepo{}, &testapi.FakeServiceBindingRepo{},
+		starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	createdApps := []string{}
+	for _, output := range fakeUI.Outputs {
+		if strings.Contains(output, "Creating app") {
+			createdApps = append(createdApps, output)
+		}
+	}
+	assert.Equal(t, len(createdApps), 2)
+	assert.Contains(t, createdApps[0], "app1")
+	assert.Contains(t, createdApps[1], "app2")
+
+	assert.Equal(t, appRepo.CreatedApp.Name, "app2")
+	assert.Equal(t, appBitsRepo.UploadedDir, "/path/to/app2")
+	assert.Equal(t, starter.AppToStart.Name, "")
+	assert.Equal(t, stopper.AppToStop.Name, "app2")
+}
+
+func TestPushingMultipleAppsFromManifestRejectsPerAppFlags(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	domainRepo.DefaultAppDomain = cf.Domain{Name: "example.com", Guid: "example-domain-guid"}
+	routeRepo.FindByHostAndDomainErr = true
+	appRepo.FindByNameNotFound = true
+
+	manifestRepo := &testmanifest.FakeManifestRepository{
+		ReadManifestManifest: &manifest.Manifest{
+			Applications: []manifest.Application{
+				{Name: "app1", Path: "/path/to/app1"},
+				{Name: "app2", Path: "/path/to/app2"},
+			},
+		},
+	}
+
+	fakeUI := callPushWithManifest(t, []string{"-n", "my-host", "-i", "3"}, manifestRepo, &testapi.FakeServiceRepo{}, &testapi.FakeServiceBindingRepo{},
+		starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	testassert.SliceContains(t, fakeUI.Outputs, []string{"FAILED", "-n, -i cannot be used"})
+	assert.Empty(t, appRepo.CreatedApp.Name)
+	assert.Empty(t, appBitsRepo.UploadedDir)
+
+	fakeUI = callPushWithManifest(t, []string{"-n", "my-host", "app2"}, manifestRepo, &testapi.FakeServiceRepo{}, &testapi.FakeServiceBindingRepo{},
+		starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	assert.Equal(t, appRepo.CreatedApp.Name, "app2")
+	assert.Equal(t, routeRepo.FindByHostAndDomainHost, "my-host")
+}
+
+func TestPushingOneAppFromManifestWithMultipleApps(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	domainRepo.DefaultAppDomain = cf.Domain{Name: "example.com", Guid: "example-domain-guid"}
+	routeRepo.FindByHostAndDomainErr = true
+	appRepo.FindByNameNotFound = true
+
+	manifestRepo := &testmanifest.FakeManifestRepository{
+		ReadManifestManifest: &manifest.Manifest{
+			Applications: []manifest.Application{
+				{Name: "app1", Path: "/path/to/app1"},
+				{Name: "app2", Path: "/path/to/app2"},
+			},
+		},
+	}
+
+	fakeUI := callPushWithManifest(t, []string{"app1"}, manifestRepo, &testapi.FakeServiceRepo{}, &testapi.FakeServiceBindingRepo{},
+		starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	for _, output := range fakeUI.Outputs {
+		assert.NotContains(t, output, "app2")
+	}
+	assert.Equal(t, appRepo.CreatedApp.Name, "app1")
+	assert.Equal(t, appBitsRepo.UploadedDir, "/path/to/app1")
+
+	fakeUI = callPushWithManifest(t, []string{"app3"}, manifestRepo, &testapi.FakeServiceRepo{}, &testapi.FakeServiceBindingRepo{},
+		starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	testassert.SliceContains(t, fakeUI.Outputs, []string{"FAILED", "app3 not found in manifest"})
+}
+
+func TestPushingWithManifestThatCannotBeRead(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	manifestRepo := &testmanifest.FakeManifestRepository{ReadManifestErr: errors.New("yaml: line 3: could not find expected ':'")}
+
+	fakeUI := callPushWithManifest(t, []string{"my-app"}, manifestRepo, &testapi.FakeServiceRepo{}, &testapi.FakeServiceBindingRepo{},
+		starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	testassert.SliceContains(t, fakeUI.Outputs, []string{"FAILED", "could not find expected"})
+	assert.Empty(t, appBitsRepo.UploadedApp.Guid)
+
+	manifestRepo = &testmanifest.FakeManifestRepository{}
+
+	fakeUI = callPushWithManifest(t, []string{"-f", "/missing/manifest.yml", "my-app"}, manifestRepo, &testapi.FakeServiceRepo{}, &testapi.FakeServiceBindingRepo{},
+		starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	testassert.SliceContains(t, fakeUI.Outputs, []string{"FAILED", "Error reading manifest file"})
+	assert.Empty(t, appBitsRepo.UploadedApp.Guid)
+}
+
+func TestPushingAppWithBlueGreenStrategy(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	existingRoute := cf.Route{
+		Guid:   "existing-route-guid",
+		Host:   "existing-app",
+		Domain: cf.Domain{Name: "example.com"},
+	}
+
+	existingApp := cf.Application{
+		Name:            "existing-app",
+		Guid:            "existing-app-guid",
+		Instances:       3,
+		Memory:          512,
+		Command:         "bundle exec rackup",
+		BuildpackUrl:    "http://example.com/buildpack",
+		Stack:           cf.Stack{Name: "lucid64", Guid: "lucid64-guid"},
+		EnvironmentVars: map[string]string{"FOO": "bar"},
+		Routes:          []cf.Route{existingRoute},
+	}
+
+	appRepo.FindByNameApps = map[string]cf.Application{"existing-app": existingApp}
+	routeRepo.FindByHostAndDomainRoute = existingRoute
+	stackRepo.FindByNameStack = existingApp.Stack
+
+	serviceRepo := &testapi.FakeServiceRepo{
+		FindInstanceByNameInstances: map[string]cf.ServiceInstance{
+			"existing-db":    {Name: "existing-db"},
+			"existing-cache": {Name: "existing-cache"},
+		},
+	}
+	serviceBindingRepo := &testapi.FakeServiceBindingRepo{}
+	serviceSummaryRepo := &testapi.FakeServiceSummaryRepo{
+		GetSummariesInCurrentSpaceInstances: []cf.ServiceInstance{
+			{Name: "existing-db", ApplicationNames: []string{"existing-app", "other-app"}},
+			{Name: "existing-cache", ApplicationNames: []string{"existing-app"}},
+			{Name: "other-db", ApplicationNames: []string{"other-app"}},
+		},
+	}
+
+	fakeUI := callPushWithServiceSummaries(t, []string{"--strategy", "blue-green", "existing-app"}, &testmanifest.FakeManifestRepository{}, serviceRepo, serviceBindingRepo, serviceSummaryRepo, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	assert.Equal(t, appRepo.CreatedApp.Name, "existing-app-new")
+	assert.Equal(t, appRepo.CreatedApp.Instances, 3)
+	assert.Equal(t, appRepo.CreatedApp.Memory, uint64(512))
+	assert.Equal(t, appRepo.CreatedApp.Command, "bundle exec rackup")
+	assert.Equal(t, appRepo.CreatedApp.BuildpackUrl, "http://example.com/buildpack")
+	assert.Equal(t, appRepo.CreatedApp.Stack.Guid, "lucid64-guid")
+	assert.Equal(t, stackRepo.FindByNameName, "lucid64")
+	assert.Equal(t, appBitsRepo.UploadedApp.Guid, "existing-app-new-guid")
+	assert.Equal(t, appRepo.SetEnvVars, map[string]string{"FOO": "bar"})
+	assert.Equal(t, serviceBindingRepo.CreatedBindings, []string{"existing-app-new:existing-db", "existing-app-new:existing-cache"})
+	assert.Equal(t, starter.AppToStart.Name, "existing-app-new")
+	assert.Equal(t, starter.AppToStart.BuildpackUrl, "http://example.com/buildpack")
+	assert.Empty(t, stopper.AppToStop.Name)
+
+	assert.Equal(t, routeRepo.BoundRoute, existingRoute)
+	assert.Equal(t, routeRepo.BoundApp.Name, "existing-app-new")
+	assert.Empty(t, routeRepo.UnboundApp.Name)
+
+	assert.Equal(t, appRepo.DeletedApp.Guid, "existing-app-guid")
+	assert.Equal(t, appRepo.RenameApp.Guid, "existing-app-new-guid")
+	assert.Equal(t, appRepo.RenameNewName, "existing-app")
+
+	testassert.SliceContains(t, fakeUI.Outputs, []string{
+		"Creating app",
+		"Uploading",
+		"Binding",
+		"Deleting app",
+		"Renaming app",
+	})
+}
+
+func TestPushingAppWithBlueGreenStrategyWhenStagingFails(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	existingApp := cf.Application{
+		Name: "existing-app",
+		Guid: "existing-app-guid",
+		Routes: []cf.Route{{
+			Guid:   "existing-route-guid",
+			Host:   "existing-app",
+			Domain: cf.Domain{Name: "example.com"},
+		}},
+	}
+
+	appRepo.FindByNameApps = map[string]cf.Application{"existing-app": existingApp}
+	starter.StartErr = true
+
+	fakeUI := callPush(t, []string{"--strategy", "blue-green", "existing-app"}, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	assert.Equal(t, starter.AppToStart.Name, "existing-app-new")
+	assert.Empty(t, stopper.AppToStop.Name)
+	assert.Empty(t, routeRepo.BoundApp.Name)
+	assert.Empty(t, routeRepo.UnboundApp.Name)
+	assert.Equal(t, appRepo.DeletedApp.Guid, "existing-app-new-guid")
+	assert.Empty(t, appRepo.RenameApp.Name)
+	testassert.SliceContains(t, fakeUI.Outputs, []string{"Deleting app", "FAILED", "Error starting application"})
+}
+
+func TestPushingAppWithBlueGreenStrategyWhenUploadFails(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	appRepo.FindByNameApps = map[string]cf.Application{
+		"existing-app": {Name: "existing-app", Guid: "existing-app-guid"},
+	}
+	appBitsRepo.UploadAppErr = true
+
+	fakeUI := callPush(t, []string{"--strategy", "blue-green", "existing-app"}, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	assert.Empty(t, starter.AppToStart.Name)
+	assert.Equal(t, appRepo.DeletedApp.Guid, "existing-app-new-guid")
+	assert.Empty(t, appRepo.RenameApp.Name)
+	testassert.SliceContains(t, fakeUI.Outputs, []string{"Deleting app", "FAILED", "Error uploading app"})
+}
+
+func TestPushingAppWithBlueGreenStrategyWhenConfiguringFails(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	appRepo.FindByNameApps = map[string]cf.Application{
+		"existing-app": {Name: "existing-app", Guid: "existing-app-guid", EnvironmentVars: map[string]string{"FOO": "bar"}},
+	}
+	appRepo.SetEnvErr = true
+
+	fakeUI := callPush(t, []string{"--strategy", "blue-green", "existing-app"}, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	assert.Empty(t, starter.AppToStart.Name)
+	assert.Equal(t, appRepo.DeletedApp.Guid, "existing-app-new-guid")
+	assert.Empty(t, appRepo.RenameApp.Name)
+	testassert.SliceContains(t, fakeUI.Outputs, []string{"Deleting app", "FAILED", "Failed setting env"})
+
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo = getPushDependencies()
+
+	appRepo.FindByNameApps = map[string]cf.Application{
+		"existing-app": {Name: "existing-app", Guid: "existing-app-guid"},
+	}
+	serviceRepo := &testapi.FakeServiceRepo{FindInstanceByNameNotFound: true}
+	serviceSummaryRepo := &testapi.FakeServiceSummaryRepo{
+		GetSummariesInCurrentSpaceInstances: []cf.ServiceInstance{
+			{Name: "existing-db", ApplicationNames: []string{"existing-app"}},
+		},
+	}
+
+	fakeUI = callPushWithServiceSummaries(t, []string{"--strategy", "blue-green", "existing-app"}, &testmanifest.FakeManifestRepository{}, serviceRepo, &testapi.FakeServiceBindingRepo{}, serviceSummaryRepo, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	assert.Empty(t, starter.AppToStart.Name)
+	assert.Equal(t, appRepo.DeletedApp.Guid, "existing-app-new-guid")
+	testassert.SliceContains(t, fakeUI.Outputs, []string{"Deleting app", "FAILED", "existing-db not found"})
+}
+
+func TestPushingAppWithBlueGreenStrategyWhenDeletingTheOldAppFails(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	appRepo.FindByNameApps = map[string]cf.Application{
+		"existing-app": {Name: "existing-app", Guid: "existing-app-guid"},
+	}
+	appRepo.DeleteErr = true
+
+	fakeUI := callPush(t, []string{"--strategy", "blue-green", "existing-app"}, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	assert.Equal(t, appRepo.DeletedApp.Guid, "existing-app-new-guid")
+	assert.Empty(t, appRepo.RenameApp.Name)
+	testassert.SliceContains(t, fakeUI.Outputs, []string{"Deleting app", "Deleting app", "FAILED", "Error deleting app"})
+}
+
+func TestPushingAppWithBlueGreenStrategyWhenRenamingFails(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	appRepo.FindByNameApps = map[string]cf.Application{
+		"existing-app": {Name: "existing-app", Guid: "existing-app-guid"},
+	}
+	appRepo.RenameErr = true
+
+	fakeUI := callPush(t, []string{"--strategy", "blue-green", "existing-app"}, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	assert.Equal(t, appRepo.DeletedApp.Guid, "existing-app-guid")
+	testassert.SliceContains(t, fakeUI.Outputs, []string{"FAILED", "existing-app-new now serves the routes of existing-app"})
+}
+
+func TestPushingAppWithBlueGreenStrategyRejectsRouteFlags(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	appRepo.FindByNameApps = map[string]cf.Application{
+		"existing-app": {Name: "existing-app", Guid: "existing-app-guid"},
+	}
+
+	fakeUI := callPush(t, []string{"--strategy", "blue-green", "-n", "other-host", "existing-app"}, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	testassert.SliceContains(t, fakeUI.Outputs, []string{"FAILED", "cannot be used with the blue-green strategy"})
+	assert.Empty(t, appRepo.CreatedApp.Name)
+}
+
+func TestPushingAppWithBlueGreenStrategyWhenTemporaryAppExists(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	appRepo.FindByNameApps = map[string]cf.Application{
+		"existing-app":     {Name: "existing-app", Guid: "existing-app-guid"},
+		"existing-app-new": {Name: "existing-app-new", Guid: "existing-app-new-guid"},
+	}
+
+	fakeUI := callPush(t, []string{"--strategy", "blue-green", "existing-app"}, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	testassert.SliceContains(t, fakeUI.Outputs, []string{"FAILED", "existing-app-new already exists"})
+	assert.Empty(t, appRepo.CreatedApp.Name)
+	assert.Empty(t, appBitsRepo.UploadedApp.Guid)
+}
+
+func TestPushingAppWithUnknownStrategy(t *testing.T) {
+	starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo := getPushDependencies()
+
+	fakeUI := callPush(t, []string{"--strategy", "red-black", "my-app"}, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+
+	testassert.SliceContains(t, fakeUI.Outputs, []string{"FAILED", "Unknown push strategy red-black"})
+	assert.Empty(t, appBitsRepo.UploadedApp.Guid)
+}
+
 func getPushDependencies() (starter *testcmd.FakeAppStarter,
 	stopper *testcmd.FakeAppStopper,
 	appRepo *testapi.FakeApplicationRepository,
@@ -499,6 +979,45 @@ func callPush(t *testing.T,
 	stackRepo api.StackRepository,
 	appBitsRepo *testapi.FakeApplicationBitsRepository) (fakeUI *testterm.FakeUI) {
 
+	manifestRepo := &testmanifest.FakeManifestRepository{}
+	serviceRepo := &testapi.FakeServiceRepo{}
+	serviceBindingRepo := &testapi.FakeServiceBindingRepo{}
+
+	return callPushWithManifest(t, args, manifestRepo, serviceRepo, serviceBindingRepo, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+}
+
+func callPushWithManifest(t *testing.T,
+	args []string,
+	manifestRepo manifest.ManifestRepository,
+	serviceRepo api.ServiceRepository,
+	serviceBindingRepo api.ServiceBindingRepository,
+	starter ApplicationStarter,
+	stopper ApplicationStopper,
+	appRepo api.ApplicationRepository,
+	domainRepo api.DomainRepository,
+	routeRepo api.RouteRepository,
+	stackRepo api.StackRepository,
+	appBitsRepo *testapi.FakeApplicationBitsRepository) (fakeUI *testterm.FakeUI) {
+
+	serviceSummaryRepo := &testapi.FakeServiceSummaryRepo{}
+
+	return callPushWithServiceSummaries(t, args, manifestRepo, serviceRepo, serviceBindingRepo, serviceSummaryRepo, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+}
+
+func callPushWithServiceSummaries(t *testing.T,
+	args []string,
+	manifestRepo manifest.ManifestRepository,
+	serviceRepo api.ServiceRepository,
+	serviceBindingRepo api.ServiceBindingRepository,
+	serviceSummaryRepo api.ServiceSummaryRepository,
+	starter ApplicationStarter,
+	stopper ApplicationStopper,
+	appRepo api.ApplicationRepository,
+	domainRepo api.DomainRepository,
+	routeRepo api.RouteRepository,
+	stackRepo api.StackRepository,
+	appBitsRepo *testapi.FakeApplicationBitsRepository) (fakeUI *testterm.FakeUI) {
+
 	fakeUI = new(testterm.FakeUI)
 	ctxt := testcmd.NewContext("push", args)
 
@@ -513,7 +1032,7 @@ func callPush(t *testing.T,
 		AccessToken:  token,
 	}
 
-	cmd := NewPush(fakeUI, config, starter, stopper, appRepo, domainRepo, routeRepo, stackRepo, appBitsRepo)
+	cmd := NewPush(fakeUI, config, starter, stopper, manifestRepo, appRepo, domainRepo, routeRepo, stackRepo, serviceRepo, serviceBindingRepo, serviceSummaryRepo, appBitsRepo)
 	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"github.com/codegangsta/cli"
 	"strings"
+	"time"
 )
 
 type ShowApp struct {
@@ -54,15 +55,68 @@ func (cmd *ShowApp) Run(c *cli.Context) {
 		terminal.EntityNameColor(cmd.config.Username()),
 	)
 
+	summary, appIsStopped, ok := cmd.getSummary(app)
+	if !ok {
+		return
+	}
+
+	cmd.ui.Ok()
+
+	if c.Bool("watch") {
+		cmd.watch(app, summary, appIsStopped, c.Int("interval"))
+		return
+	}
+
+	cmd.displaySummary(summary, appIsStopped, nil)
+}
+
+func (cmd *ShowApp) getSummary(app cf.Application) (summary cf.AppSummary, appIsStopped bool, ok bool) {
 	summary, apiResponse := cmd.appSummaryRepo.GetSummary(app)
-	appIsStopped := apiResponse.ErrorCode == cf.APP_STOPPED || apiResponse.ErrorCode == cf.APP_NOT_STAGED
+	appIsStopped = apiResponse.ErrorCode == cf.APP_STOPPED || apiResponse.ErrorCode == cf.APP_NOT_STAGED
 
 	if apiResponse.IsNotSuccessful() && !appIsStopped {
 		cmd.ui.Failed(apiResponse.Message)
 		return
 	}
 
-	cmd.ui.Ok()
+	ok = true
+	return
+}
+
+// watch redraws the summary every interval seconds until interrupted. Changes
+// since the previous snapshot are shown next to each instance, and the watch
+// fails as soon as an instance crashes.
+func (cmd *ShowApp) watch(app cf.Application, summary cf.AppSummary, appIsStopped bool, interval int) {
+	if interval < 1 {
+		interval = 1
+	}
+
+	var previous []cf.ApplicationInstance
+	for snapshot := 0; ; snapshot++ {
+		cmd.ui.ClearScreen()
+		cmd.ui.Say("Every %ds, last updated %s. Press Ctrl-C to stop.", interval, time.Now().Format("03:04:05 PM"))
+		cmd.displaySummary(summary, appIsStopped, previous)
+
+		if snapshot > 0 {
+			crashed := crashedInstances(previous, summary.Instances)
+			if len(crashed) > 0 {
+				cmd.ui.Failed("App %s crashed: instance %s", app.Name, strings.Join(crashed, ", "))
+				return
+			}
+		}
+
+		previous = summary.Instances
+		cmd.ui.Wait(time.Duration(interval) * time.Second)
+
+		var ok bool
+		summary, appIsStopped, ok = cmd.getSummary(app)
+		if !ok {
+			return
+		}
+	}
+}
+
+func (cmd *ShowApp) displaySummary(summary cf.AppSummary, appIsStopped bool, previous []cf.ApplicationInstance) {
 	cmd.ui.Say("\n%s %s", terminal.HeaderColor("state:"), coloredAppState(summary.App))
 	cmd.ui.Say("%s %s", terminal.HeaderColor("instances:"), coloredAppInstaces(summary.App))
 	cmd.ui.Say("%s %s x %d instances", terminal.HeaderColor("usage:"), formatters.ByteSize(summary.App.Memory*formatters.MEGABYTE), summary.App.Instances)
@@ -82,15 +136,57 @@ func (cmd *ShowApp) Run(c *cli.Context) {
 	}
 
 	for index, instance := range summary.Instances {
+		var (
+			status                        = coloredInstanceState(instance)
+			cpuDelta, memDelta, diskDelta string
+		)
+
+		if index < len(previous) {
+			before := previous[index]
+			if before.State != instance.State {
+				status = fmt.Sprintf("%s -> %s", coloredInstanceState(before), status)
+			}
+			if instance.CpuUsage != before.CpuUsage {
+				cpuDelta = fmt.Sprintf(" (%+.1f)", instance.CpuUsage-before.CpuUsage)
+			}
+			memDelta = formatByteDelta(before.MemUsage, instance.MemUsage)
+			diskDelta = formatByteDelta(before.DiskUsage, instance.DiskUsage)
+		}
+
 		table = append(table, []string{
 			fmt.Sprintf("#%d", index),
-			coloredInstanceState(instance),
+			status,
 			instance.Since.Format("2006-01-02 03:04:05 PM"),
-			fmt.Sprintf("%.1f%%", instance.CpuUsage),
-			fmt.Sprintf("%s of %s", formatters.ByteSize(instance.MemUsage), formatters.ByteSize(instance.MemQuota)),
-			fmt.Sprintf("%s of %s", formatters.ByteSize(instance.DiskUsage), formatters.ByteSize(instance.DiskQuota)),
+			fmt.Sprintf("%.1f%%%s", instance.CpuUsage, cpuDelta),
+			fmt.Sprintf("%s of %s%s", formatters.ByteSize(instance.MemUsage), formatters.ByteSize(instance.MemQuota), memDelta),
+			fmt.Sprintf("%s of %s%s", formatters.ByteSize(instance.DiskUsage), formatters.ByteSize(instance.DiskQuota), diskDelta),
 		})
 	}
 
 	cmd.ui.DisplayTable(table)
 }
+
+// crashedInstances returns the indexes of instances that have crashed since
+// the previous snapshot.
+func crashedInstances(previous, current []cf.ApplicationInstance) (crashed []string) {
+	for index, instance := range current {
+		if instance.State != cf.InstanceCrashed {
+			continue
+		}
+		if index < len(previous) && previous[index].State == cf.InstanceCrashed {
+			continue
+		}
+		crashed = append(crashed, fmt.Sprintf("#%d", index))
+	}
+	return
+}
+
+func formatByteDelta(before, after uint64) string {
+	switch {
+	case after > before:
+		return fmt.Sprintf(" (+%s)", formatters.ByteSize(after-before))
+	case after < before:
+		return fmt.Sprintf(" (-%s)", formatters.ByteSize(before-after))
+	}
+	return ""
+}
@@ -165,6 +165,70 @@ func testDisplayingAppSummaryWithErrorCode(t *testing.T, errorCode string) {
 	assert.Contains(t, ui.Outputs[5], "my-app.example.com, foo.example.com")
 }
 
+func TestWatchingAppShowsChangesAndFailsWhenAnInstanceCrashes(t *testing.T) {
+	app := cf.Application{State: "started", Instances: 2, RunningInstances: 2, Memory: 256}
+	before := cf.AppSummary{App: app, Instances: []cf.ApplicationInstance{
+		{State: cf.InstanceRunning, CpuUsage: 1.0, MemUsage: 10 * formatters.MEGABYTE, MemQuota: 64 * formatters.MEGABYTE},
+		{State: cf.InstanceRunning, CpuUsage: 1.0, MemUsage: 10 * formatters.MEGABYTE, MemQuota: 64 * formatters.MEGABYTE},
+	}}
+	after := cf.AppSummary{App: app, Instances: []cf.ApplicationInstance{
+		{State: cf.InstanceFlapping, CpuUsage: 2.5, MemUsage: 12 * formatters.MEGABYTE, MemQuota: 64 * formatters.MEGABYTE},
+		{State: cf.InstanceCrashed, CpuUsage: 1.0, MemUsage: 10 * formatters.MEGABYTE, MemQuota: 64 * formatters.MEGABYTE},
+	}}
+
+	appSummaryRepo := &testapi.FakeAppSummaryRepo{
+		GetSummaryResponses:  []cf.AppSummary{before, after},
+		GetSummaryErrorCodes: []string{"", ""},
+	}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: cf.Application{Name: "my-app"}}
+	ui := callApp(t, []string{"--watch", "--interval", "1", "my-app"}, reqFactory, appSummaryRepo)
+
+	assert.Equal(t, appSummaryRepo.GetSummaryCalls, 2)
+	assert.Equal(t, ui.ScreenClears, 2)
+	assert.Equal(t, ui.WaitDurations, []time.Duration{1 * time.Second})
+
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[2], "Every 1s")
+	assert.Contains(t, ui.Outputs[8], "#0")
+	assert.Contains(t, ui.Outputs[8], "1.0%")
+	assert.NotContains(t, ui.Outputs[8], "->")
+
+	assert.Contains(t, ui.Outputs[10], "Every 1s")
+	assert.Contains(t, ui.Outputs[16], "#0")
+	assert.Contains(t, ui.Outputs[16], "running")
+	assert.Contains(t, ui.Outputs[16], "-> ")
+	assert.Contains(t, ui.Outputs[16], "crashing")
+	assert.Contains(t, ui.Outputs[16], "2.5% (+1.5)")
+	assert.Contains(t, ui.Outputs[16], "12M of 64M (+2M)")
+
+	assert.Contains(t, ui.Outputs[17], "#1")
+	assert.Contains(t, ui.Outputs[17], "crashed")
+	assert.Contains(t, ui.Outputs[17], "10M of 64M  ")
+
+	assert.Contains(t, ui.Outputs[18], "FAILED")
+	assert.Contains(t, ui.Outputs[19], "my-app crashed: instance #1")
+}
+
+func TestWatchingAppStopsWhenTheSummaryCannotBeFetched(t *testing.T) {
+	app := cf.Application{State: "started", Instances: 1, RunningInstances: 1, Memory: 256}
+	summary := cf.AppSummary{App: app, Instances: []cf.ApplicationInstance{
+		{State: cf.InstanceCrashed},
+	}}
+
+	appSummaryRepo := &testapi.FakeAppSummaryRepo{
+		GetSummaryResponses:  []cf.AppSummary{summary, cf.AppSummary{}},
+		GetSummaryErrorCodes: []string{"", "10001"},
+	}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: cf.Application{Name: "my-app"}}
+	ui := callApp(t, []string{"--watch", "--interval", "1", "my-app"}, reqFactory, appSummaryRepo)
+
+	assert.Equal(t, appSummaryRepo.GetSummaryCalls, 2)
+	assert.Equal(t, ui.ScreenClears, 1)
+	assert.Equal(t, ui.WaitDurations, []time.Duration{1 * time.Second})
+	assert.Contains(t, ui.Outputs[len(ui.Outputs)-2], "FAILED")
+	assert.NotContains(t, ui.Outputs[len(ui.Outputs)-1], "crashed")
+}
+
 func callApp(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, appSummaryRepo *testapi.FakeAppSummaryRepo) (ui *testterm.FakeUI) {
 	ui = &testterm.FakeUI{}
 	ctxt := testcmd.NewContext("app", args)
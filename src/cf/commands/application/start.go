@@ -51,7 +51,10 @@ func (cmd *Start) GetRequirements(reqFactory requirements.Factory, c *cli.Contex
 }
 
 func (cmd *Start) Run(c *cli.Context) {
-	cmd.ApplicationStart(cmd.appReq.GetApplication())
+	_, err := cmd.ApplicationStart(cmd.appReq.GetApplication())
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+	}
 }
 
 func (cmd *Start) ApplicationStart(app cf.Application) (updatedApp cf.Application, err error) {
@@ -69,7 +72,7 @@ func (cmd *Start) ApplicationStart(app cf.Application) (updatedApp cf.Applicatio
 
 	updatedApp, apiResponse := cmd.appRepo.Start(app)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = errors.New(apiResponse.Message)
 		return
 	}
 
@@ -88,8 +91,8 @@ func (cmd *Start) ApplicationStart(app cf.Application) (updatedApp cf.Applicatio
 	instances, apiResponse := cmd.appRepo.GetInstances(updatedApp)
 	for apiResponse.IsNotSuccessful() {
 		if apiResponse.ErrorCode != cf.APP_NOT_STAGED {
+			err = errors.New(apiResponse.Message)
 			cmd.ui.Say("")
-			cmd.ui.Failed(apiResponse.Message)
 			return
 		}
 
@@ -103,9 +106,16 @@ func (cmd *Start) ApplicationStart(app cf.Application) (updatedApp cf.Applicatio
 
 	cmd.startTime = time.Now()
 
-	for cmd.displayInstancesStatus(app, instances) {
-		cmd.ui.Wait(1 * time.Second)
-		instances, _ = cmd.appRepo.GetInstances(updatedApp)
+	notFinished := true
+	for notFinished {
+		notFinished, err = cmd.displayInstancesStatus(app, instances)
+		if err != nil {
+			return
+		}
+		if notFinished {
+			cmd.ui.Wait(1 * time.Second)
+			instances, _ = cmd.appRepo.GetInstances(updatedApp)
+		}
 	}
 	return
 }
@@ -116,7 +126,7 @@ func (cmd Start) displayLogMessages(logChan chan *logmessage.Message) {
 	}
 }
 
-func (cmd Start) displayInstancesStatus(app cf.Application, instances []cf.ApplicationInstance) (notFinished bool) {
+func (cmd Start) displayInstancesStatus(app cf.Application, instances []cf.ApplicationInstance) (notFinished bool, err error) {
 	totalCount := len(instances)
 	runningCount, startingCount, flappingCount, downCount := 0, 0, 0, 0
 
@@ -134,8 +144,8 @@ func (cmd Start) displayInstancesStatus(app cf.Application, instances []cf.Appli
 	}
 
 	if flappingCount > 0 {
-		cmd.ui.Failed("Start unsuccessful")
-		return false
+		err = errors.New("Start unsuccessful")
+		return
 	}
 
 	anyInstanceRunning := runningCount > 0
@@ -146,18 +156,19 @@ func (cmd Start) displayInstancesStatus(app cf.Application, instances []cf.Appli
 		} else {
 			cmd.ui.Say("Started: app %s available at %s", terminal.EntityNameColor(app.Name), terminal.EntityNameColor(app.Routes[0].URL()))
 		}
-		return false
+		return
 	} else {
 		details := instancesDetails(runningCount, startingCount, downCount)
 		cmd.ui.Say("%d of %d instances running (%s)", runningCount, totalCount, details)
 	}
 
 	if time.Since(cmd.startTime) > cmd.config.ApplicationStartTimeout*time.Second {
-		cmd.ui.Failed("Start app timeout")
-		return false
+		err = errors.New("Start app timeout")
+		return
 	}
 
-	return totalCount > runningCount
+	notFinished = totalCount > runningCount
+	return
 }
 
 func instancesDetails(runningCount int, startingCount int, downCount int) string {
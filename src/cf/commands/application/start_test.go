@@ -5,6 +5,7 @@ import (
 	"cf/api"
 	. "cf/commands/application"
 	"cf/configuration"
+	"cf/terminal"
 	"code.google.com/p/gogoprotobuf/proto"
 	"github.com/cloudfoundry/loggregatorlib/logmessage"
 	"github.com/stretchr/testify/assert"
@@ -27,6 +28,22 @@ var defaultAppForStart = cf.Application{
 }
 
 func startAppWithInstancesAndErrors(t *testing.T, app cf.Application, instances [][]cf.ApplicationInstance, errorCodes []string) (ui *testterm.FakeUI, appRepo *testapi.FakeApplicationRepository, reqFactory *testreq.FakeReqFactory) {
+	ui = new(testterm.FakeUI)
+	appRepo, reqFactory = startAppWithUI(t, ui, app, instances, errorCodes)
+	return
+}
+
+// sleepingUI really waits, for the tests of the start timeout, which is
+// measured on the clock
+type sleepingUI struct {
+	*testterm.FakeUI
+}
+
+func (ui sleepingUI) Wait(duration time.Duration) {
+	time.Sleep(duration)
+}
+
+func startAppWithUI(t *testing.T, ui terminal.UI, app cf.Application, instances [][]cf.ApplicationInstance, errorCodes []string) (appRepo *testapi.FakeApplicationRepository, reqFactory *testreq.FakeReqFactory) {
 	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
 		Username: "my-user",
 	})
@@ -73,7 +90,7 @@ func startAppWithInstancesAndErrors(t *testing.T, app cf.Application, instances
 
 	args := []string{"my-app"}
 	reqFactory = &testreq.FakeReqFactory{Application: app}
-	ui = callStart(args, config, reqFactory, appRepo, logRepo)
+	runStart(ui, args, config, reqFactory, appRepo, logRepo)
 	return
 }
 
@@ -214,7 +231,8 @@ func TestStartApplicationWhenOneInstanceFlaps(t *testing.T) {
 
 	errorCodes := []string{"", ""}
 
-	ui, _, _ := startAppWithInstancesAndErrors(t, defaultAppForStart, instances, errorCodes)
+	ui := new(testterm.FakeUI)
+	startAppWithUI(t, sleepingUI{ui}, defaultAppForStart, instances, errorCodes)
 
 	assert.Contains(t, ui.Outputs[0], "my-app")
 	assert.Contains(t, ui.Outputs[1], "OK")
@@ -243,7 +261,8 @@ func TestStartApplicationWhenStartTimesOut(t *testing.T) {
 
 	errorCodes := []string{"", "", ""}
 
-	ui, _, _ := startAppWithInstancesAndErrors(t, defaultAppForStart, instances, errorCodes)
+	ui := new(testterm.FakeUI)
+	startAppWithUI(t, sleepingUI{ui}, defaultAppForStart, instances, errorCodes)
 
 	assert.Contains(t, ui.Outputs[0], "my-app")
 	assert.Contains(t, ui.Outputs[1], "OK")
@@ -291,9 +310,13 @@ func TestStartApplicationIsAlreadyStarted(t *testing.T) {
 
 func callStart(args []string, config *configuration.Configuration, reqFactory *testreq.FakeReqFactory, appRepo api.ApplicationRepository, logRepo api.LogsRepository) (ui *testterm.FakeUI) {
 	ui = new(testterm.FakeUI)
+	runStart(ui, args, config, reqFactory, appRepo, logRepo)
+	return
+}
+
+func runStart(ui terminal.UI, args []string, config *configuration.Configuration, reqFactory *testreq.FakeReqFactory, appRepo api.ApplicationRepository, logRepo api.LogsRepository) {
 	ctxt := testcmd.NewContext("start", args)
 
 	cmd := NewStart(ui, config, appRepo, logRepo)
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
-	return
 }
@@ -38,25 +38,20 @@ func (cmd Authenticate) GetRequirements(reqFactory requirements.Factory, c *cli.
 func (cmd Authenticate) Run(c *cli.Context) {
 	cmd.ui.Say("API endpoint: %s", terminal.EntityNameColor(cmd.config.Target))
 
-	username := c.Args()[0]
-	password := c.Args()[1]
-
 	cmd.ui.Say("Authenticating...")
 
-	apiResponse := cmd.doLogin(username, password)
+	var apiResponse net.ApiResponse
+	if c.Bool("client-credentials") {
+		apiResponse = cmd.authenticator.AuthenticateWithClientCredentials(c.Args()[0], c.Args()[1])
+	} else {
+		apiResponse = cmd.authenticator.Authenticate(c.Args()[0], c.Args()[1])
+	}
+
 	if apiResponse.IsNotSuccessful() {
 		cmd.ui.Failed(apiResponse.Message)
 		return
 	}
 
-	return
-}
-
-func (cmd Authenticate) doLogin(username, password string) (apiResponse net.ApiResponse) {
-	apiResponse = cmd.authenticator.Authenticate(username, password)
-	if apiResponse.IsSuccessful() {
-		cmd.ui.Ok()
-		cmd.ui.Say("Use '%s' to view or set your target org and space", terminal.CommandColor(cf.Name()+" target"))
-	}
-	return
+	cmd.ui.Ok()
+	cmd.ui.Say("Use '%s' to view or set your target org and space", terminal.CommandColor(cf.Name()+" target"))
 }
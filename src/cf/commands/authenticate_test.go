@@ -67,6 +67,23 @@ func TestSuccessfullyAuthenticatingWithUsernameAndPasswordAsArguments(t *testing
 	testSuccessfulAuthenticate(t, []string{"user@example.com", "password"})
 }
 
+func TestAuthenticatingWithClientCredentials(t *testing.T) {
+	configRepo := testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+
+	auth := &testapi.FakeAuthenticationRepository{
+		AccessToken: "my_client_token",
+		ConfigRepo:  configRepo,
+	}
+	ui := callAuthenticate([]string{"--client-credentials", "ci-client", "ci-secret"}, configRepo, auth)
+
+	assert.Contains(t, ui.Outputs[2], "OK")
+	assert.Equal(t, auth.ClientId, "ci-client")
+	assert.Equal(t, auth.ClientSecret, "ci-secret")
+	assert.Empty(t, auth.Email)
+	assert.Equal(t, testconfig.SavedConfiguration.AccessToken, "my_client_token")
+}
+
 func TestUnsuccessfullyAuthenticatingWithoutInteractivity(t *testing.T) {
 	configRepo := testconfig.FakeConfigRepository{}
 	configRepo.Delete()
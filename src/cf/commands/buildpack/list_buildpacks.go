@@ -8,6 +8,12 @@ import (
 	"strconv"
 )
 
+type buildpackRecord struct {
+	Name     string `json:"name" yaml:"name"`
+	Guid     string `json:"guid" yaml:"guid"`
+	Position *int   `json:"position" yaml:"position"`
+}
+
 type ListBuildpacks struct {
 	ui            terminal.UI
 	buildpackRepo api.BuildpackRepository
@@ -38,8 +44,11 @@ func (cmd ListBuildpacks) Run(c *cli.Context) {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	records := []buildpackRecord{}
+
 	if len(buildpacks) == 0 {
 		cmd.ui.Say("No buildpacks found")
+		cmd.ui.DisplayRecords(nil, records)
 		return
 	}
 
@@ -56,7 +65,13 @@ func (cmd ListBuildpacks) Run(c *cli.Context) {
 			buildpack.Name,
 			position,
 		})
+
+		records = append(records, buildpackRecord{
+			Name:     buildpack.Name,
+			Guid:     buildpack.Guid,
+			Position: buildpack.Position,
+		})
 	}
 
-	cmd.ui.DisplayTable(table)
+	cmd.ui.DisplayRecords(table, records)
 }
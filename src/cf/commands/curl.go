@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+	"strings"
+)
+
+type Curl struct {
+	ui       terminal.UI
+	config   *configuration.Configuration
+	curlRepo api.CurlRepository
+}
+
+func NewCurl(ui terminal.UI, config *configuration.Configuration, curlRepo api.CurlRepository) (cmd *Curl) {
+	cmd = new(Curl)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.curlRepo = curlRepo
+	return
+}
+
+func (cmd *Curl) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect number of arguments")
+		cmd.ui.FailWithUsage(c, "curl")
+		return
+	}
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+	}
+	return
+}
+
+func (cmd *Curl) Run(c *cli.Context) {
+	path := c.Args()[0]
+	body := c.String("d")
+
+	method := strings.ToUpper(c.String("X"))
+	if method == "" {
+		method = "GET"
+		if body != "" {
+			method = "POST"
+		}
+	}
+
+	resHeaders, resBody, apiResponse := cmd.curlRepo.Request(method, path, strings.Join(c.StringSlice("H"), "\n"), body)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	if c.Bool("i") {
+		cmd.ui.Say("%s\n", resHeaders)
+	}
+
+	cmd.ui.Say("%s", strings.TrimRight(resBody, "\n"))
+}
@@ -0,0 +1,129 @@
+package commands_test
+
+import (
+	. "cf/commands"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testcmd "testhelpers/commands"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestCurlFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callCurl([]string{}, reqFactory, &testapi.FakeCurlRepository{})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callCurl([]string{"/v2/events", "/v2/apps"}, reqFactory, &testapi.FakeCurlRepository{})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callCurl([]string{"/v2/events"}, reqFactory, &testapi.FakeCurlRepository{})
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestCurlRequirements(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: false}
+	callCurl([]string{"/v2/events"}, reqFactory, &testapi.FakeCurlRepository{})
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true}
+	callCurl([]string{"/v2/events"}, reqFactory, &testapi.FakeCurlRepository{})
+	assert.True(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestCurlGetRequest(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{
+		ResponseHeaders: "HTTP/1.1 200 OK\r\nContent-Type: application/json",
+		ResponseBody:    `{"resources":[]}`,
+	}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callCurl([]string{"/v2/events"}, reqFactory, curlRepo)
+
+	assert.Equal(t, curlRepo.Method, "GET")
+	assert.Equal(t, curlRepo.Path, "/v2/events")
+	assert.Equal(t, curlRepo.Body, "")
+	assert.Equal(t, len(ui.Outputs), 1)
+	assert.Equal(t, ui.Outputs[0], `{"resources":[]}`)
+}
+
+func TestCurlWithDataDefaultsToPost(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	callCurl([]string{"-d", `{"name":"my-org"}`, "/v2/organizations"}, reqFactory, curlRepo)
+
+	assert.Equal(t, curlRepo.Method, "POST")
+	assert.Equal(t, curlRepo.Body, `{"name":"my-org"}`)
+}
+
+func TestCurlWithCustomMethodAndHeaders(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	callCurl([]string{"-X", "put", "-H", "Accept-Language: en", "-d", `{"instances":2}`, "/v2/apps/my-app-guid"}, reqFactory, curlRepo)
+
+	assert.Equal(t, curlRepo.Method, "PUT")
+	assert.Equal(t, curlRepo.Headers, "Accept-Language: en")
+	assert.Equal(t, curlRepo.Body, `{"instances":2}`)
+}
+
+func TestCurlWithMultipleHeaders(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	callCurl([]string{"-H", "Accept-Language: en", "-H", "X-Foo: bar", "/v2/apps"}, reqFactory, curlRepo)
+
+	assert.Equal(t, curlRepo.Headers, "Accept-Language: en\nX-Foo: bar")
+}
+
+func TestCurlPrintsErrorResponsesRaw(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{
+		ResponseHeaders: "HTTP/1.1 404 Not Found\r\nContent-Type: application/json",
+		ResponseBody:    `{"code": 10000, "description": "Unknown request"}`,
+	}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callCurl([]string{"-i", "/v2/does-not-exist"}, reqFactory, curlRepo)
+
+	assert.Equal(t, len(ui.Outputs), 2)
+	assert.Contains(t, ui.Outputs[0], "HTTP/1.1 404 Not Found")
+	assert.Equal(t, ui.Outputs[1], `{"code": 10000, "description": "Unknown request"}`)
+}
+
+func TestCurlIncludesHeaders(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{
+		ResponseHeaders: "HTTP/1.1 200 OK\r\nContent-Type: application/json",
+		ResponseBody:    `{"resources":[]}`,
+	}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callCurl([]string{"-i", "/v2/events"}, reqFactory, curlRepo)
+
+	assert.Equal(t, len(ui.Outputs), 2)
+	assert.Contains(t, ui.Outputs[0], "HTTP/1.1 200 OK")
+	assert.Contains(t, ui.Outputs[0], "Content-Type: application/json")
+	assert.Equal(t, ui.Outputs[1], `{"resources":[]}`)
+}
+
+func TestCurlWhenRequestFails(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{Error: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callCurl([]string{"/v2/does-not-exist"}, reqFactory, curlRepo)
+
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "status code: 404")
+}
+
+func callCurl(args []string, reqFactory *testreq.FakeReqFactory, curlRepo *testapi.FakeCurlRepository) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	ctxt := testcmd.NewContext("curl", args)
+
+	cmd := NewCurl(ui, &configuration.Configuration{}, curlRepo)
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type DeleteProfile struct {
+	ui         terminal.UI
+	configRepo configuration.ConfigurationRepository
+}
+
+func NewDeleteProfile(ui terminal.UI, configRepo configuration.ConfigurationRepository) (cmd DeleteProfile) {
+	cmd.ui = ui
+	cmd.configRepo = configRepo
+	return
+}
+
+func (cmd DeleteProfile) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "delete-profile")
+	}
+	return
+}
+
+func (cmd DeleteProfile) Run(c *cli.Context) {
+	name := c.Args()[0]
+	force := c.Bool("f")
+
+	cmd.ui.Say("Deleting profile %s...", terminal.EntityNameColor(name))
+
+	names, err := cmd.configRepo.ListProfiles()
+	if err != nil {
+		cmd.ui.ConfigFailure(err)
+		return
+	}
+
+	if !containsProfile(names, name) {
+		cmd.ui.Ok()
+		cmd.ui.Warn("Profile %s does not exist.", name)
+		return
+	}
+
+	if !force {
+		response := cmd.ui.Confirm(
+			"Really delete profile %s and its saved credentials?%s",
+			terminal.EntityNameColor(name),
+			terminal.PromptColor(">"),
+		)
+		if !response {
+			return
+		}
+	}
+
+	err = cmd.configRepo.DeleteProfile(name)
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	cmd.ui.Ok()
+}
+
+func containsProfile(names []string, name string) bool {
+	for _, existing := range names {
+		if existing == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package commands_test
+
+import (
+	. "cf/commands"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestDeleteProfileFailsWithUsage(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+
+	ui := callDeleteProfile([]string{}, []string{}, configRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callDeleteProfile([]string{"prod"}, []string{"n"}, configRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestDeleteProfileWithConfirmation(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	defer configRepo.Delete()
+
+	configRepo.SwitchProfile("prod")
+	configRepo.SwitchProfile("default")
+
+	ui := callDeleteProfile([]string{"prod"}, []string{"y"}, configRepo)
+
+	assert.Contains(t, ui.Outputs[0], "Deleting profile")
+	assert.Contains(t, ui.Prompts[0], "Really delete profile")
+	assert.Contains(t, ui.Outputs[1], "OK")
+
+	names, _ := configRepo.ListProfiles()
+	assert.Equal(t, names, []string{"default"})
+}
+
+func TestDeleteProfileWhenProfileDoesNotExist(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	defer configRepo.Delete()
+
+	ui := callDeleteProfile([]string{"-f", "prod"}, []string{}, configRepo)
+
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[2], "prod")
+	assert.Contains(t, ui.Outputs[2], "does not exist")
+}
+
+func TestDeleteCurrentProfileFails(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	defer configRepo.Delete()
+
+	configRepo.SwitchProfile("prod")
+
+	ui := callDeleteProfile([]string{"-f", "prod"}, []string{}, configRepo)
+
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "current profile cannot be deleted")
+}
+
+func callDeleteProfile(args []string, inputs []string, configRepo configuration.ConfigurationRepository) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{Inputs: inputs}
+	cmd := NewDeleteProfile(ui, configRepo)
+	ctxt := testcmd.NewContext("delete-profile", args)
+	testcmd.RunCommand(cmd, ctxt, &testreq.FakeReqFactory{})
+	return
+}
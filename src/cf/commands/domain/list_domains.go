@@ -11,6 +11,13 @@ import (
 	"strings"
 )
 
+type domainRecord struct {
+	Name   string   `json:"name" yaml:"name"`
+	Guid   string   `json:"guid" yaml:"guid"`
+	Status string   `json:"status" yaml:"status"`
+	Spaces []string `json:"spaces" yaml:"spaces"`
+}
+
 type ListDomains struct {
 	ui         terminal.UI
 	config     *configuration.Configuration
@@ -61,6 +68,7 @@ func (cmd *ListDomains) Run(c *cli.Context) {
 	table := [][]string{
 		[]string{"name", "status", "spaces"},
 	}
+	records := []domainRecord{}
 	for _, domain := range domains {
 		var status string
 		if domain.Shared {
@@ -71,12 +79,21 @@ func (cmd *ListDomains) Run(c *cli.Context) {
 			status = "owned"
 		}
 
+		spaceNames := formatters.MapStr(domain.Spaces)
+
 		table = append(table, []string{
 			domain.Name,
 			status,
-			strings.Join(formatters.MapStr(domain.Spaces), ", "),
+			strings.Join(spaceNames, ", "),
+		})
+
+		records = append(records, domainRecord{
+			Name:   domain.Name,
+			Guid:   domain.Guid,
+			Status: status,
+			Spaces: spaceNames,
 		})
 	}
 
-	cmd.ui.DisplayTable(table)
+	cmd.ui.DisplayRecords(table, records)
 }
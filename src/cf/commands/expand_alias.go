@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"io/ioutil"
+	"strings"
+)
+
+// expandAlias returns the command an alias stands for, with a context holding
+// the alias's default args followed by the ones the user gave, so that the
+// user's flags override the defaults. Commands the cli does not know, such as
+// plugin commands, get their args unparsed after the command name, just as the
+// app passes them.
+func expandAlias(name, aliasCommand string, c *cli.Context) (cmdName string, ctxt *cli.Context, err error) {
+	defaultArgs, err := splitCommandLine(aliasCommand)
+	if err != nil {
+		return
+	}
+	if len(defaultArgs) == 0 {
+		err = errors.New("The alias has no command.")
+		return
+	}
+
+	userArgs := c.Args()
+	if len(userArgs) > 0 && userArgs[0] == name {
+		userArgs = userArgs[1:]
+	}
+
+	cmdName = defaultArgs[0]
+	flagSet := flag.NewFlagSet(cmdName, flag.ContinueOnError)
+	flagSet.SetOutput(ioutil.Discard)
+
+	args := append(defaultArgs, userArgs...)
+
+	target := c.App.Command(cmdName)
+	if target != nil {
+		cmdName = target.Name
+		for _, f := range target.Flags {
+			f.Apply(flagSet)
+		}
+
+		var defaultPositional, userPositional []string
+		defaultPositional, err = parseInterspersedFlags(flagSet, defaultArgs[1:])
+		if err != nil {
+			return
+		}
+		userPositional, err = parseInterspersedFlags(flagSet, userArgs)
+		if err != nil {
+			return
+		}
+		args = append(defaultPositional, userPositional...)
+	}
+
+	// everything after "--" is left as args
+	err = flagSet.Parse(append([]string{"--"}, args...))
+	if err != nil {
+		return
+	}
+
+	ctxt = cli.NewContext(c.App, flagSet, globalFlagSet(c))
+	return
+}
+
+// parseInterspersedFlags parses flags wherever they are among args, and
+// returns the args that are not flags.
+func parseInterspersedFlags(flagSet *flag.FlagSet, args []string) (positional []string, err error) {
+	for {
+		err = flagSet.Parse(args)
+		if err != nil {
+			return
+		}
+
+		args = flagSet.Args()
+		if len(args) == 0 {
+			return
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// globalFlagSet copies the global flags given on the command line, since
+// a context does not expose them.
+func globalFlagSet(c *cli.Context) (globalSet *flag.FlagSet) {
+	globalSet = flag.NewFlagSet(c.App.Name, flag.ContinueOnError)
+	for _, f := range c.App.Flags {
+		f.Apply(globalSet)
+	}
+
+	globalSet.VisitAll(func(f *flag.Flag) {
+		globalSet.Set(f.Name, c.GlobalString(f.Name))
+	})
+	return
+}
+
+// splitCommandLine splits an alias command into args the way a shell would:
+// on whitespace, except inside single or double quotes, with a backslash
+// escaping the next character outside single quotes.
+func splitCommandLine(line string) (args []string, err error) {
+	var arg []rune
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, char := range line {
+		switch {
+		case escaped:
+			arg = append(arg, char)
+			escaped = false
+		case quote == '\'':
+			if char == '\'' {
+				quote = 0
+			} else {
+				arg = append(arg, char)
+			}
+		case char == '\\':
+			escaped = true
+			inArg = true
+		case quote == '"':
+			if char == '"' {
+				quote = 0
+			} else {
+				arg = append(arg, char)
+			}
+		case char == '\'' || char == '"':
+			quote = char
+			inArg = true
+		case char == ' ' || char == '\t' || char == '\n':
+			if inArg {
+				args = append(args, string(arg))
+				arg = nil
+				inArg = false
+			}
+		default:
+			arg = append(arg, char)
+			inArg = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		err = fmt.Errorf("Unterminated quote or escape in '%s'", line)
+		return
+	}
+	if inArg {
+		args = append(args, string(arg))
+	}
+	return
+}
+
+// joinCommandLine is the reverse of splitCommandLine, quoting the args that
+// need it.
+func joinCommandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for index, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\") {
+			quoted[index] = arg
+			continue
+		}
+		quoted[index] = "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"flag"
+	"github.com/codegangsta/cli"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	args, err := splitCommandLine(`push  -c 'bundle exec rake' -m "512M" my\ app`)
+	assert.NoError(t, err)
+	assert.Equal(t, args, []string{"push", "-c", "bundle exec rake", "-m", "512M", "my app"})
+
+	args, err = splitCommandLine(`set-env my-app GREETING "say \"hi\"" ''`)
+	assert.NoError(t, err)
+	assert.Equal(t, args, []string{"set-env", "my-app", "GREETING", `say "hi"`, ""})
+
+	_, err = splitCommandLine(`push -c 'bundle exec`)
+	assert.Error(t, err)
+}
+
+func TestJoinCommandLineQuotesWhatSplitCommandLineSplits(t *testing.T) {
+	original := []string{"push", "-c", "bundle exec rake", "it's", ""}
+
+	line := joinCommandLine(original)
+	assert.Equal(t, line, `push -c 'bundle exec rake' 'it'\''s' ''`)
+
+	args, err := splitCommandLine(line)
+	assert.NoError(t, err)
+	assert.Equal(t, args, original)
+}
+
+func TestExpandAliasPassesArgsOfPluginCommandsUnparsed(t *testing.T) {
+	globalSet := flag.NewFlagSet("cf", flag.ContinueOnError)
+	globalSet.Parse([]string{"greet", "world", "-n", "2"})
+	ctxt := cli.NewContext(cli.NewApp(), globalSet, globalSet)
+
+	cmdName, expanded, err := expandAlias("greet", "hello --loud", ctxt)
+	assert.NoError(t, err)
+	assert.Equal(t, cmdName, "hello")
+	assert.Equal(t, expanded.Args(), []string{"hello", "--loud", "world", "-n", "2"})
+}
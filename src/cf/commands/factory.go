@@ -6,6 +6,7 @@ import (
 	"cf/commands/buildpack"
 	"cf/commands/domain"
 	"cf/commands/organization"
+	plugincommands "cf/commands/plugin"
 	"cf/commands/route"
 	"cf/commands/service"
 	"cf/commands/serviceauthtoken"
@@ -13,21 +14,32 @@ import (
 	"cf/commands/space"
 	"cf/commands/user"
 	"cf/configuration"
+	"cf/manifest"
+	"cf/plugin"
 	"cf/terminal"
 	"errors"
 )
 
 type Factory interface {
 	GetByCmdName(cmdName string) (cmd Command, err error)
+	GetAlias(name string) (command string, found bool)
 }
 
 type ConcreteFactory struct {
 	cmdsByName map[string]Command
+	ui         terminal.UI
+	config     *configuration.Configuration
+	pluginRepo plugin.PluginRepository
 }
 
 func NewFactory(ui terminal.UI, config *configuration.Configuration, configRepo configuration.ConfigurationRepository, repoLocator api.RepositoryLocator) (factory ConcreteFactory) {
 	factory.cmdsByName = make(map[string]Command)
+	factory.ui = ui
+	factory.config = config
+	factory.pluginRepo = plugin.NewPluginDiskRepository()
 
+	factory.cmdsByName["alias"] = NewAlias(ui, configRepo, factory.isCommand)
+	factory.cmdsByName["aliases"] = NewListAliases(ui, configRepo)
 	factory.cmdsByName["api"] = NewApi(ui, config, repoLocator.GetEndpointRepository())
 	factory.cmdsByName["app"] = application.NewShowApp(ui, config, repoLocator.GetAppSummaryRepository())
 	factory.cmdsByName["apps"] = application.NewListApps(ui, config, repoLocator.GetAppSummaryRepository())
@@ -37,16 +49,21 @@ func NewFactory(ui terminal.UI, config *configuration.Configuration, configRepo
 	factory.cmdsByName["create-buildpack"] = buildpack.NewCreateBuildpack(ui, repoLocator.GetBuildpackRepository(), repoLocator.GetBuildpackBitsRepository())
 	factory.cmdsByName["create-domain"] = domain.NewCreateDomain(ui, config, repoLocator.GetDomainRepository())
 	factory.cmdsByName["create-org"] = organization.NewCreateOrg(ui, config, repoLocator.GetOrganizationRepository())
+	factory.cmdsByName["create-quota"] = organization.NewCreateQuota(ui, config, repoLocator.GetQuotaRepository())
 	factory.cmdsByName["create-service"] = service.NewCreateService(ui, config, repoLocator.GetServiceRepository())
 	factory.cmdsByName["create-service-auth-token"] = serviceauthtoken.NewCreateServiceAuthToken(ui, config, repoLocator.GetServiceAuthTokenRepository())
 	factory.cmdsByName["create-service-broker"] = servicebroker.NewCreateServiceBroker(ui, config, repoLocator.GetServiceBrokerRepository())
 	factory.cmdsByName["create-space"] = space.NewCreateSpace(ui, config, repoLocator.GetSpaceRepository())
+	factory.cmdsByName["create-space-quota"] = organization.NewCreateSpaceQuota(ui, config, repoLocator.GetSpaceQuotaRepository())
 	factory.cmdsByName["create-user"] = user.NewCreateUser(ui, config, repoLocator.GetUserRepository())
 	factory.cmdsByName["create-user-provided-service"] = service.NewCreateUserProvidedService(ui, config, repoLocator.GetUserProvidedServiceInstanceRepository())
+	factory.cmdsByName["curl"] = NewCurl(ui, config, repoLocator.GetCurlRepository())
 	factory.cmdsByName["delete"] = application.NewDeleteApp(ui, config, repoLocator.GetApplicationRepository())
 	factory.cmdsByName["delete-buildpack"] = buildpack.NewDeleteBuildpack(ui, repoLocator.GetBuildpackRepository())
 	factory.cmdsByName["delete-domain"] = domain.NewDeleteDomain(ui, config, repoLocator.GetDomainRepository())
 	factory.cmdsByName["delete-org"] = organization.NewDeleteOrg(ui, config, repoLocator.GetOrganizationRepository(), configRepo)
+	factory.cmdsByName["delete-profile"] = NewDeleteProfile(ui, configRepo)
+	factory.cmdsByName["delete-quota"] = organization.NewDeleteQuota(ui, config, repoLocator.GetQuotaRepository())
 	factory.cmdsByName["delete-route"] = route.NewDeleteRoute(ui, config, repoLocator.GetRouteRepository())
 	factory.cmdsByName["delete-service"] = service.NewDeleteService(ui, config, repoLocator.GetServiceRepository())
 	factory.cmdsByName["delete-service-auth-token"] = serviceauthtoken.NewDeleteServiceAuthToken(ui, config, repoLocator.GetServiceAuthTokenRepository())
@@ -57,18 +74,25 @@ func NewFactory(ui terminal.UI, config *configuration.Configuration, configRepo
 	factory.cmdsByName["env"] = application.NewEnv(ui, config)
 	factory.cmdsByName["events"] = application.NewEvents(ui, config, repoLocator.GetAppEventsRepository())
 	factory.cmdsByName["files"] = application.NewFiles(ui, config, repoLocator.GetAppFilesRepository())
+	factory.cmdsByName["install-plugin"] = plugincommands.NewInstallPlugin(ui, factory.pluginRepo, factory.isBuiltInCommand)
 	factory.cmdsByName["login"] = NewLogin(ui, configRepo, repoLocator.GetAuthenticationRepository(), repoLocator.GetEndpointRepository(), repoLocator.GetOrganizationRepository(), repoLocator.GetSpaceRepository())
 	factory.cmdsByName["logout"] = NewLogout(ui, configRepo)
-	factory.cmdsByName["logs"] = application.NewLogs(ui, config, repoLocator.GetLogsRepository())
+	factory.cmdsByName["logs"] = application.NewLogs(ui, config, repoLocator.GetLogsRepository(), repoLocator.GetApplicationRepository(), repoLocator.GetAppSummaryRepository())
 	factory.cmdsByName["marketplace"] = service.NewMarketplaceServices(ui, config, repoLocator.GetServiceRepository())
 	factory.cmdsByName["map-domain"] = domain.NewDomainMapper(ui, config, repoLocator.GetDomainRepository(), true)
+	factory.cmdsByName["oauth-token"] = NewOAuthToken(ui, repoLocator.GetAuthenticationRepository())
 	factory.cmdsByName["org"] = organization.NewShowOrg(ui, config)
+	factory.cmdsByName["org-usage"] = organization.NewOrgUsage(ui, config, repoLocator.GetAppSummaryRepository(), repoLocator.GetServiceSummaryRepository(), repoLocator.GetApplicationRepository(), repoLocator.GetQuotaRepository())
 	factory.cmdsByName["org-users"] = user.NewOrgUsers(ui, config, repoLocator.GetUserRepository())
 	factory.cmdsByName["orgs"] = organization.NewListOrgs(ui, config, repoLocator.GetOrganizationRepository())
 	factory.cmdsByName["passwd"] = NewPassword(ui, repoLocator.GetPasswordRepository(), configRepo)
+	factory.cmdsByName["plugins"] = plugincommands.NewListPlugins(ui, factory.pluginRepo)
+	factory.cmdsByName["profiles"] = NewListProfiles(ui, configRepo)
+	factory.cmdsByName["quota"] = organization.NewShowQuota(ui, config, repoLocator.GetQuotaRepository())
 	factory.cmdsByName["quotas"] = organization.NewListQuotas(ui, config, repoLocator.GetQuotaRepository())
 	factory.cmdsByName["rename"] = application.NewRenameApp(ui, config, repoLocator.GetApplicationRepository())
 	factory.cmdsByName["rename-org"] = organization.NewRenameOrg(ui, config, repoLocator.GetOrganizationRepository())
+	factory.cmdsByName["rename-profile"] = NewRenameProfile(ui, configRepo)
 	factory.cmdsByName["rename-service"] = service.NewRenameService(ui, config, repoLocator.GetServiceRepository())
 	factory.cmdsByName["rename-service-broker"] = servicebroker.NewRenameServiceBroker(ui, config, repoLocator.GetServiceBrokerRepository())
 	factory.cmdsByName["rename-space"] = space.NewRenameSpace(ui, config, repoLocator.GetSpaceRepository(), configRepo)
@@ -76,25 +100,32 @@ func NewFactory(ui terminal.UI, config *configuration.Configuration, configRepo
 	factory.cmdsByName["service"] = service.NewShowService(ui)
 	factory.cmdsByName["service-auth-tokens"] = serviceauthtoken.NewListServiceAuthTokens(ui, config, repoLocator.GetServiceAuthTokenRepository())
 	factory.cmdsByName["service-brokers"] = servicebroker.NewListServiceBrokers(ui, config, repoLocator.GetServiceBrokerRepository())
+	factory.cmdsByName["service-credentials"] = service.NewServiceCredentials(ui, config, repoLocator.GetServiceBindingRepository())
 	factory.cmdsByName["services"] = service.NewListServices(ui, config, repoLocator.GetServiceSummaryRepository())
 	factory.cmdsByName["set-env"] = application.NewSetEnv(ui, config, repoLocator.GetApplicationRepository())
 	factory.cmdsByName["set-org-role"] = user.NewSetOrgRole(ui, config, repoLocator.GetUserRepository())
 	factory.cmdsByName["set-quota"] = organization.NewSetQuota(ui, config, repoLocator.GetQuotaRepository())
+	factory.cmdsByName["set-space-quota"] = organization.NewSetSpaceQuota(ui, config, repoLocator.GetSpaceQuotaRepository())
 	factory.cmdsByName["set-space-role"] = user.NewSetSpaceRole(ui, config, repoLocator.GetSpaceRepository(), repoLocator.GetUserRepository())
 	factory.cmdsByName["share-domain"] = domain.NewShareDomain(ui, config, repoLocator.GetDomainRepository())
 	factory.cmdsByName["space"] = space.NewShowSpace(ui, config)
+	factory.cmdsByName["space-usage"] = organization.NewSpaceUsage(ui, config, repoLocator.GetAppSummaryRepository(), repoLocator.GetServiceSummaryRepository(), repoLocator.GetApplicationRepository(), repoLocator.GetOrganizationRepository(), repoLocator.GetQuotaRepository(), repoLocator.GetSpaceQuotaRepository())
 	factory.cmdsByName["space-users"] = user.NewSpaceUsers(ui, config, repoLocator.GetSpaceRepository(), repoLocator.GetUserRepository())
 	factory.cmdsByName["spaces"] = space.NewListSpaces(ui, config, repoLocator.GetSpaceRepository())
 	factory.cmdsByName["stacks"] = NewStacks(ui, config, repoLocator.GetStackRepository())
 	factory.cmdsByName["target"] = NewTarget(ui, configRepo, repoLocator.GetOrganizationRepository(), repoLocator.GetSpaceRepository())
+	factory.cmdsByName["unalias"] = NewUnalias(ui, configRepo)
 	factory.cmdsByName["unbind-service"] = service.NewUnbindService(ui, config, repoLocator.GetServiceBindingRepository())
+	factory.cmdsByName["uninstall-plugin"] = plugincommands.NewUninstallPlugin(ui, factory.pluginRepo)
 	factory.cmdsByName["unmap-domain"] = domain.NewDomainMapper(ui, config, repoLocator.GetDomainRepository(), false)
 	factory.cmdsByName["unset-env"] = application.NewUnsetEnv(ui, config, repoLocator.GetApplicationRepository())
 	factory.cmdsByName["unset-org-role"] = user.NewUnsetOrgRole(ui, config, repoLocator.GetUserRepository())
 	factory.cmdsByName["unset-space-role"] = user.NewUnsetSpaceRole(ui, config, repoLocator.GetSpaceRepository(), repoLocator.GetUserRepository())
 	factory.cmdsByName["update-buildpack"] = buildpack.NewUpdateBuildpack(ui, repoLocator.GetBuildpackRepository(), repoLocator.GetBuildpackBitsRepository())
+	factory.cmdsByName["update-quota"] = organization.NewUpdateQuota(ui, config, repoLocator.GetQuotaRepository())
 	factory.cmdsByName["update-service-broker"] = servicebroker.NewUpdateServiceBroker(ui, config, repoLocator.GetServiceBrokerRepository())
 	factory.cmdsByName["update-service-auth-token"] = serviceauthtoken.NewUpdateServiceAuthToken(ui, config, repoLocator.GetServiceAuthTokenRepository())
+	factory.cmdsByName["update-service"] = service.NewUpdateService(ui, config, repoLocator.GetServiceRepository())
 	factory.cmdsByName["update-user-provided-service"] = service.NewUpdateUserProvidedService(ui, config, repoLocator.GetUserProvidedServiceInstanceRepository())
 
 	createRoute := route.NewCreateRoute(ui, config, repoLocator.GetRouteRepository())
@@ -109,16 +140,48 @@ func NewFactory(ui terminal.UI, config *configuration.Configuration, configRepo
 	factory.cmdsByName["start"] = start
 	factory.cmdsByName["stop"] = stop
 	factory.cmdsByName["restart"] = restart
-	factory.cmdsByName["push"] = application.NewPush(ui, config, start, stop, repoLocator.GetApplicationRepository(), repoLocator.GetDomainRepository(), repoLocator.GetRouteRepository(), repoLocator.GetStackRepository(), repoLocator.GetApplicationBitsRepository())
+	factory.cmdsByName["push"] = application.NewPush(ui, config, start, stop, manifest.NewManifestDiskRepository(), repoLocator.GetApplicationRepository(), repoLocator.GetDomainRepository(), repoLocator.GetRouteRepository(), repoLocator.GetStackRepository(), repoLocator.GetServiceRepository(), repoLocator.GetServiceBindingRepository(), repoLocator.GetServiceSummaryRepository(), repoLocator.GetApplicationBitsRepository())
 	factory.cmdsByName["scale"] = application.NewScale(ui, config, restart, repoLocator.GetApplicationRepository())
+	factory.cmdsByName["bind-services"] = service.NewBindServices(ui, config, repoLocator.GetServiceSummaryRepository(), repoLocator.GetServiceRepository(), repoLocator.GetApplicationRepository(), repoLocator.GetServiceBindingRepository(), manifest.NewManifestDiskRepository(), restart)
 
 	return
 }
 
 func (f ConcreteFactory) GetByCmdName(cmdName string) (cmd Command, err error) {
 	cmd, found := f.cmdsByName[cmdName]
-	if !found {
-		err = errors.New("Command not found")
+	if found {
+		return
+	}
+
+	if f.pluginRepo != nil {
+		installedPlugin, found, findErr := f.pluginRepo.FindByCommandName(cmdName)
+		if findErr == nil && found {
+			cmd = plugincommands.NewRunPlugin(f.ui, f.config, f.pluginRepo, installedPlugin, cmdName)
+			return
+		}
+	}
+
+	err = errors.New("Command not found")
+	return
+}
+
+func (f ConcreteFactory) GetAlias(name string) (command string, found bool) {
+	if f.config != nil {
+		command, found = f.config.Aliases[name]
 	}
 	return
 }
+
+func (f ConcreteFactory) isBuiltInCommand(cmdName string) bool {
+	_, found := f.cmdsByName[cmdName]
+	return found || cmdName == "help" || cmdName == "h"
+}
+
+func (f ConcreteFactory) isCommand(cmdName string) bool {
+	if f.isBuiltInCommand(cmdName) {
+		return true
+	}
+
+	_, found, err := f.pluginRepo.FindByCommandName(cmdName)
+	return err == nil && found
+}
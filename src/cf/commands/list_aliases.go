@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"github.com/codegangsta/cli"
+)
+
+type aliasRecord struct {
+	Name    string `json:"name" yaml:"name"`
+	Command string `json:"command" yaml:"command"`
+}
+
+type ListAliases struct {
+	ui         terminal.UI
+	configRepo configuration.ConfigurationRepository
+}
+
+func NewListAliases(ui terminal.UI, configRepo configuration.ConfigurationRepository) (cmd ListAliases) {
+	cmd.ui = ui
+	cmd.configRepo = configRepo
+	return
+}
+
+func (cmd ListAliases) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	return
+}
+
+func (cmd ListAliases) Run(c *cli.Context) {
+	cmd.ui.Say("Getting aliases...")
+
+	config, err := cmd.configRepo.Get()
+	if err != nil {
+		cmd.ui.ConfigFailure(err)
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	records := []aliasRecord{}
+	names := config.AliasNames()
+	if len(names) == 0 {
+		cmd.ui.Say("No aliases defined")
+		cmd.ui.DisplayRecords(nil, records)
+		return
+	}
+
+	table := [][]string{
+		[]string{"alias", "command"},
+	}
+
+	for _, name := range names {
+		table = append(table, []string{name, config.Aliases[name]})
+		records = append(records, aliasRecord{Name: name, Command: config.Aliases[name]})
+	}
+
+	cmd.ui.DisplayRecords(table, records)
+}
@@ -0,0 +1,50 @@
+package commands_test
+
+import (
+	. "cf/commands"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestListAliases(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	defer configRepo.Delete()
+
+	configRepo.SetAlias("ls", "apps")
+	configRepo.SetAlias("deploy", "push -m 512M -i 2")
+
+	ui := callListAliases(configRepo)
+
+	assert.Contains(t, ui.Outputs[0], "Getting aliases")
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[3], "alias")
+	assert.Contains(t, ui.Outputs[4], "deploy")
+	assert.Contains(t, ui.Outputs[4], "push -m 512M -i 2")
+	assert.Contains(t, ui.Outputs[5], "ls")
+	assert.Contains(t, ui.Outputs[5], "apps")
+}
+
+func TestListAliasesWhenNoneAreDefined(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	defer configRepo.Delete()
+
+	ui := callListAliases(configRepo)
+
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[3], "No aliases defined")
+}
+
+func callListAliases(configRepo configuration.ConfigurationRepository) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	cmd := NewListAliases(ui, configRepo)
+	ctxt := testcmd.NewContext("aliases", []string{})
+	testcmd.RunCommand(cmd, ctxt, &testreq.FakeReqFactory{})
+	return
+}
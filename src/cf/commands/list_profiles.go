@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"github.com/codegangsta/cli"
+)
+
+type profileRecord struct {
+	Name        string `json:"name" yaml:"name"`
+	ApiEndpoint string `json:"api_endpoint" yaml:"api_endpoint"`
+	Org         string `json:"org" yaml:"org"`
+	Space       string `json:"space" yaml:"space"`
+	Current     bool   `json:"current" yaml:"current"`
+}
+
+type ListProfiles struct {
+	ui         terminal.UI
+	configRepo configuration.ConfigurationRepository
+}
+
+func NewListProfiles(ui terminal.UI, configRepo configuration.ConfigurationRepository) (cmd ListProfiles) {
+	cmd.ui = ui
+	cmd.configRepo = configRepo
+	return
+}
+
+func (cmd ListProfiles) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	return
+}
+
+func (cmd ListProfiles) Run(c *cli.Context) {
+	cmd.ui.Say("Getting profiles...")
+
+	names, err := cmd.configRepo.ListProfiles()
+	if err != nil {
+		cmd.ui.ConfigFailure(err)
+		return
+	}
+
+	config, err := cmd.configRepo.Get()
+	if err != nil {
+		cmd.ui.ConfigFailure(err)
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	table := [][]string{
+		[]string{"name", "api endpoint", "org", "space"},
+	}
+	records := []profileRecord{}
+
+	for _, name := range names {
+		profile, _ := config.FindProfile(name)
+		current := name == config.ProfileName()
+
+		displayName := name
+		if current {
+			displayName = name + " (current)"
+		}
+
+		table = append(table, []string{
+			displayName,
+			profile.Target,
+			profile.Organization.Name,
+			profile.Space.Name,
+		})
+		records = append(records, profileRecord{
+			Name:        name,
+			ApiEndpoint: profile.Target,
+			Org:         profile.Organization.Name,
+			Space:       profile.Space.Name,
+			Current:     current,
+		})
+	}
+
+	cmd.ui.DisplayRecords(table, records)
+}
@@ -0,0 +1,41 @@
+package commands_test
+
+import (
+	. "cf/commands"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestListProfiles(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	defer configRepo.Delete()
+
+	config, _ := configRepo.Get()
+	config.Target = "https://api.staging.example.com"
+	configRepo.SwitchProfile("prod")
+	config.Target = "https://api.prod.example.com"
+
+	ui := callListProfiles(configRepo)
+
+	assert.Contains(t, ui.Outputs[0], "Getting profiles")
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[3], "name")
+	assert.Contains(t, ui.Outputs[4], "default")
+	assert.Contains(t, ui.Outputs[4], "https://api.staging.example.com")
+	assert.Contains(t, ui.Outputs[5], "prod (current)")
+	assert.Contains(t, ui.Outputs[5], "https://api.prod.example.com")
+}
+
+func callListProfiles(configRepo configuration.ConfigurationRepository) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	cmd := NewListProfiles(ui, configRepo)
+	ctxt := testcmd.NewContext("profiles", []string{})
+	testcmd.RunCommand(cmd, ctxt, &testreq.FakeReqFactory{})
+	return
+}
@@ -81,6 +81,10 @@ func (cmd Login) Run(c *cli.Context) {
 
 func (cmd Login) setApi(c *cli.Context) (apiResponse net.ApiResponse) {
 	api := c.String("a")
+	if api != "" || c.Bool("skip-ssl-validation") {
+		cmd.config.SSLDisabled = c.Bool("skip-ssl-validation")
+	}
+
 	if api == "" {
 		api = cmd.config.Target
 	}
@@ -101,6 +105,10 @@ func (cmd Login) setApi(c *cli.Context) (apiResponse net.ApiResponse) {
 }
 
 func (cmd Login) authenticate(c *cli.Context) (apiResponse net.ApiResponse) {
+	if c.Bool("sso") {
+		return cmd.authenticateWithPasscode()
+	}
+
 	username := c.String("u")
 	if username == "" {
 		username = cmd.ui.Ask("Username%s", terminal.PromptColor(">"))
@@ -127,6 +135,26 @@ func (cmd Login) authenticate(c *cli.Context) (apiResponse net.ApiResponse) {
 	return
 }
 
+func (cmd Login) authenticateWithPasscode() (apiResponse net.ApiResponse) {
+	passcodeUrl := cmd.config.AuthorizationEndpoint + "/passcode"
+
+	for i := 0; i < maxLoginTries; i++ {
+		passcode := cmd.ui.AskForPassword("One Time Code (Get one at %s)%s", passcodeUrl, terminal.PromptColor(">"))
+
+		cmd.ui.Say("Authenticating...")
+
+		apiResponse = cmd.authenticator.AuthenticateWithPasscode(passcode)
+		if apiResponse.IsSuccessful() {
+			cmd.ui.Ok()
+			cmd.ui.Say("")
+			break
+		}
+
+		cmd.ui.Say(apiResponse.Message)
+	}
+	return
+}
+
 func (cmd Login) setOrganization(c *cli.Context, userChanged bool) (apiResponse net.ApiResponse) {
 	orgName := c.String("o")
 
@@ -213,6 +213,38 @@ func TestSuccessfullyLoggingInWithFlags(t *testing.T) {
 	assert.True(t, c.ui.ShowConfigurationCalled)
 }
 
+func TestLoggingInWithSkipSSLValidation(t *testing.T) {
+	c := LoginTestContext{
+		Flags: []string{"-a", "https://api.example.com", "--skip-ssl-validation", "-u", "user@example.com", "-p", "password", "-o", "my-org", "-s", "my-space"},
+	}
+
+	callLogin(t, &c, defaultBeforeBlock)
+
+	assert.True(t, testconfig.SavedConfiguration.SSLDisabled)
+	assert.Equal(t, c.endpointRepo.UpdateEndpointEndpoint, "https://api.example.com")
+}
+
+func TestLoggingInWithSSOPasscode(t *testing.T) {
+	c := LoginTestContext{
+		Flags:  []string{"--sso", "-a", "api.example.com", "-o", "my-org", "-s", "my-space"},
+		Inputs: []string{"my-passcode"},
+	}
+
+	callLogin(t, &c, func(c *LoginTestContext) {
+		config, _ := c.configRepo.Get()
+		config.AuthorizationEndpoint = "https://login.example.com"
+	})
+
+	assert.Contains(t, c.ui.PasswordPrompts[0], "One Time Code")
+	assert.Contains(t, c.ui.PasswordPrompts[0], "https://login.example.com/passcode")
+	assert.Equal(t, len(c.ui.Prompts), 0)
+
+	assert.Equal(t, c.authRepo.Passcode, "my-passcode")
+	assert.Empty(t, c.authRepo.Email)
+	assert.Equal(t, testconfig.SavedConfiguration.AccessToken, "my_access_token")
+	assert.True(t, c.ui.ShowConfigurationCalled)
+}
+
 func TestSuccessfullyLoggingInWithEndpointSetInConfig(t *testing.T) {
 	existingConfig := configuration.Configuration{
 		Target: "http://api.example.com",
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"cf/api"
+	"cf/requirements"
+	"cf/terminal"
+	"github.com/codegangsta/cli"
+)
+
+type OAuthToken struct {
+	ui            terminal.UI
+	authenticator api.AuthenticationRepository
+}
+
+func NewOAuthToken(ui terminal.UI, authenticator api.AuthenticationRepository) (cmd OAuthToken) {
+	cmd.ui = ui
+	cmd.authenticator = authenticator
+	return
+}
+
+func (cmd OAuthToken) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+	}
+	return
+}
+
+// Run prints only the token, so that other tools can use the output as is.
+func (cmd OAuthToken) Run(c *cli.Context) {
+	token, apiResponse := cmd.authenticator.RefreshAuthToken()
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Say("%s", token)
+}
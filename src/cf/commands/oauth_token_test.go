@@ -0,0 +1,48 @@
+package commands_test
+
+import (
+	. "cf/commands"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testcmd "testhelpers/commands"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestOAuthTokenRequirements(t *testing.T) {
+	auth := &testapi.FakeAuthenticationRepository{}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: false}
+	callOAuthToken(auth, reqFactory)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true}
+	callOAuthToken(auth, reqFactory)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestOAuthTokenPrintsARefreshedToken(t *testing.T) {
+	auth := &testapi.FakeAuthenticationRepository{AccessToken: "bearer my-fresh-token"}
+
+	ui := callOAuthToken(auth, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	assert.True(t, auth.RefreshTokenCalled)
+	assert.Equal(t, ui.Outputs, []string{"bearer my-fresh-token"})
+}
+
+func TestOAuthTokenWhenRefreshFails(t *testing.T) {
+	auth := &testapi.FakeAuthenticationRepository{RefreshTokenError: true}
+
+	ui := callOAuthToken(auth, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	assert.Equal(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "Error refreshing auth token")
+}
+
+func callOAuthToken(auth *testapi.FakeAuthenticationRepository, reqFactory *testreq.FakeReqFactory) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	cmd := NewOAuthToken(ui, auth)
+	testcmd.RunCommand(cmd, testcmd.NewContext("oauth-token", []string{}), reqFactory)
+	return
+}
@@ -0,0 +1,71 @@
+package organization
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type CreateQuota struct {
+	ui        terminal.UI
+	config    *configuration.Configuration
+	quotaRepo api.QuotaRepository
+}
+
+func NewCreateQuota(ui terminal.UI, config *configuration.Configuration, quotaRepo api.QuotaRepository) (cmd *CreateQuota) {
+	cmd = new(CreateQuota)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.quotaRepo = quotaRepo
+	return
+}
+
+func (cmd *CreateQuota) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "create-quota")
+		return
+	}
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+	}
+	return
+}
+
+func (cmd *CreateQuota) Run(c *cli.Context) {
+	quota := cf.Quota{
+		Name:                c.Args()[0],
+		InstanceMemoryLimit: cf.UnlimitedQuota,
+		AppInstanceLimit:    cf.UnlimitedQuota,
+	}
+
+	err := applyQuotaFlags(&quota, c)
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	cmd.ui.Say("Creating quota %s as %s...",
+		terminal.EntityNameColor(quota.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	apiResponse := cmd.quotaRepo.Create(quota)
+	if apiResponse.IsNotSuccessful() {
+		if apiResponse.ErrorCode == cf.QUOTA_EXISTS {
+			cmd.ui.Ok()
+			cmd.ui.Warn("Quota %s already exists", quota.Name)
+			return
+		}
+
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Ok()
+}
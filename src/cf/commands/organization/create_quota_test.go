@@ -0,0 +1,119 @@
+package organization_test
+
+import (
+	"cf"
+	. "cf/commands/organization"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestCreateQuotaFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{}
+	quotaRepo := &testapi.FakeQuotaRepository{}
+
+	ui := callCreateQuota(t, []string{}, reqFactory, quotaRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callCreateQuota(t, []string{"my-quota"}, reqFactory, quotaRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestCreateQuotaRequirements(t *testing.T) {
+	quotaRepo := &testapi.FakeQuotaRepository{}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	callCreateQuota(t, []string{"my-quota"}, reqFactory, quotaRepo)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: false}
+	callCreateQuota(t, []string{"my-quota"}, reqFactory, quotaRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestCreateQuotaWithDefaults(t *testing.T) {
+	quotaRepo := &testapi.FakeQuotaRepository{}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callCreateQuota(t, []string{"my-quota"}, reqFactory, quotaRepo)
+
+	assert.Contains(t, ui.Outputs[0], "Creating quota")
+	assert.Contains(t, ui.Outputs[0], "my-quota")
+	assert.Contains(t, ui.Outputs[0], "my-user")
+	assert.Contains(t, ui.Outputs[1], "OK")
+
+	assert.Equal(t, quotaRepo.CreateQuota, cf.Quota{
+		Name:                "my-quota",
+		InstanceMemoryLimit: cf.UnlimitedQuota,
+		AppInstanceLimit:    cf.UnlimitedQuota,
+	})
+}
+
+func TestCreateQuotaWithLimits(t *testing.T) {
+	quotaRepo := &testapi.FakeQuotaRepository{}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	args := []string{"-m", "10G", "-i", "512M", "-r", "100", "-s", "20", "-a", "50", "--allow-paid-service-plans", "my-quota"}
+	callCreateQuota(t, args, reqFactory, quotaRepo)
+
+	assert.Equal(t, quotaRepo.CreateQuota, cf.Quota{
+		Name:                    "my-quota",
+		MemoryLimit:             10240,
+		InstanceMemoryLimit:     512,
+		RoutesLimit:             100,
+		ServicesLimit:           20,
+		NonBasicServicesAllowed: true,
+		AppInstanceLimit:        50,
+	})
+}
+
+func TestCreateQuotaWithInvalidLimits(t *testing.T) {
+	quotaRepo := &testapi.FakeQuotaRepository{}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callCreateQuota(t, []string{"-m", "lots", "my-quota"}, reqFactory, quotaRepo)
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "Invalid total memory limit")
+
+	ui = callCreateQuota(t, []string{"-r", "-5", "my-quota"}, reqFactory, quotaRepo)
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "Invalid total routes limit")
+
+	assert.Equal(t, quotaRepo.CreateQuota, cf.Quota{})
+}
+
+func TestCreateQuotaWhenItAlreadyExists(t *testing.T) {
+	quotaRepo := &testapi.FakeQuotaRepository{CreateErrorCode: cf.QUOTA_EXISTS}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callCreateQuota(t, []string{"my-quota"}, reqFactory, quotaRepo)
+
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[2], "my-quota")
+	assert.Contains(t, ui.Outputs[2], "already exists")
+}
+
+func callCreateQuota(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, repo *testapi.FakeQuotaRepository) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{}
+	ctxt := testcmd.NewContext("create-quota", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+
+	config := &configuration.Configuration{
+		Space:        cf.Space{Name: "my-space"},
+		Organization: cf.Organization{Name: "my-org", Guid: "my-org-guid"},
+		AccessToken:  token,
+	}
+
+	cmd := NewCreateQuota(ui, config, repo)
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
@@ -0,0 +1,77 @@
+package organization
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type CreateSpaceQuota struct {
+	ui             terminal.UI
+	config         *configuration.Configuration
+	spaceQuotaRepo api.SpaceQuotaRepository
+}
+
+func NewCreateSpaceQuota(ui terminal.UI, config *configuration.Configuration, spaceQuotaRepo api.SpaceQuotaRepository) (cmd *CreateSpaceQuota) {
+	cmd = new(CreateSpaceQuota)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.spaceQuotaRepo = spaceQuotaRepo
+	return
+}
+
+func (cmd *CreateSpaceQuota) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "create-space-quota")
+		return
+	}
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedOrgRequirement(),
+	}
+	return
+}
+
+func (cmd *CreateSpaceQuota) Run(c *cli.Context) {
+	quota := cf.SpaceQuota{
+		Quota: cf.Quota{
+			Name:                c.Args()[0],
+			InstanceMemoryLimit: cf.UnlimitedQuota,
+			AppInstanceLimit:    cf.UnlimitedQuota,
+		},
+		OrgGuid: cmd.config.Organization.Guid,
+	}
+
+	err := applyQuotaFlags(&quota.Quota, c)
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	cmd.ui.Say("Creating space quota %s for org %s as %s...",
+		terminal.EntityNameColor(quota.Name),
+		terminal.EntityNameColor(cmd.config.Organization.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	apiResponse := cmd.spaceQuotaRepo.Create(quota)
+	if apiResponse.IsNotSuccessful() {
+		if apiResponse.ErrorCode == cf.SPACE_QUOTA_EXISTS {
+			cmd.ui.Ok()
+			cmd.ui.Warn("Space quota %s already exists", quota.Name)
+			return
+		}
+
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("\nTIP: Use '%s' to assign it to a space", terminal.CommandColor(cf.Name()+" set-space-quota SPACE "+quota.Name))
+}
@@ -0,0 +1,86 @@
+package organization_test
+
+import (
+	"cf"
+	. "cf/commands/organization"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestCreateSpaceQuotaFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{}
+	spaceQuotaRepo := &testapi.FakeSpaceQuotaRepository{}
+
+	ui := callCreateSpaceQuota(t, []string{}, reqFactory, spaceQuotaRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callCreateSpaceQuota(t, []string{"my-space-quota"}, reqFactory, spaceQuotaRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestCreateSpaceQuotaRequirements(t *testing.T) {
+	spaceQuotaRepo := &testapi.FakeSpaceQuotaRepository{}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedOrgSuccess: true}
+	callCreateSpaceQuota(t, []string{"my-space-quota"}, reqFactory, spaceQuotaRepo)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedOrgSuccess: false}
+	callCreateSpaceQuota(t, []string{"my-space-quota"}, reqFactory, spaceQuotaRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: false, TargetedOrgSuccess: true}
+	callCreateSpaceQuota(t, []string{"my-space-quota"}, reqFactory, spaceQuotaRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestCreateSpaceQuota(t *testing.T) {
+	spaceQuotaRepo := &testapi.FakeSpaceQuotaRepository{}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedOrgSuccess: true}
+
+	ui := callCreateSpaceQuota(t, []string{"-m", "2G", "-r", "10", "my-space-quota"}, reqFactory, spaceQuotaRepo)
+
+	assert.Contains(t, ui.Outputs[0], "Creating space quota")
+	assert.Contains(t, ui.Outputs[0], "my-space-quota")
+	assert.Contains(t, ui.Outputs[0], "my-org")
+	assert.Contains(t, ui.Outputs[0], "my-user")
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[2], "set-space-quota")
+
+	assert.Equal(t, spaceQuotaRepo.CreateQuota, cf.SpaceQuota{
+		Quota: cf.Quota{
+			Name:                "my-space-quota",
+			MemoryLimit:         2048,
+			InstanceMemoryLimit: cf.UnlimitedQuota,
+			RoutesLimit:         10,
+			AppInstanceLimit:    cf.UnlimitedQuota,
+		},
+		OrgGuid: "my-org-guid",
+	})
+}
+
+func callCreateSpaceQuota(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, repo *testapi.FakeSpaceQuotaRepository) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{}
+	ctxt := testcmd.NewContext("create-space-quota", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+
+	config := &configuration.Configuration{
+		Space:        cf.Space{Name: "my-space"},
+		Organization: cf.Organization{Name: "my-org", Guid: "my-org-guid"},
+		AccessToken:  token,
+	}
+
+	cmd := NewCreateSpaceQuota(ui, config, repo)
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
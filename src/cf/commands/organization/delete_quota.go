@@ -0,0 +1,79 @@
+package organization
+
+import (
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type DeleteQuota struct {
+	ui        terminal.UI
+	config    *configuration.Configuration
+	quotaRepo api.QuotaRepository
+}
+
+func NewDeleteQuota(ui terminal.UI, config *configuration.Configuration, quotaRepo api.QuotaRepository) (cmd *DeleteQuota) {
+	cmd = new(DeleteQuota)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.quotaRepo = quotaRepo
+	return
+}
+
+func (cmd *DeleteQuota) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "delete-quota")
+		return
+	}
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+	}
+	return
+}
+
+func (cmd *DeleteQuota) Run(c *cli.Context) {
+	quotaName := c.Args()[0]
+
+	if !c.Bool("f") {
+		response := cmd.ui.Confirm(
+			"Really delete quota %s?%s",
+			terminal.EntityNameColor(quotaName),
+			terminal.PromptColor(">"),
+		)
+
+		if !response {
+			return
+		}
+	}
+
+	cmd.ui.Say("Deleting quota %s as %s...",
+		terminal.EntityNameColor(quotaName),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	quota, apiResponse := cmd.quotaRepo.FindByName(quotaName)
+
+	if apiResponse.IsError() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	if apiResponse.IsNotFound() {
+		cmd.ui.Ok()
+		cmd.ui.Warn("Quota %s does not exist", quotaName)
+		return
+	}
+
+	apiResponse = cmd.quotaRepo.Delete(quota)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Ok()
+}
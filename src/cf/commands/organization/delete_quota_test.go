@@ -0,0 +1,109 @@
+package organization_test
+
+import (
+	"cf"
+	. "cf/commands/organization"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestDeleteQuotaFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{}
+	quotaRepo := &testapi.FakeQuotaRepository{}
+
+	ui := callDeleteQuota(t, []string{}, reqFactory, quotaRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callDeleteQuota(t, []string{"-f", "my-quota"}, reqFactory, quotaRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestDeleteQuotaRequirements(t *testing.T) {
+	quotaRepo := &testapi.FakeQuotaRepository{}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	callDeleteQuota(t, []string{"-f", "my-quota"}, reqFactory, quotaRepo)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: false}
+	callDeleteQuota(t, []string{"-f", "my-quota"}, reqFactory, quotaRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestDeleteQuotaConfirmingWithY(t *testing.T) {
+	quota := cf.Quota{Name: "my-quota", Guid: "my-quota-guid"}
+	quotaRepo := &testapi.FakeQuotaRepository{FindByNameQuota: quota}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callDeleteQuota(t, []string{"my-quota"}, reqFactory, quotaRepo, "y")
+
+	assert.Contains(t, ui.Prompts[0], "Really delete")
+	assert.Contains(t, ui.Outputs[0], "Deleting quota")
+	assert.Contains(t, ui.Outputs[0], "my-quota")
+	assert.Contains(t, ui.Outputs[0], "my-user")
+	assert.Equal(t, quotaRepo.FindByNameName, "my-quota")
+	assert.Equal(t, quotaRepo.DeleteQuota, quota)
+	assert.Contains(t, ui.Outputs[1], "OK")
+}
+
+func TestDeleteQuotaDeclined(t *testing.T) {
+	quota := cf.Quota{Name: "my-quota", Guid: "my-quota-guid"}
+	quotaRepo := &testapi.FakeQuotaRepository{FindByNameQuota: quota}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callDeleteQuota(t, []string{"my-quota"}, reqFactory, quotaRepo, "n")
+
+	assert.Contains(t, ui.Prompts[0], "Really delete")
+	assert.Equal(t, len(ui.Outputs), 0)
+	assert.Equal(t, quotaRepo.DeleteQuota, cf.Quota{})
+}
+
+func TestDeleteQuotaWithForceOption(t *testing.T) {
+	quota := cf.Quota{Name: "my-quota", Guid: "my-quota-guid"}
+	quotaRepo := &testapi.FakeQuotaRepository{FindByNameQuota: quota}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callDeleteQuota(t, []string{"-f", "my-quota"}, reqFactory, quotaRepo)
+
+	assert.Equal(t, len(ui.Prompts), 0)
+	assert.Equal(t, quotaRepo.DeleteQuota, quota)
+	assert.Contains(t, ui.Outputs[1], "OK")
+}
+
+func TestDeleteQuotaWhenQuotaDoesNotExist(t *testing.T) {
+	quotaRepo := &testapi.FakeQuotaRepository{FindByNameNotFound: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callDeleteQuota(t, []string{"-f", "my-quota"}, reqFactory, quotaRepo)
+
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[2], "my-quota")
+	assert.Contains(t, ui.Outputs[2], "does not exist")
+	assert.Equal(t, quotaRepo.DeleteQuota, cf.Quota{})
+}
+
+func callDeleteQuota(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, repo *testapi.FakeQuotaRepository, inputs ...string) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{Inputs: inputs}
+	ctxt := testcmd.NewContext("delete-quota", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+
+	config := &configuration.Configuration{
+		Space:        cf.Space{Name: "my-space"},
+		Organization: cf.Organization{Name: "my-org", Guid: "my-org-guid"},
+		AccessToken:  token,
+	}
+
+	cmd := NewDeleteQuota(ui, config, repo)
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
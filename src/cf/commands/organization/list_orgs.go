@@ -1,6 +1,7 @@
 package organization
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/requirements"
@@ -8,6 +9,11 @@ import (
 	"github.com/codegangsta/cli"
 )
 
+type orgRecord struct {
+	Name string `json:"name" yaml:"name"`
+	Guid string `json:"guid" yaml:"guid"`
+}
+
 type ListOrgs struct {
 	ui      terminal.UI
 	config  *configuration.Configuration
@@ -31,16 +37,26 @@ func (cmd ListOrgs) GetRequirements(reqFactory requirements.Factory, c *cli.Cont
 func (cmd ListOrgs) Run(c *cli.Context) {
 	cmd.ui.Say("Getting orgs as %s...", terminal.EntityNameColor(cmd.config.Username()))
 
-	orgs, apiResponse := cmd.orgRepo.FindAll()
+	records := []orgRecord{}
+	apiResponse := cmd.orgRepo.ListOrgs(func(org cf.Organization) bool {
+		if len(records) == 0 {
+			cmd.ui.Ok()
+			cmd.ui.Say("")
+		}
+
+		cmd.ui.Say(org.Name)
+		records = append(records, orgRecord{Name: org.Name, Guid: org.Guid})
+		return true
+	})
 	if apiResponse.IsNotSuccessful() {
 		cmd.ui.Failed(apiResponse.Message)
 		return
 	}
 
-	cmd.ui.Ok()
-	cmd.ui.Say("")
-
-	for _, org := range orgs {
-		cmd.ui.Say(org.Name)
+	if len(records) == 0 {
+		cmd.ui.Ok()
+		cmd.ui.Say("")
 	}
+
+	cmd.ui.DisplayRecords(nil, records)
 }
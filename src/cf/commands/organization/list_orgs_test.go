@@ -4,6 +4,7 @@ import (
 	"cf"
 	. "cf/commands/organization"
 	"cf/configuration"
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	testapi "testhelpers/api"
 	testcmd "testhelpers/commands"
@@ -28,8 +29,8 @@ func TestListOrgsRequirements(t *testing.T) {
 
 func TestListOrgs(t *testing.T) {
 	orgs := []cf.Organization{
-		cf.Organization{Name: "Organization-1"},
-		cf.Organization{Name: "Organization-2"},
+		cf.Organization{Name: "Organization-1", Guid: "org-1-guid"},
+		cf.Organization{Name: "Organization-2", Guid: "org-2-guid"},
 	}
 	orgRepo := &testapi.FakeOrgRepository{
 		Organizations: orgs,
@@ -49,6 +50,10 @@ func TestListOrgs(t *testing.T) {
 	assert.Contains(t, ui.Outputs[1], "OK")
 	assert.Contains(t, ui.Outputs[3], "Organization-1")
 	assert.Contains(t, ui.Outputs[4], "Organization-2")
+
+	records, err := json.Marshal(ui.Records)
+	assert.NoError(t, err)
+	assert.Equal(t, string(records), `[{"name":"Organization-1","guid":"org-1-guid"},{"name":"Organization-2","guid":"org-2-guid"}]`)
 }
 
 func callListOrgs(config *configuration.Configuration, reqFactory *testreq.FakeReqFactory, orgRepo *testapi.FakeOrgRepository) (fakeUI *testterm.FakeUI) {
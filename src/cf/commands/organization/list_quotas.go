@@ -1,6 +1,7 @@
 package organization
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/formatters"
@@ -9,6 +10,30 @@ import (
 	"github.com/codegangsta/cli"
 )
 
+type quotaRecord struct {
+	Name                    string `json:"name" yaml:"name"`
+	Guid                    string `json:"guid" yaml:"guid"`
+	MemoryLimit             uint64 `json:"memory_limit" yaml:"memory_limit"`
+	InstanceMemoryLimit     int64  `json:"instance_memory_limit" yaml:"instance_memory_limit"`
+	RoutesLimit             int    `json:"total_routes" yaml:"total_routes"`
+	ServicesLimit           int    `json:"total_services" yaml:"total_services"`
+	NonBasicServicesAllowed bool   `json:"non_basic_services_allowed" yaml:"non_basic_services_allowed"`
+	AppInstanceLimit        int    `json:"app_instance_limit" yaml:"app_instance_limit"`
+}
+
+func newQuotaRecord(quota cf.Quota) *quotaRecord {
+	return &quotaRecord{
+		Name:                    quota.Name,
+		Guid:                    quota.Guid,
+		MemoryLimit:             quota.MemoryLimit,
+		InstanceMemoryLimit:     quota.InstanceMemoryLimit,
+		RoutesLimit:             quota.RoutesLimit,
+		ServicesLimit:           quota.ServicesLimit,
+		NonBasicServicesAllowed: quota.NonBasicServicesAllowed,
+		AppInstanceLimit:        quota.AppInstanceLimit,
+	}
+}
+
 type ListQuotas struct {
 	ui        terminal.UI
 	config    *configuration.Configuration
@@ -43,15 +68,23 @@ func (cmd *ListQuotas) Run(c *cli.Context) {
 	cmd.ui.Say("")
 
 	table := [][]string{
-		[]string{"name", "memory limit"},
+		[]string{"name", "memory limit", "instance memory limit", "routes", "service instances", "paid service plans", "app instance limit"},
 	}
+	records := []quotaRecord{}
 
 	for _, quota := range quotas {
 		table = append(table, []string{
 			quota.Name,
 			formatters.ByteSize(quota.MemoryLimit * formatters.MEGABYTE),
+			formatQuotaMemory(quota.InstanceMemoryLimit),
+			formatQuotaCount(quota.RoutesLimit),
+			formatQuotaCount(quota.ServicesLimit),
+			formatPaidServicePlans(quota.NonBasicServicesAllowed),
+			formatQuotaCount(quota.AppInstanceLimit),
 		})
+
+		records = append(records, *newQuotaRecord(quota))
 	}
 
-	cmd.ui.DisplayTable(table)
+	cmd.ui.DisplayRecords(table, records)
 }
@@ -26,7 +26,15 @@ func TestListQuotasRequirements(t *testing.T) {
 }
 
 func TestListQuotas(t *testing.T) {
-	quotas := []cf.Quota{{Name: "quota-name", MemoryLimit: 1024}}
+	quotas := []cf.Quota{{
+		Name:                    "quota-name",
+		MemoryLimit:             1024,
+		InstanceMemoryLimit:     cf.UnlimitedQuota,
+		RoutesLimit:             111,
+		ServicesLimit:           222,
+		NonBasicServicesAllowed: true,
+		AppInstanceLimit:        333,
+	}}
 	quotaRepo := &testapi.FakeQuotaRepository{FindAllQuotas: quotas}
 	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
 	ui := callListQuotas(t, reqFactory, quotaRepo)
@@ -38,6 +46,11 @@ func TestListQuotas(t *testing.T) {
 	assert.Contains(t, ui.Outputs[3], "memory limit")
 	assert.Contains(t, ui.Outputs[4], "quota-name")
 	assert.Contains(t, ui.Outputs[4], "1G")
+	assert.Contains(t, ui.Outputs[4], "unlimited")
+	assert.Contains(t, ui.Outputs[4], "111")
+	assert.Contains(t, ui.Outputs[4], "222")
+	assert.Contains(t, ui.Outputs[4], "allowed")
+	assert.Contains(t, ui.Outputs[4], "333")
 }
 
 func callListQuotas(t *testing.T, reqFactory *testreq.FakeReqFactory, quotaRepo *testapi.FakeQuotaRepository) (fakeUI *testterm.FakeUI) {
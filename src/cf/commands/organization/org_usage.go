@@ -0,0 +1,102 @@
+package organization
+
+import (
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type OrgUsage struct {
+	ui                 terminal.UI
+	config             *configuration.Configuration
+	appSummaryRepo     api.AppSummaryRepository
+	serviceSummaryRepo api.ServiceSummaryRepository
+	appRepo            api.ApplicationRepository
+	quotaRepo          api.QuotaRepository
+	orgReq             requirements.OrganizationRequirement
+}
+
+func NewOrgUsage(ui terminal.UI, config *configuration.Configuration, appSummaryRepo api.AppSummaryRepository,
+	serviceSummaryRepo api.ServiceSummaryRepository, appRepo api.ApplicationRepository, quotaRepo api.QuotaRepository) (cmd *OrgUsage) {
+
+	cmd = new(OrgUsage)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.appSummaryRepo = appSummaryRepo
+	cmd.serviceSummaryRepo = serviceSummaryRepo
+	cmd.appRepo = appRepo
+	cmd.quotaRepo = quotaRepo
+	return
+}
+
+func (cmd *OrgUsage) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "org-usage")
+		return
+	}
+
+	cmd.orgReq = reqFactory.NewOrganizationRequirement(c.Args()[0])
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		cmd.orgReq,
+	}
+	return
+}
+
+func (cmd *OrgUsage) Run(c *cli.Context) {
+	org := cmd.orgReq.GetOrganization()
+	cmd.ui.Say("Getting usage for org %s as %s...",
+		terminal.EntityNameColor(org.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	report := usageReportRecord{
+		Name:          org.Name,
+		Total:         usageRecord{Name: "total"},
+		Breakdown:     []usageRecord{},
+		UnhealthyApps: []unhealthyAppRecord{},
+	}
+
+	if org.QuotaGuid != "" {
+		quota, apiResponse := cmd.quotaRepo.FindByGuid(org.QuotaGuid)
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+		report.Quota = newQuotaRecord(quota)
+	}
+
+	for _, space := range org.Spaces {
+		apps, apiResponse := cmd.appSummaryRepo.GetSummariesInSpace(space)
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+
+		services, apiResponse := cmd.serviceSummaryRepo.GetSummariesInSpace(space)
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+
+		unhealthyApps, apiResponse := findUnhealthyApps(cmd.appRepo, space.Name, apps)
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+
+		usage := spaceUsage(space.Name, apps, services)
+		addUsage(&report.Total, usage)
+		report.Breakdown = append(report.Breakdown, usage)
+		report.UnhealthyApps = append(report.UnhealthyApps, unhealthyApps...)
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	displayUsageReport(cmd.ui, "space", report)
+}
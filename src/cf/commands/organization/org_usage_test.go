@@ -0,0 +1,141 @@
+package organization_test
+
+import (
+	"cf"
+	. "cf/commands/organization"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	testapi "testhelpers/api"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+type usageDeps struct {
+	reqFactory         *testreq.FakeReqFactory
+	appSummaryRepo     *testapi.FakeAppSummaryRepo
+	serviceSummaryRepo *testapi.FakeServiceSummaryRepo
+	appRepo            *testapi.FakeApplicationRepository
+	orgRepo            *testapi.FakeOrgRepository
+	quotaRepo          *testapi.FakeQuotaRepository
+	spaceQuotaRepo     *testapi.FakeSpaceQuotaRepository
+}
+
+func newUsageDeps() (deps usageDeps) {
+	devSpace := cf.Space{Name: "dev", Guid: "dev-guid"}
+	prodSpace := cf.Space{Name: "prod", Guid: "prod-guid", SpaceQuotaGuid: "prod-quota-guid"}
+	org := cf.Organization{Name: "my-org", Guid: "my-org-guid", QuotaGuid: "my-quota-guid", Spaces: []cf.Space{devSpace, prodSpace}}
+	sharedRoute := cf.Route{Guid: "shared-route-guid"}
+
+	deps.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedOrgSuccess: true, Organization: org, Space: prodSpace}
+	deps.appSummaryRepo = &testapi.FakeAppSummaryRepo{
+		GetSummariesInSpaceApps: map[string][]cf.Application{
+			"dev-guid": {
+				{Name: "dev-app", State: "started", Instances: 1, RunningInstances: 1, Memory: 512, Routes: []cf.Route{{Guid: "dev-route-guid"}}},
+				{Name: "stopped-app", State: "stopped", Instances: 4, Memory: 1024},
+			},
+			"prod-guid": {
+				{Name: "web", State: "started", Instances: 3, RunningInstances: 2, Memory: 1024, Routes: []cf.Route{sharedRoute}},
+				{Name: "worker", State: "started", Instances: 2, RunningInstances: 2, Memory: 256, Routes: []cf.Route{sharedRoute}},
+			},
+		},
+	}
+	deps.serviceSummaryRepo = &testapi.FakeServiceSummaryRepo{
+		GetSummariesInSpaceInstances: map[string][]cf.ServiceInstance{
+			"dev-guid": {{Name: "dev-db", ApplicationNames: []string{"dev-app"}}},
+			"prod-guid": {
+				{Name: "prod-db", ApplicationNames: []string{"web", "worker"}},
+				{Name: "prod-cache", ApplicationNames: []string{"web"}},
+			},
+		},
+	}
+	deps.appRepo = &testapi.FakeApplicationRepository{
+		GetInstancesResponses: [][]cf.ApplicationInstance{
+			{{State: cf.InstanceRunning}, {State: cf.InstanceRunning}, {State: cf.InstanceCrashed}},
+		},
+		GetInstancesErrorCodes: []string{""},
+	}
+	deps.orgRepo = &testapi.FakeOrgRepository{FindByNameOrganization: org}
+	deps.quotaRepo = &testapi.FakeQuotaRepository{
+		FindByGuidQuota: cf.Quota{Name: "my-quota", MemoryLimit: 10240, RoutesLimit: 100, ServicesLimit: 20, AppInstanceLimit: cf.UnlimitedQuota},
+	}
+	deps.spaceQuotaRepo = &testapi.FakeSpaceQuotaRepository{
+		FindByGuidQuota: cf.SpaceQuota{Quota: cf.Quota{Name: "prod-quota", MemoryLimit: 4096, RoutesLimit: 10, ServicesLimit: 5, AppInstanceLimit: 8}},
+	}
+	return
+}
+
+func TestOrgUsageFailsWithUsage(t *testing.T) {
+	deps := newUsageDeps()
+
+	ui := callOrgUsage(t, []string{}, deps)
+	assert.True(t, ui.FailedWithUsage)
+
+	deps = newUsageDeps()
+	ui = callOrgUsage(t, []string{"my-org"}, deps)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestOrgUsageRequirements(t *testing.T) {
+	deps := newUsageDeps()
+	callOrgUsage(t, []string{"my-org"}, deps)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, deps.reqFactory.OrganizationName, "my-org")
+
+	deps = newUsageDeps()
+	deps.reqFactory.LoginSuccess = false
+	callOrgUsage(t, []string{"my-org"}, deps)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestOrgUsage(t *testing.T) {
+	deps := newUsageDeps()
+	ui := callOrgUsage(t, []string{"my-org"}, deps)
+
+	assert.Contains(t, ui.Outputs[0], "Getting usage for org")
+	assert.Contains(t, ui.Outputs[0], "my-org")
+	assert.Contains(t, ui.Outputs[0], "my-user")
+	assert.Contains(t, ui.Outputs[1], "OK")
+
+	assert.Equal(t, deps.quotaRepo.FindByGuidGuid, "my-quota-guid")
+
+	assert.Contains(t, ui.Outputs[3], "space")
+	assert.Contains(t, ui.Outputs[3], "memory")
+	assert.Contains(t, ui.Outputs[3], "service instances")
+	assert.Equal(t, cleanFields(ui.Outputs[4]), []string{"dev", "512M", "1", "1", "1"})
+	assert.Equal(t, cleanFields(ui.Outputs[5]), []string{"prod", "3.5G", "5", "1", "2"})
+	assert.Equal(t, cleanFields(ui.Outputs[6]), []string{"total", "4G", "6", "2", "3"})
+	assert.Equal(t, cleanFields(ui.Outputs[7]), []string{"quota", "my-quota", "10G", "unlimited", "100", "20"})
+
+	assert.Contains(t, ui.Outputs[8], "web")
+	assert.Contains(t, ui.Outputs[8], "prod")
+	assert.Contains(t, ui.Outputs[8], "1 crashed")
+	assert.Equal(t, len(ui.Outputs), 9)
+}
+
+func callOrgUsage(t *testing.T, args []string, deps usageDeps) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	ctxt := testcmd.NewContext("org-usage", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+
+	config := &configuration.Configuration{
+		Space:        cf.Space{Name: "my-space"},
+		Organization: cf.Organization{Name: "my-org"},
+		AccessToken:  token,
+	}
+
+	cmd := NewOrgUsage(ui, config, deps.appSummaryRepo, deps.serviceSummaryRepo, deps.appRepo, deps.quotaRepo)
+	testcmd.RunCommand(cmd, ctxt, deps.reqFactory)
+	return
+}
+
+func cleanFields(line string) []string {
+	return strings.Fields(line)
+}
@@ -0,0 +1,101 @@
+package organization
+
+import (
+	"cf"
+	"cf/formatters"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"strconv"
+)
+
+// applyQuotaFlags overwrites the limits of quota with the ones given on the
+// command line, leaving the others untouched.
+func applyQuotaFlags(quota *cf.Quota, c *cli.Context) (err error) {
+	if c.String("m") != "" {
+		var megabytes int64
+		megabytes, err = quotaMemoryFromString(c.String("m"), false)
+		if err != nil {
+			return fmt.Errorf("Invalid total memory limit: %s", c.String("m"))
+		}
+		quota.MemoryLimit = uint64(megabytes)
+	}
+
+	if c.String("i") != "" {
+		quota.InstanceMemoryLimit, err = quotaMemoryFromString(c.String("i"), true)
+		if err != nil {
+			return fmt.Errorf("Invalid instance memory limit: %s", c.String("i"))
+		}
+	}
+
+	if c.String("r") != "" {
+		quota.RoutesLimit, err = quotaCountFromString(c.String("r"))
+		if err != nil {
+			return fmt.Errorf("Invalid total routes limit: %s", c.String("r"))
+		}
+	}
+
+	if c.String("s") != "" {
+		quota.ServicesLimit, err = quotaCountFromString(c.String("s"))
+		if err != nil {
+			return fmt.Errorf("Invalid total service instances limit: %s", c.String("s"))
+		}
+	}
+
+	if c.String("a") != "" {
+		quota.AppInstanceLimit, err = quotaCountFromString(c.String("a"))
+		if err != nil {
+			return fmt.Errorf("Invalid app instance limit: %s", c.String("a"))
+		}
+	}
+
+	if c.Bool("allow-paid-service-plans") {
+		quota.NonBasicServicesAllowed = true
+	}
+	if c.Bool("disallow-paid-service-plans") {
+		quota.NonBasicServicesAllowed = false
+	}
+	return
+}
+
+func quotaMemoryFromString(value string, allowUnlimited bool) (megabytes int64, err error) {
+	if allowUnlimited && value == strconv.Itoa(cf.UnlimitedQuota) {
+		megabytes = cf.UnlimitedQuota
+		return
+	}
+
+	bytes, err := formatters.BytesFromString(value)
+	if err != nil {
+		return
+	}
+	megabytes = int64(bytes / formatters.MEGABYTE)
+	return
+}
+
+func quotaCountFromString(value string) (count int, err error) {
+	count, err = strconv.Atoi(value)
+	if err == nil && count < cf.UnlimitedQuota {
+		err = fmt.Errorf("%d is not a valid limit", count)
+	}
+	return
+}
+
+func formatQuotaMemory(megabytes int64) string {
+	if megabytes == cf.UnlimitedQuota {
+		return "unlimited"
+	}
+	return formatters.ByteSize(uint64(megabytes) * formatters.MEGABYTE)
+}
+
+func formatQuotaCount(count int) string {
+	if count == cf.UnlimitedQuota {
+		return "unlimited"
+	}
+	return strconv.Itoa(count)
+}
+
+func formatPaidServicePlans(allowed bool) string {
+	if allowed {
+		return "allowed"
+	}
+	return "disallowed"
+}
@@ -60,8 +60,8 @@ func TestSetQuota(t *testing.T) {
 	assert.Contains(t, ui.Outputs[0], "my-org")
 	assert.Contains(t, ui.Outputs[0], "my-user")
 
-	assert.Equal(t, quotaRepo.UpdateOrg, org)
-	assert.Equal(t, quotaRepo.UpdateQuota, quota)
+	assert.Equal(t, quotaRepo.AssignQuotaToOrgOrg, org)
+	assert.Equal(t, quotaRepo.AssignQuotaToOrgQuota, quota)
 
 	assert.Contains(t, ui.Outputs[1], "OK")
 }
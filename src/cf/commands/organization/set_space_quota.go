@@ -0,0 +1,66 @@
+package organization
+
+import (
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type SetSpaceQuota struct {
+	ui             terminal.UI
+	config         *configuration.Configuration
+	spaceQuotaRepo api.SpaceQuotaRepository
+	spaceReq       requirements.SpaceRequirement
+}
+
+func NewSetSpaceQuota(ui terminal.UI, config *configuration.Configuration, spaceQuotaRepo api.SpaceQuotaRepository) (cmd *SetSpaceQuota) {
+	cmd = new(SetSpaceQuota)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.spaceQuotaRepo = spaceQuotaRepo
+	return
+}
+
+func (cmd *SetSpaceQuota) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 2 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "set-space-quota")
+		return
+	}
+
+	cmd.spaceReq = reqFactory.NewSpaceRequirement(c.Args()[0])
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedOrgRequirement(),
+		cmd.spaceReq,
+	}
+	return
+}
+
+func (cmd *SetSpaceQuota) Run(c *cli.Context) {
+	space := cmd.spaceReq.GetSpace()
+	quota, apiResponse := cmd.spaceQuotaRepo.FindByName(c.Args()[1])
+
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Say("Setting space quota %s to space %s as %s...",
+		terminal.EntityNameColor(quota.Name),
+		terminal.EntityNameColor(space.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	apiResponse = cmd.spaceQuotaRepo.AssignQuotaToSpace(space, quota)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Ok()
+}
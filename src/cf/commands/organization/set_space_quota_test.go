@@ -0,0 +1,97 @@
+package organization_test
+
+import (
+	"cf"
+	. "cf/commands/organization"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestSetSpaceQuotaFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{}
+	spaceQuotaRepo := &testapi.FakeSpaceQuotaRepository{}
+
+	ui := callSetSpaceQuota(t, []string{}, reqFactory, spaceQuotaRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callSetSpaceQuota(t, []string{"my-space"}, reqFactory, spaceQuotaRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callSetSpaceQuota(t, []string{"my-space", "my-space-quota"}, reqFactory, spaceQuotaRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestSetSpaceQuotaRequirements(t *testing.T) {
+	spaceQuotaRepo := &testapi.FakeSpaceQuotaRepository{}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedOrgSuccess: true}
+	callSetSpaceQuota(t, []string{"my-space", "my-space-quota"}, reqFactory, spaceQuotaRepo)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.SpaceName, "my-space")
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedOrgSuccess: false}
+	callSetSpaceQuota(t, []string{"my-space", "my-space-quota"}, reqFactory, spaceQuotaRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: false, TargetedOrgSuccess: true}
+	callSetSpaceQuota(t, []string{"my-space", "my-space-quota"}, reqFactory, spaceQuotaRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestSetSpaceQuota(t *testing.T) {
+	space := cf.Space{Name: "my-space", Guid: "my-space-guid"}
+	quota := cf.SpaceQuota{Quota: cf.Quota{Name: "my-found-space-quota", Guid: "my-space-quota-guid"}}
+	spaceQuotaRepo := &testapi.FakeSpaceQuotaRepository{FindByNameQuota: quota}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedOrgSuccess: true, Space: space}
+
+	ui := callSetSpaceQuota(t, []string{"my-space", "my-space-quota"}, reqFactory, spaceQuotaRepo)
+
+	assert.Equal(t, spaceQuotaRepo.FindByNameName, "my-space-quota")
+
+	assert.Contains(t, ui.Outputs[0], "Setting space quota")
+	assert.Contains(t, ui.Outputs[0], "my-found-space-quota")
+	assert.Contains(t, ui.Outputs[0], "my-space")
+	assert.Contains(t, ui.Outputs[0], "my-user")
+
+	assert.Equal(t, spaceQuotaRepo.AssignQuotaToSpaceSpace, space)
+	assert.Equal(t, spaceQuotaRepo.AssignQuotaToSpaceQuota, quota)
+
+	assert.Contains(t, ui.Outputs[1], "OK")
+}
+
+func TestSetSpaceQuotaWhenQuotaIsNotFound(t *testing.T) {
+	spaceQuotaRepo := &testapi.FakeSpaceQuotaRepository{FindByNameNotFound: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedOrgSuccess: true}
+
+	ui := callSetSpaceQuota(t, []string{"my-space", "my-space-quota"}, reqFactory, spaceQuotaRepo)
+
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "not found")
+	assert.Equal(t, spaceQuotaRepo.AssignQuotaToSpaceQuota, cf.SpaceQuota{})
+}
+
+func callSetSpaceQuota(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, repo *testapi.FakeSpaceQuotaRepository) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{}
+	ctxt := testcmd.NewContext("set-space-quota", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+
+	config := &configuration.Configuration{
+		Space:        cf.Space{Name: "my-space"},
+		Organization: cf.Organization{Name: "my-org", Guid: "my-org-guid"},
+		AccessToken:  token,
+	}
+
+	cmd := NewSetSpaceQuota(ui, config, repo)
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
@@ -0,0 +1,60 @@
+package organization
+
+import (
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type ShowQuota struct {
+	ui        terminal.UI
+	config    *configuration.Configuration
+	quotaRepo api.QuotaRepository
+}
+
+func NewShowQuota(ui terminal.UI, config *configuration.Configuration, quotaRepo api.QuotaRepository) (cmd *ShowQuota) {
+	cmd = new(ShowQuota)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.quotaRepo = quotaRepo
+	return
+}
+
+func (cmd *ShowQuota) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "quota")
+		return
+	}
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+	}
+	return
+}
+
+func (cmd *ShowQuota) Run(c *cli.Context) {
+	quotaName := c.Args()[0]
+	cmd.ui.Say("Getting info for quota %s as %s...",
+		terminal.EntityNameColor(quotaName),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	quota, apiResponse := cmd.quotaRepo.FindByName(quotaName)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("\n%s:", terminal.EntityNameColor(quota.Name))
+	cmd.ui.Say("  total memory limit: %s", terminal.EntityNameColor(formatQuotaMemory(int64(quota.MemoryLimit))))
+	cmd.ui.Say("  instance memory limit: %s", terminal.EntityNameColor(formatQuotaMemory(quota.InstanceMemoryLimit)))
+	cmd.ui.Say("  routes: %s", terminal.EntityNameColor(formatQuotaCount(quota.RoutesLimit)))
+	cmd.ui.Say("  service instances: %s", terminal.EntityNameColor(formatQuotaCount(quota.ServicesLimit)))
+	cmd.ui.Say("  paid service plans: %s", terminal.EntityNameColor(formatPaidServicePlans(quota.NonBasicServicesAllowed)))
+	cmd.ui.Say("  app instance limit: %s", terminal.EntityNameColor(formatQuotaCount(quota.AppInstanceLimit)))
+}
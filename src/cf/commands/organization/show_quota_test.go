@@ -0,0 +1,102 @@
+package organization_test
+
+import (
+	"cf"
+	. "cf/commands/organization"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestShowQuotaFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{}
+	quotaRepo := &testapi.FakeQuotaRepository{}
+
+	ui := callShowQuota(t, []string{}, reqFactory, quotaRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callShowQuota(t, []string{"my-quota"}, reqFactory, quotaRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestShowQuotaRequirements(t *testing.T) {
+	quotaRepo := &testapi.FakeQuotaRepository{}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	callShowQuota(t, []string{"my-quota"}, reqFactory, quotaRepo)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: false}
+	callShowQuota(t, []string{"my-quota"}, reqFactory, quotaRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestShowQuota(t *testing.T) {
+	quota := cf.Quota{
+		Name:                    "my-quota",
+		MemoryLimit:             10240,
+		InstanceMemoryLimit:     cf.UnlimitedQuota,
+		RoutesLimit:             100,
+		ServicesLimit:           20,
+		NonBasicServicesAllowed: true,
+		AppInstanceLimit:        50,
+	}
+	quotaRepo := &testapi.FakeQuotaRepository{FindByNameQuota: quota}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callShowQuota(t, []string{"my-quota"}, reqFactory, quotaRepo)
+
+	assert.Equal(t, quotaRepo.FindByNameName, "my-quota")
+	assert.Contains(t, ui.Outputs[0], "Getting info for quota")
+	assert.Contains(t, ui.Outputs[0], "my-quota")
+	assert.Contains(t, ui.Outputs[0], "my-user")
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[2], "my-quota")
+	assert.Contains(t, ui.Outputs[3], "total memory limit")
+	assert.Contains(t, ui.Outputs[3], "10G")
+	assert.Contains(t, ui.Outputs[4], "instance memory limit")
+	assert.Contains(t, ui.Outputs[4], "unlimited")
+	assert.Contains(t, ui.Outputs[5], "routes")
+	assert.Contains(t, ui.Outputs[5], "100")
+	assert.Contains(t, ui.Outputs[6], "service instances")
+	assert.Contains(t, ui.Outputs[6], "20")
+	assert.Contains(t, ui.Outputs[7], "paid service plans")
+	assert.Contains(t, ui.Outputs[7], "allowed")
+	assert.Contains(t, ui.Outputs[8], "app instance limit")
+	assert.Contains(t, ui.Outputs[8], "50")
+}
+
+func TestShowQuotaWhenQuotaIsNotFound(t *testing.T) {
+	quotaRepo := &testapi.FakeQuotaRepository{FindByNameNotFound: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callShowQuota(t, []string{"my-quota"}, reqFactory, quotaRepo)
+
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "not found")
+}
+
+func callShowQuota(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, repo *testapi.FakeQuotaRepository) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{}
+	ctxt := testcmd.NewContext("quota", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+
+	config := &configuration.Configuration{
+		Space:        cf.Space{Name: "my-space"},
+		Organization: cf.Organization{Name: "my-org", Guid: "my-org-guid"},
+		AccessToken:  token,
+	}
+
+	cmd := NewShowQuota(ui, config, repo)
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
@@ -0,0 +1,137 @@
+package organization
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type SpaceUsage struct {
+	ui                 terminal.UI
+	config             *configuration.Configuration
+	appSummaryRepo     api.AppSummaryRepository
+	serviceSummaryRepo api.ServiceSummaryRepository
+	appRepo            api.ApplicationRepository
+	orgRepo            api.OrganizationRepository
+	quotaRepo          api.QuotaRepository
+	spaceQuotaRepo     api.SpaceQuotaRepository
+	spaceReq           requirements.SpaceRequirement
+}
+
+func NewSpaceUsage(ui terminal.UI, config *configuration.Configuration, appSummaryRepo api.AppSummaryRepository,
+	serviceSummaryRepo api.ServiceSummaryRepository, appRepo api.ApplicationRepository, orgRepo api.OrganizationRepository,
+	quotaRepo api.QuotaRepository, spaceQuotaRepo api.SpaceQuotaRepository) (cmd *SpaceUsage) {
+
+	cmd = new(SpaceUsage)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.appSummaryRepo = appSummaryRepo
+	cmd.serviceSummaryRepo = serviceSummaryRepo
+	cmd.appRepo = appRepo
+	cmd.orgRepo = orgRepo
+	cmd.quotaRepo = quotaRepo
+	cmd.spaceQuotaRepo = spaceQuotaRepo
+	return
+}
+
+func (cmd *SpaceUsage) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "space-usage")
+		return
+	}
+
+	cmd.spaceReq = reqFactory.NewSpaceRequirement(c.Args()[0])
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedOrgRequirement(),
+		cmd.spaceReq,
+	}
+	return
+}
+
+func (cmd *SpaceUsage) Run(c *cli.Context) {
+	space := cmd.spaceReq.GetSpace()
+	cmd.ui.Say("Getting usage for space %s in org %s as %s...",
+		terminal.EntityNameColor(space.Name),
+		terminal.EntityNameColor(cmd.config.Organization.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	quota, found, ok := cmd.findQuota(space)
+	if !ok {
+		return
+	}
+
+	apps, apiResponse := cmd.appSummaryRepo.GetSummariesInSpace(space)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	services, apiResponse := cmd.serviceSummaryRepo.GetSummariesInSpace(space)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	unhealthyApps, apiResponse := findUnhealthyApps(cmd.appRepo, space.Name, apps)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	report := usageReportRecord{
+		Name:          space.Name,
+		Total:         spaceUsage("total", apps, services),
+		Breakdown:     []usageRecord{},
+		UnhealthyApps: []unhealthyAppRecord{},
+	}
+	if found {
+		report.Quota = newQuotaRecord(quota)
+	}
+	for _, app := range apps {
+		report.Breakdown = append(report.Breakdown, appUsage(app, services))
+	}
+	report.UnhealthyApps = append(report.UnhealthyApps, unhealthyApps...)
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	displayUsageReport(cmd.ui, "app", report)
+}
+
+// findQuota returns the space quota assigned to the space, falling back to
+// the quota of the targeted org.
+func (cmd *SpaceUsage) findQuota(space cf.Space) (quota cf.Quota, found bool, ok bool) {
+	if space.SpaceQuotaGuid != "" {
+		spaceQuota, apiResponse := cmd.spaceQuotaRepo.FindByGuid(space.SpaceQuotaGuid)
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+		return spaceQuota.Quota, true, true
+	}
+
+	org, apiResponse := cmd.orgRepo.FindByName(cmd.config.Organization.Name)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	if org.QuotaGuid == "" {
+		ok = true
+		return
+	}
+
+	quota, apiResponse = cmd.quotaRepo.FindByGuid(org.QuotaGuid)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+	return quota, true, true
+}
@@ -0,0 +1,98 @@
+package organization_test
+
+import (
+	"cf"
+	. "cf/commands/organization"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestSpaceUsageFailsWithUsage(t *testing.T) {
+	deps := newUsageDeps()
+
+	ui := callSpaceUsage(t, []string{}, deps)
+	assert.True(t, ui.FailedWithUsage)
+
+	deps = newUsageDeps()
+	ui = callSpaceUsage(t, []string{"prod"}, deps)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestSpaceUsageRequirements(t *testing.T) {
+	deps := newUsageDeps()
+	callSpaceUsage(t, []string{"prod"}, deps)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, deps.reqFactory.SpaceName, "prod")
+
+	deps = newUsageDeps()
+	deps.reqFactory.TargetedOrgSuccess = false
+	callSpaceUsage(t, []string{"prod"}, deps)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	deps = newUsageDeps()
+	deps.reqFactory.LoginSuccess = false
+	callSpaceUsage(t, []string{"prod"}, deps)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestSpaceUsageAgainstSpaceQuota(t *testing.T) {
+	deps := newUsageDeps()
+	ui := callSpaceUsage(t, []string{"prod"}, deps)
+
+	assert.Contains(t, ui.Outputs[0], "Getting usage for space")
+	assert.Contains(t, ui.Outputs[0], "prod")
+	assert.Contains(t, ui.Outputs[0], "my-org")
+	assert.Contains(t, ui.Outputs[0], "my-user")
+	assert.Contains(t, ui.Outputs[1], "OK")
+
+	assert.Equal(t, deps.spaceQuotaRepo.FindByGuidGuid, "prod-quota-guid")
+	assert.Equal(t, deps.orgRepo.FindByNameName, "")
+
+	assert.Contains(t, ui.Outputs[3], "app")
+	assert.Equal(t, cleanFields(ui.Outputs[4]), []string{"web", "3G", "3", "1", "2"})
+	assert.Equal(t, cleanFields(ui.Outputs[5]), []string{"worker", "512M", "2", "1", "1"})
+	assert.Equal(t, cleanFields(ui.Outputs[6]), []string{"total", "3.5G", "5", "1", "2"})
+	assert.Equal(t, cleanFields(ui.Outputs[7]), []string{"quota", "prod-quota", "4G", "8", "10", "5"})
+
+	assert.Contains(t, ui.Outputs[8], "web")
+	assert.Contains(t, ui.Outputs[8], "1 crashed")
+}
+
+func TestSpaceUsageFallsBackToOrgQuota(t *testing.T) {
+	deps := newUsageDeps()
+	deps.reqFactory.Space = cf.Space{Name: "dev", Guid: "dev-guid"}
+	ui := callSpaceUsage(t, []string{"dev"}, deps)
+
+	assert.Equal(t, deps.orgRepo.FindByNameName, "my-org")
+	assert.Equal(t, deps.quotaRepo.FindByGuidGuid, "my-quota-guid")
+
+	assert.Equal(t, cleanFields(ui.Outputs[4]), []string{"dev-app", "512M", "1", "1", "1"})
+	assert.Equal(t, cleanFields(ui.Outputs[5]), []string{"stopped-app", "0", "0", "0", "0"})
+	assert.Equal(t, cleanFields(ui.Outputs[6]), []string{"total", "512M", "1", "1", "1"})
+	assert.Equal(t, cleanFields(ui.Outputs[7]), []string{"quota", "my-quota", "10G", "unlimited", "100", "20"})
+	assert.Equal(t, len(ui.Outputs), 8)
+}
+
+func callSpaceUsage(t *testing.T, args []string, deps usageDeps) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	ctxt := testcmd.NewContext("space-usage", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+
+	config := &configuration.Configuration{
+		Space:        cf.Space{Name: "my-space"},
+		Organization: cf.Organization{Name: "my-org", Guid: "my-org-guid"},
+		AccessToken:  token,
+	}
+
+	cmd := NewSpaceUsage(ui, config, deps.appSummaryRepo, deps.serviceSummaryRepo, deps.appRepo, deps.orgRepo, deps.quotaRepo, deps.spaceQuotaRepo)
+	testcmd.RunCommand(cmd, ctxt, deps.reqFactory)
+	return
+}
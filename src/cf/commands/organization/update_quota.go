@@ -0,0 +1,74 @@
+package organization
+
+import (
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type UpdateQuota struct {
+	ui        terminal.UI
+	config    *configuration.Configuration
+	quotaRepo api.QuotaRepository
+}
+
+func NewUpdateQuota(ui terminal.UI, config *configuration.Configuration, quotaRepo api.QuotaRepository) (cmd *UpdateQuota) {
+	cmd = new(UpdateQuota)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.quotaRepo = quotaRepo
+	return
+}
+
+func (cmd *UpdateQuota) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "update-quota")
+		return
+	}
+
+	if c.Bool("allow-paid-service-plans") && c.Bool("disallow-paid-service-plans") {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "update-quota")
+		return
+	}
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+	}
+	return
+}
+
+func (cmd *UpdateQuota) Run(c *cli.Context) {
+	quota, apiResponse := cmd.quotaRepo.FindByName(c.Args()[0])
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	err := applyQuotaFlags(&quota, c)
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	if c.String("n") != "" {
+		quota.Name = c.String("n")
+	}
+
+	cmd.ui.Say("Updating quota %s as %s...",
+		terminal.EntityNameColor(c.Args()[0]),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	apiResponse = cmd.quotaRepo.Update(quota)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Ok()
+}
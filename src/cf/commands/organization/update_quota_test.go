@@ -0,0 +1,106 @@
+package organization_test
+
+import (
+	"cf"
+	. "cf/commands/organization"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestUpdateQuotaFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{}
+	quotaRepo := &testapi.FakeQuotaRepository{}
+
+	ui := callUpdateQuota(t, []string{}, reqFactory, quotaRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callUpdateQuota(t, []string{"--allow-paid-service-plans", "--disallow-paid-service-plans", "my-quota"}, reqFactory, quotaRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callUpdateQuota(t, []string{"my-quota"}, reqFactory, quotaRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestUpdateQuotaRequirements(t *testing.T) {
+	quotaRepo := &testapi.FakeQuotaRepository{}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	callUpdateQuota(t, []string{"my-quota"}, reqFactory, quotaRepo)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: false}
+	callUpdateQuota(t, []string{"my-quota"}, reqFactory, quotaRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestUpdateQuotaOnlyChangesTheGivenLimits(t *testing.T) {
+	quota := cf.Quota{
+		Guid:                    "my-quota-guid",
+		Name:                    "my-quota",
+		MemoryLimit:             1024,
+		InstanceMemoryLimit:     256,
+		RoutesLimit:             10,
+		ServicesLimit:           5,
+		NonBasicServicesAllowed: true,
+		AppInstanceLimit:        cf.UnlimitedQuota,
+	}
+	quotaRepo := &testapi.FakeQuotaRepository{FindByNameQuota: quota}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	args := []string{"-n", "my-new-quota", "-i", "-1", "-s", "50", "--disallow-paid-service-plans", "my-quota"}
+	ui := callUpdateQuota(t, args, reqFactory, quotaRepo)
+
+	assert.Equal(t, quotaRepo.FindByNameName, "my-quota")
+	assert.Contains(t, ui.Outputs[0], "Updating quota")
+	assert.Contains(t, ui.Outputs[0], "my-quota")
+	assert.Contains(t, ui.Outputs[0], "my-user")
+	assert.Contains(t, ui.Outputs[1], "OK")
+
+	assert.Equal(t, quotaRepo.UpdateQuota, cf.Quota{
+		Guid:                    "my-quota-guid",
+		Name:                    "my-new-quota",
+		MemoryLimit:             1024,
+		InstanceMemoryLimit:     cf.UnlimitedQuota,
+		RoutesLimit:             10,
+		ServicesLimit:           50,
+		NonBasicServicesAllowed: false,
+		AppInstanceLimit:        cf.UnlimitedQuota,
+	})
+}
+
+func TestUpdateQuotaWhenQuotaIsNotFound(t *testing.T) {
+	quotaRepo := &testapi.FakeQuotaRepository{FindByNameNotFound: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+
+	ui := callUpdateQuota(t, []string{"-m", "1G", "my-quota"}, reqFactory, quotaRepo)
+
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "not found")
+	assert.Equal(t, quotaRepo.UpdateQuota, cf.Quota{})
+}
+
+func callUpdateQuota(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, repo *testapi.FakeQuotaRepository) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{}
+	ctxt := testcmd.NewContext("update-quota", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+
+	config := &configuration.Configuration{
+		Space:        cf.Space{Name: "my-space"},
+		Organization: cf.Organization{Name: "my-org", Guid: "my-org-guid"},
+		AccessToken:  token,
+	}
+
+	cmd := NewUpdateQuota(ui, config, repo)
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
@@ -0,0 +1,149 @@
+package organization
+
+import (
+	"cf"
+	"cf/api"
+	"cf/formatters"
+	"cf/net"
+	"cf/terminal"
+	"strconv"
+)
+
+type usageRecord struct {
+	Name             string `json:"name" yaml:"name"`
+	Memory           uint64 `json:"memory" yaml:"memory"`
+	Instances        int    `json:"instances" yaml:"instances"`
+	Routes           int    `json:"routes" yaml:"routes"`
+	ServiceInstances int    `json:"service_instances" yaml:"service_instances"`
+}
+
+type unhealthyAppRecord struct {
+	Name              string `json:"name" yaml:"name"`
+	Space             string `json:"space" yaml:"space"`
+	CrashedInstances  int    `json:"crashed_instances" yaml:"crashed_instances"`
+	FlappingInstances int    `json:"flapping_instances" yaml:"flapping_instances"`
+}
+
+type usageReportRecord struct {
+	Name          string               `json:"name" yaml:"name"`
+	Quota         *quotaRecord         `json:"quota" yaml:"quota"`
+	Total         usageRecord          `json:"total" yaml:"total"`
+	Breakdown     []usageRecord        `json:"breakdown" yaml:"breakdown"`
+	UnhealthyApps []unhealthyAppRecord `json:"unhealthy_apps" yaml:"unhealthy_apps"`
+}
+
+// appUsage counts memory and instances of started apps only, the same way the
+// cloud controller enforces quotas.
+func appUsage(app cf.Application, services []cf.ServiceInstance) (usage usageRecord) {
+	usage.Name = app.Name
+	if app.State == "started" {
+		usage.Instances = app.Instances
+		usage.Memory = uint64(app.Instances) * app.Memory
+	}
+	usage.Routes = len(app.Routes)
+
+	for _, instance := range services {
+		for _, appName := range instance.ApplicationNames {
+			if appName == app.Name {
+				usage.ServiceInstances++
+			}
+		}
+	}
+	return
+}
+
+// spaceUsage adds up the usage of every app in the space. Routes shared by
+// several apps are only counted once.
+func spaceUsage(name string, apps []cf.Application, services []cf.ServiceInstance) (usage usageRecord) {
+	usage.Name = name
+	usage.ServiceInstances = len(services)
+
+	routeGuids := map[string]bool{}
+	for _, app := range apps {
+		usageOfApp := appUsage(app, services)
+		usage.Memory += usageOfApp.Memory
+		usage.Instances += usageOfApp.Instances
+
+		for _, route := range app.Routes {
+			routeGuids[route.Guid] = true
+		}
+	}
+	usage.Routes = len(routeGuids)
+	return
+}
+
+func addUsage(total *usageRecord, usage usageRecord) {
+	total.Memory += usage.Memory
+	total.Instances += usage.Instances
+	total.Routes += usage.Routes
+	total.ServiceInstances += usage.ServiceInstances
+}
+
+// findUnhealthyApps looks up the instances of started apps that are not fully
+// running, and returns the ones with crashed or flapping instances.
+func findUnhealthyApps(appRepo api.ApplicationRepository, spaceName string, apps []cf.Application) (unhealthyApps []unhealthyAppRecord, apiResponse net.ApiResponse) {
+	for _, app := range apps {
+		if app.State != "started" || app.RunningInstances >= app.Instances {
+			continue
+		}
+
+		var instances []cf.ApplicationInstance
+		instances, apiResponse = appRepo.GetInstances(app)
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+
+		record := unhealthyAppRecord{Name: app.Name, Space: spaceName}
+		for _, instance := range instances {
+			switch instance.State {
+			case cf.InstanceCrashed:
+				record.CrashedInstances++
+			case cf.InstanceFlapping:
+				record.FlappingInstances++
+			}
+		}
+
+		if record.CrashedInstances > 0 || record.FlappingInstances > 0 {
+			unhealthyApps = append(unhealthyApps, record)
+		}
+	}
+	return
+}
+
+func displayUsageReport(ui terminal.UI, nameHeader string, report usageReportRecord) {
+	table := [][]string{
+		[]string{nameHeader, "memory", "instances", "routes", "service instances"},
+	}
+
+	for _, usage := range report.Breakdown {
+		table = append(table, usageRow(usage))
+	}
+	table = append(table, usageRow(report.Total))
+
+	if report.Quota != nil {
+		table = append(table, []string{
+			"quota " + report.Quota.Name,
+			formatQuotaMemory(int64(report.Quota.MemoryLimit)),
+			formatQuotaCount(report.Quota.AppInstanceLimit),
+			formatQuotaCount(report.Quota.RoutesLimit),
+			formatQuotaCount(report.Quota.ServicesLimit),
+		})
+	}
+
+	ui.DisplayRecords(table, report)
+
+	for _, app := range report.UnhealthyApps {
+		ui.Warn("App %s in space %s has %d crashed and %d flapping instances",
+			app.Name, app.Space, app.CrashedInstances, app.FlappingInstances)
+	}
+}
+
+func usageRow(usage usageRecord) []string {
+	return []string{
+		usage.Name,
+		formatters.ByteSize(usage.Memory * formatters.MEGABYTE),
+		strconv.Itoa(usage.Instances),
+		strconv.Itoa(usage.Routes),
+		strconv.Itoa(usage.ServiceInstances),
+	}
+}
@@ -0,0 +1,73 @@
+package plugin
+
+import (
+	"cf/plugin"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+	"path/filepath"
+)
+
+type InstallPlugin struct {
+	ui               terminal.UI
+	pluginRepo       plugin.PluginRepository
+	isBuiltInCommand func(name string) bool
+}
+
+func NewInstallPlugin(ui terminal.UI, pluginRepo plugin.PluginRepository, isBuiltInCommand func(name string) bool) (cmd InstallPlugin) {
+	cmd.ui = ui
+	cmd.pluginRepo = pluginRepo
+	cmd.isBuiltInCommand = isBuiltInCommand
+	return
+}
+
+func (cmd InstallPlugin) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "install-plugin")
+	}
+	return
+}
+
+func (cmd InstallPlugin) Run(c *cli.Context) {
+	executable, err := filepath.Abs(c.Args()[0])
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	cmd.ui.Say("Installing plugin %s...", terminal.EntityNameColor(executable))
+
+	newPlugin, err := cmd.pluginRepo.ReadMetadata(executable)
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	for _, command := range newPlugin.Commands {
+		if cmd.isBuiltInCommand(command.Name) || c.App.Command(command.Name) != nil {
+			cmd.ui.Failed("Command %s in plugin %s is already a cf command.", command.Name, newPlugin.Name)
+			return
+		}
+
+		existingPlugin, found, err := cmd.pluginRepo.FindByCommandName(command.Name)
+		if err != nil {
+			cmd.ui.Failed(err.Error())
+			return
+		}
+		if found {
+			cmd.ui.Failed("Command %s in plugin %s is already provided by plugin %s.", command.Name, newPlugin.Name, existingPlugin.Name)
+			return
+		}
+	}
+
+	err = cmd.pluginRepo.Install(newPlugin)
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("Plugin %s %s installed.", terminal.EntityNameColor(newPlugin.Name), newPlugin.Version)
+}
@@ -0,0 +1,119 @@
+package plugin_test
+
+import (
+	. "cf/commands/plugin"
+	"cf/plugin"
+	"errors"
+	"github.com/codegangsta/cli"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	testcmd "testhelpers/commands"
+	testplugin "testhelpers/plugin"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+var helloPlugin = plugin.Plugin{
+	Name:    "hello",
+	Version: "1.0.0",
+	Commands: []plugin.Command{
+		plugin.Command{Name: "hello", Description: "Say hello"},
+		plugin.Command{Name: "goodbye", Description: "Say goodbye"},
+	},
+}
+
+func TestInstallPluginFailsWithUsage(t *testing.T) {
+	pluginRepo := &testplugin.FakePluginRepository{}
+
+	ui := callInstallPlugin([]string{}, pluginRepo)
+	assert.True(t, ui.FailedWithUsage)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	ui = callInstallPlugin([]string{"path/to/plugin"}, pluginRepo)
+	assert.False(t, ui.FailedWithUsage)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestInstallPlugin(t *testing.T) {
+	pluginRepo := &testplugin.FakePluginRepository{ReadMetadataPlugin: helloPlugin}
+
+	ui := callInstallPlugin([]string{"path/to/hello"}, pluginRepo)
+
+	expectedPath, _ := filepath.Abs("path/to/hello")
+	assert.Equal(t, pluginRepo.ReadMetadataExecutable, expectedPath)
+	assert.Equal(t, pluginRepo.InstalledPlugin.Name, "hello")
+
+	assert.Contains(t, ui.Outputs[0], "Installing plugin")
+	assert.Contains(t, ui.Outputs[0], expectedPath)
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[2], "hello")
+	assert.Contains(t, ui.Outputs[2], "1.0.0")
+}
+
+func TestInstallPluginWhenMetadataIsInvalid(t *testing.T) {
+	pluginRepo := &testplugin.FakePluginRepository{ReadMetadataErr: errors.New("Invalid plugin metadata")}
+
+	ui := callInstallPlugin([]string{"path/to/hello"}, pluginRepo)
+
+	assert.Equal(t, pluginRepo.InstalledPlugin.Name, "")
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "Invalid plugin metadata")
+}
+
+func TestInstallPluginWhenCommandIsBuiltIn(t *testing.T) {
+	pluginRepo := &testplugin.FakePluginRepository{ReadMetadataPlugin: helloPlugin}
+
+	ui := new(testterm.FakeUI)
+	isBuiltInCommand := func(name string) bool { return name == "goodbye" }
+	cmd := NewInstallPlugin(ui, pluginRepo, isBuiltInCommand)
+	testcmd.RunCommand(cmd, testcmd.NewContext("install-plugin", []string{"path/to/hello"}), &testreq.FakeReqFactory{})
+
+	assert.Equal(t, pluginRepo.InstalledPlugin.Name, "")
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "goodbye")
+	assert.Contains(t, ui.Outputs[2], "already a cf command")
+}
+
+func TestInstallPluginWhenCommandBelongsToAnotherPlugin(t *testing.T) {
+	otherPlugin := plugin.Plugin{
+		Name:     "farewell",
+		Commands: []plugin.Command{plugin.Command{Name: "goodbye"}},
+	}
+	pluginRepo := &testplugin.FakePluginRepository{
+		Plugins:            []plugin.Plugin{otherPlugin},
+		ReadMetadataPlugin: helloPlugin,
+	}
+
+	ui := callInstallPlugin([]string{"path/to/hello"}, pluginRepo)
+
+	assert.Equal(t, pluginRepo.InstalledPlugin.Name, "")
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "goodbye")
+	assert.Contains(t, ui.Outputs[2], "already provided by plugin farewell")
+}
+
+func TestInstallPluginWhenCommandIsABuiltInShortName(t *testing.T) {
+	pluginRepo := &testplugin.FakePluginRepository{ReadMetadataPlugin: helloPlugin}
+
+	ui := new(testterm.FakeUI)
+	isBuiltInCommand := func(name string) bool { return false }
+	cmd := NewInstallPlugin(ui, pluginRepo, isBuiltInCommand)
+	ctxt := testcmd.NewContext("install-plugin", []string{"path/to/hello"})
+	ctxt.App.Commands = []cli.Command{{Name: "farewell", ShortName: "goodbye"}}
+	testcmd.RunCommand(cmd, ctxt, &testreq.FakeReqFactory{})
+
+	assert.Equal(t, pluginRepo.InstalledPlugin.Name, "")
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "goodbye")
+	assert.Contains(t, ui.Outputs[2], "already a cf command")
+}
+
+func callInstallPlugin(args []string, pluginRepo plugin.PluginRepository) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	isBuiltInCommand := func(name string) bool { return false }
+	cmd := NewInstallPlugin(ui, pluginRepo, isBuiltInCommand)
+	ctxt := testcmd.NewContext("install-plugin", args)
+	testcmd.RunCommand(cmd, ctxt, &testreq.FakeReqFactory{})
+	return
+}
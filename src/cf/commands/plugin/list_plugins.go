@@ -0,0 +1,73 @@
+package plugin
+
+import (
+	"cf/plugin"
+	"cf/requirements"
+	"cf/terminal"
+	"github.com/codegangsta/cli"
+)
+
+type pluginRecord struct {
+	Name        string `json:"name" yaml:"name"`
+	Version     string `json:"version" yaml:"version"`
+	Command     string `json:"command" yaml:"command"`
+	Description string `json:"description" yaml:"description"`
+}
+
+type ListPlugins struct {
+	ui         terminal.UI
+	pluginRepo plugin.PluginRepository
+}
+
+func NewListPlugins(ui terminal.UI, pluginRepo plugin.PluginRepository) (cmd ListPlugins) {
+	cmd.ui = ui
+	cmd.pluginRepo = pluginRepo
+	return
+}
+
+func (cmd ListPlugins) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	return
+}
+
+func (cmd ListPlugins) Run(c *cli.Context) {
+	cmd.ui.Say("Getting installed plugins...")
+
+	plugins, err := cmd.pluginRepo.List()
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	records := []pluginRecord{}
+	if len(plugins) == 0 {
+		cmd.ui.Say("No plugins installed")
+		cmd.ui.DisplayRecords(nil, records)
+		return
+	}
+
+	table := [][]string{
+		[]string{"plugin", "version", "command", "description"},
+	}
+
+	for _, installedPlugin := range plugins {
+		for _, command := range installedPlugin.Commands {
+			table = append(table, []string{
+				installedPlugin.Name,
+				installedPlugin.Version,
+				command.Name,
+				command.Description,
+			})
+			records = append(records, pluginRecord{
+				Name:        installedPlugin.Name,
+				Version:     installedPlugin.Version,
+				Command:     command.Name,
+				Description: command.Description,
+			})
+		}
+	}
+
+	cmd.ui.DisplayRecords(table, records)
+}
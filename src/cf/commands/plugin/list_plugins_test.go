@@ -0,0 +1,45 @@
+package plugin_test
+
+import (
+	. "cf/commands/plugin"
+	"cf/plugin"
+	"github.com/stretchr/testify/assert"
+	testcmd "testhelpers/commands"
+	testplugin "testhelpers/plugin"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestListPlugins(t *testing.T) {
+	pluginRepo := &testplugin.FakePluginRepository{Plugins: []plugin.Plugin{helloPlugin}}
+
+	ui := callListPlugins(pluginRepo)
+
+	assert.Contains(t, ui.Outputs[0], "Getting installed plugins")
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[3], "plugin")
+	assert.Contains(t, ui.Outputs[3], "command")
+	assert.Contains(t, ui.Outputs[4], "hello")
+	assert.Contains(t, ui.Outputs[4], "1.0.0")
+	assert.Contains(t, ui.Outputs[4], "Say hello")
+	assert.Contains(t, ui.Outputs[5], "goodbye")
+	assert.Contains(t, ui.Outputs[5], "Say goodbye")
+}
+
+func TestListPluginsWhenNoneAreInstalled(t *testing.T) {
+	pluginRepo := &testplugin.FakePluginRepository{}
+
+	ui := callListPlugins(pluginRepo)
+
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[3], "No plugins installed")
+}
+
+func callListPlugins(pluginRepo plugin.PluginRepository) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	cmd := NewListPlugins(ui, pluginRepo)
+	ctxt := testcmd.NewContext("plugins", []string{})
+	testcmd.RunCommand(cmd, ctxt, &testreq.FakeReqFactory{})
+	return
+}
@@ -0,0 +1,46 @@
+package plugin
+
+import (
+	"cf/configuration"
+	"cf/plugin"
+	"cf/requirements"
+	"cf/terminal"
+	"github.com/codegangsta/cli"
+)
+
+type RunPlugin struct {
+	ui          terminal.UI
+	config      *configuration.Configuration
+	pluginRepo  plugin.PluginRepository
+	plugin      plugin.Plugin
+	commandName string
+}
+
+func NewRunPlugin(ui terminal.UI, config *configuration.Configuration, pluginRepo plugin.PluginRepository, installedPlugin plugin.Plugin, commandName string) (cmd RunPlugin) {
+	cmd.ui = ui
+	cmd.config = config
+	cmd.pluginRepo = pluginRepo
+	cmd.plugin = installedPlugin
+	cmd.commandName = commandName
+	return
+}
+
+// plugins check for themselves whether they need a target or a login
+func (cmd RunPlugin) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	return
+}
+
+// plugin commands are not parsed by the cli, so that the plugin gets all of
+// its args and flags as given, after the command name
+func (cmd RunPlugin) Run(c *cli.Context) {
+	args := c.Args()
+	if len(args) > 0 {
+		args = args[1:]
+	}
+
+	err := cmd.pluginRepo.Run(cmd.plugin, cmd.commandName, args, plugin.NewContext(cmd.config))
+	if err != nil {
+		cmd.ui.Failed("Plugin %s failed running %s.\n%s", cmd.plugin.Name, cmd.commandName, err.Error())
+		return
+	}
+}
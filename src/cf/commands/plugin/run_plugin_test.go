@@ -0,0 +1,59 @@
+package plugin_test
+
+import (
+	"cf"
+	. "cf/commands/plugin"
+	"cf/configuration"
+	"errors"
+	"flag"
+	"github.com/codegangsta/cli"
+	"github.com/stretchr/testify/assert"
+	testcmd "testhelpers/commands"
+	testplugin "testhelpers/plugin"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestRunPlugin(t *testing.T) {
+	config := &configuration.Configuration{
+		Target:       "https://api.example.com",
+		AccessToken:  "BEARER my_access_token",
+		Organization: cf.Organization{Name: "my-org", Guid: "my-org-guid"},
+		Space:        cf.Space{Name: "my-space", Guid: "my-space-guid"},
+	}
+	pluginRepo := &testplugin.FakePluginRepository{}
+
+	ui := new(testterm.FakeUI)
+	cmd := NewRunPlugin(ui, config, pluginRepo, helloPlugin, "goodbye")
+	testcmd.RunCommand(cmd, pluginContext("goodbye", "world", "--loud", "-n", "2"), &testreq.FakeReqFactory{})
+
+	assert.Empty(t, ui.Outputs)
+	assert.Equal(t, pluginRepo.RunPlugin.Name, "hello")
+	assert.Equal(t, pluginRepo.RunCommandName, "goodbye")
+	assert.Equal(t, pluginRepo.RunArgs, []string{"world", "--loud", "-n", "2"})
+	assert.Equal(t, pluginRepo.RunContext.Target, "https://api.example.com")
+	assert.Equal(t, pluginRepo.RunContext.AccessToken, "BEARER my_access_token")
+	assert.Equal(t, pluginRepo.RunContext.Organization.Guid, "my-org-guid")
+	assert.Equal(t, pluginRepo.RunContext.Space.Name, "my-space")
+}
+
+func TestRunPluginWhenPluginFails(t *testing.T) {
+	pluginRepo := &testplugin.FakePluginRepository{RunErr: errors.New("exit status 1")}
+
+	ui := new(testterm.FakeUI)
+	cmd := NewRunPlugin(ui, &configuration.Configuration{}, pluginRepo, helloPlugin, "hello")
+	testcmd.RunCommand(cmd, pluginContext("hello"), &testreq.FakeReqFactory{})
+
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "hello")
+	assert.Contains(t, ui.Outputs[1], "exit status 1")
+}
+
+// pluginContext is the context the app passes to a plugin command, with the
+// command name and its args unparsed
+func pluginContext(args ...string) *cli.Context {
+	globalSet := flag.NewFlagSet("cf", flag.ContinueOnError)
+	globalSet.Parse(args)
+	return cli.NewContext(cli.NewApp(), globalSet, globalSet)
+}
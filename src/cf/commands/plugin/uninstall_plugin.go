@@ -0,0 +1,42 @@
+package plugin
+
+import (
+	"cf/plugin"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type UninstallPlugin struct {
+	ui         terminal.UI
+	pluginRepo plugin.PluginRepository
+}
+
+func NewUninstallPlugin(ui terminal.UI, pluginRepo plugin.PluginRepository) (cmd UninstallPlugin) {
+	cmd.ui = ui
+	cmd.pluginRepo = pluginRepo
+	return
+}
+
+func (cmd UninstallPlugin) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "uninstall-plugin")
+	}
+	return
+}
+
+func (cmd UninstallPlugin) Run(c *cli.Context) {
+	name := c.Args()[0]
+
+	cmd.ui.Say("Uninstalling plugin %s...", terminal.EntityNameColor(name))
+
+	err := cmd.pluginRepo.Uninstall(name)
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	cmd.ui.Ok()
+}
@@ -0,0 +1,49 @@
+package plugin_test
+
+import (
+	. "cf/commands/plugin"
+	"cf/plugin"
+	"github.com/stretchr/testify/assert"
+	testcmd "testhelpers/commands"
+	testplugin "testhelpers/plugin"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestUninstallPluginFailsWithUsage(t *testing.T) {
+	pluginRepo := &testplugin.FakePluginRepository{}
+
+	ui := callUninstallPlugin([]string{}, pluginRepo)
+	assert.True(t, ui.FailedWithUsage)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestUninstallPlugin(t *testing.T) {
+	pluginRepo := &testplugin.FakePluginRepository{Plugins: []plugin.Plugin{helloPlugin}}
+
+	ui := callUninstallPlugin([]string{"hello"}, pluginRepo)
+
+	assert.Equal(t, pluginRepo.UninstalledName, "hello")
+	assert.Empty(t, pluginRepo.Plugins)
+	assert.Contains(t, ui.Outputs[0], "Uninstalling plugin")
+	assert.Contains(t, ui.Outputs[0], "hello")
+	assert.Contains(t, ui.Outputs[1], "OK")
+}
+
+func TestUninstallPluginWhenNotInstalled(t *testing.T) {
+	pluginRepo := &testplugin.FakePluginRepository{}
+
+	ui := callUninstallPlugin([]string{"hello"}, pluginRepo)
+
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "not installed")
+}
+
+func callUninstallPlugin(args []string, pluginRepo plugin.PluginRepository) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	cmd := NewUninstallPlugin(ui, pluginRepo)
+	ctxt := testcmd.NewContext("uninstall-plugin", args)
+	testcmd.RunCommand(cmd, ctxt, &testreq.FakeReqFactory{})
+	return
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type RenameProfile struct {
+	ui         terminal.UI
+	configRepo configuration.ConfigurationRepository
+}
+
+func NewRenameProfile(ui terminal.UI, configRepo configuration.ConfigurationRepository) (cmd RenameProfile) {
+	cmd.ui = ui
+	cmd.configRepo = configRepo
+	return
+}
+
+func (cmd RenameProfile) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 2 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "rename-profile")
+	}
+	return
+}
+
+func (cmd RenameProfile) Run(c *cli.Context) {
+	name := c.Args()[0]
+	newName := c.Args()[1]
+
+	cmd.ui.Say("Renaming profile %s to %s...",
+		terminal.EntityNameColor(name),
+		terminal.EntityNameColor(newName),
+	)
+
+	err := cmd.configRepo.RenameProfile(name, newName)
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	cmd.ui.Ok()
+}
@@ -0,0 +1,57 @@
+package commands_test
+
+import (
+	. "cf/commands"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestRenameProfileFailsWithUsage(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+
+	ui := callRenameProfile([]string{"prod"}, configRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callRenameProfile([]string{"prod", "production"}, configRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestRenameProfile(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	defer configRepo.Delete()
+
+	configRepo.SwitchProfile("prod")
+
+	ui := callRenameProfile([]string{"prod", "production"}, configRepo)
+
+	assert.Contains(t, ui.Outputs[0], "Renaming profile")
+	assert.Contains(t, ui.Outputs[0], "prod")
+	assert.Contains(t, ui.Outputs[0], "production")
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Equal(t, testconfig.SavedConfiguration.CurrentProfile, "production")
+}
+
+func TestRenameProfileWhenProfileDoesNotExist(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	defer configRepo.Delete()
+
+	ui := callRenameProfile([]string{"prod", "production"}, configRepo)
+
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "Profile prod does not exist")
+}
+
+func callRenameProfile(args []string, configRepo configuration.ConfigurationRepository) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	cmd := NewRenameProfile(ui, configRepo)
+	ctxt := testcmd.NewContext("rename-profile", args)
+	testcmd.RunCommand(cmd, ctxt, &testreq.FakeReqFactory{})
+	return
+}
@@ -9,6 +9,13 @@ import (
 	"strings"
 )
 
+type routeRecord struct {
+	Guid   string   `json:"guid" yaml:"guid"`
+	Host   string   `json:"host" yaml:"host"`
+	Domain string   `json:"domain" yaml:"domain"`
+	Apps   []string `json:"apps" yaml:"apps"`
+}
+
 type ListRoutes struct {
 	ui        terminal.UI
 	routeRepo api.RouteRepository
@@ -42,8 +49,11 @@ func (cmd ListRoutes) Run(c *cli.Context) {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	records := []routeRecord{}
+
 	if len(routes) == 0 {
 		cmd.ui.Say("No routes found")
+		cmd.ui.DisplayRecords(nil, records)
 		return
 	}
 
@@ -57,7 +67,19 @@ func (cmd ListRoutes) Run(c *cli.Context) {
 			route.Domain.Name,
 			strings.Join(route.AppNames, ", "),
 		})
+
+		appNames := route.AppNames
+		if appNames == nil {
+			appNames = []string{}
+		}
+
+		records = append(records, routeRecord{
+			Guid:   route.Guid,
+			Host:   route.Host,
+			Domain: route.Domain.Name,
+			Apps:   appNames,
+		})
 	}
 
-	cmd.ui.DisplayTable(table)
+	cmd.ui.DisplayRecords(table, records)
 }
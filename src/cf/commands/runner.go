@@ -2,6 +2,7 @@ package commands
 
 import (
 	"cf/requirements"
+	"cf/terminal"
 	"errors"
 	"fmt"
 	"github.com/codegangsta/cli"
@@ -18,11 +19,13 @@ type Runner interface {
 }
 
 type ConcreteRunner struct {
+	ui         terminal.UI
 	cmdFactory Factory
 	reqFactory requirements.Factory
 }
 
-func NewRunner(cmdFactory Factory, reqFactory requirements.Factory) (runner ConcreteRunner) {
+func NewRunner(ui terminal.UI, cmdFactory Factory, reqFactory requirements.Factory) (runner ConcreteRunner) {
+	runner.ui = ui
 	runner.cmdFactory = cmdFactory
 	runner.reqFactory = reqFactory
 	return
@@ -31,8 +34,23 @@ func NewRunner(cmdFactory Factory, reqFactory requirements.Factory) (runner Conc
 func (runner ConcreteRunner) RunCmdByName(cmdName string, c *cli.Context) (err error) {
 	cmd, err := runner.cmdFactory.GetByCmdName(cmdName)
 	if err != nil {
-		fmt.Printf("Error finding command %s\n", cmdName)
-		os.Exit(1)
+		aliasCommand, found := runner.cmdFactory.GetAlias(cmdName)
+		if !found {
+			fmt.Printf("Error finding command %s\n", cmdName)
+			os.Exit(1)
+			return
+		}
+
+		cmd, c, err = runner.commandForAlias(cmdName, aliasCommand, c)
+		if err != nil {
+			runner.ui.Failed("Error running alias %s: %s", cmdName, err.Error())
+			return
+		}
+	}
+
+	err = runner.ui.SetOutputFormat(c.GlobalString("output"))
+	if err != nil {
+		runner.ui.Failed(err.Error())
 		return
 	}
 
@@ -52,3 +70,15 @@ func (runner ConcreteRunner) RunCmdByName(cmdName string, c *cli.Context) (err e
 	cmd.Run(c)
 	return
 }
+
+// commandForAlias finds the command a user defined alias stands for, so that
+// its requirements are checked like those of the command itself.
+func (runner ConcreteRunner) commandForAlias(name, aliasCommand string, c *cli.Context) (cmd Command, ctxt *cli.Context, err error) {
+	cmdName, ctxt, err := expandAlias(name, aliasCommand, c)
+	if err != nil {
+		return
+	}
+
+	cmd, err = runner.cmdFactory.GetByCmdName(cmdName)
+	return
+}
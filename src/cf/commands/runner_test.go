@@ -3,23 +3,37 @@ package commands_test
 import (
 	. "cf/commands"
 	"cf/requirements"
+	"errors"
+	"flag"
 	"github.com/codegangsta/cli"
 	"github.com/stretchr/testify/assert"
 	testcmd "testhelpers/commands"
+	testterm "testhelpers/terminal"
 	"testing"
 )
 
 type TestCommandFactory struct {
 	Cmd     Command
 	CmdName string
+	Aliases map[string]string
 }
 
 func (f *TestCommandFactory) GetByCmdName(cmdName string) (cmd Command, err error) {
+	if _, found := f.Aliases[cmdName]; found {
+		err = errors.New("Command not found")
+		return
+	}
+
 	f.CmdName = cmdName
 	cmd = f.Cmd
 	return
 }
 
+func (f *TestCommandFactory) GetAlias(name string) (command string, found bool) {
+	command, found = f.Aliases[name]
+	return
+}
+
 type TestCommand struct {
 	Reqs       []requirements.Requirement
 	WasRunWith *cli.Context
@@ -59,7 +73,7 @@ func TestRun(t *testing.T) {
 	}
 
 	cmdFactory := &TestCommandFactory{Cmd: &cmd}
-	runner := NewRunner(cmdFactory, nil)
+	runner := NewRunner(&testterm.FakeUI{}, cmdFactory, nil)
 
 	ctxt := testcmd.NewContext("login", []string{})
 
@@ -75,3 +89,45 @@ func TestRun(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestRunExpandsAliasesBeforeCheckingRequirements(t *testing.T) {
+	req := TestRequirement{Passes: true}
+	cmd := TestCommand{Reqs: []requirements.Requirement{&req}}
+	cmdFactory := &TestCommandFactory{
+		Cmd:     &cmd,
+		Aliases: map[string]string{"deploy": `p -m 512M -c 'bundle exec rake' -i 2`},
+	}
+	runner := NewRunner(&testterm.FakeUI{}, cmdFactory, nil)
+
+	app := cli.NewApp()
+	app.Flags = []cli.Flag{
+		cli.StringFlag{Name: "output", Value: "table"},
+	}
+	app.Commands = []cli.Command{
+		{
+			Name:      "push",
+			ShortName: "p",
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "m"},
+				cli.StringFlag{Name: "c"},
+				cli.IntFlag{Name: "i", Value: 1},
+			},
+		},
+	}
+
+	globalSet := flag.NewFlagSet("cf", flag.ContinueOnError)
+	globalSet.String("output", "table", "")
+	globalSet.Parse([]string{"--output", "json", "deploy", "my-app", "-i", "3"})
+	ctxt := cli.NewContext(app, globalSet, globalSet)
+
+	err := runner.RunCmdByName("deploy", ctxt)
+	assert.NoError(t, err)
+
+	assert.Equal(t, cmdFactory.CmdName, "push")
+	assert.True(t, req.WasExecuted)
+	assert.Equal(t, cmd.WasRunWith.Args(), []string{"my-app"})
+	assert.Equal(t, cmd.WasRunWith.String("m"), "512M")
+	assert.Equal(t, cmd.WasRunWith.String("c"), "bundle exec rake")
+	assert.Equal(t, cmd.WasRunWith.Int("i"), 3)
+	assert.Equal(t, cmd.WasRunWith.GlobalString("output"), "json")
+}
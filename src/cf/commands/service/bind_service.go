@@ -1,6 +1,7 @@
 package service
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/requirements"
@@ -55,14 +56,14 @@ func (cmd *BindService) Run(c *cli.Context) {
 	)
 
 	apiResponse := cmd.serviceBindingRepo.Create(instance, app)
-	if apiResponse.IsNotSuccessful() && apiResponse.ErrorCode != "90003" {
+	if apiResponse.IsNotSuccessful() && apiResponse.ErrorCode != cf.APP_ALREADY_BOUND {
 		cmd.ui.Failed(apiResponse.Message)
 		return
 	}
 
 	cmd.ui.Ok()
 
-	if apiResponse.ErrorCode == "90003" {
+	if apiResponse.ErrorCode == cf.APP_ALREADY_BOUND {
 		cmd.ui.Warn("App %s is already bound to %s.", app.Name, instance.Name)
 		return
 	}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"cf"
+	"cf/api"
+	"cf/commands/application"
+	"cf/configuration"
+	"cf/manifest"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"strings"
+)
+
+type desiredBindings struct {
+	appNames []string
+	services map[string][]string
+}
+
+func (desired *desiredBindings) add(appName string, serviceNames []string) {
+	if _, found := desired.services[appName]; !found {
+		desired.appNames = append(desired.appNames, appName)
+		desired.services[appName] = []string{}
+	}
+
+	for _, serviceName := range serviceNames {
+		if !containsString(desired.services[appName], serviceName) {
+			desired.services[appName] = append(desired.services[appName], serviceName)
+		}
+	}
+}
+
+type bindingChange struct {
+	appName     string
+	serviceName string
+	bind        bool
+}
+
+type BindServices struct {
+	ui                 terminal.UI
+	config             *configuration.Configuration
+	serviceSummaryRepo api.ServiceSummaryRepository
+	serviceRepo        api.ServiceRepository
+	appRepo            api.ApplicationRepository
+	serviceBindingRepo api.ServiceBindingRepository
+	manifestRepo       manifest.ManifestRepository
+	restarter          application.ApplicationRestarter
+}
+
+func NewBindServices(ui terminal.UI, config *configuration.Configuration, serviceSummaryRepo api.ServiceSummaryRepository,
+	serviceRepo api.ServiceRepository, appRepo api.ApplicationRepository, serviceBindingRepo api.ServiceBindingRepository,
+	manifestRepo manifest.ManifestRepository, restarter application.ApplicationRestarter) (cmd *BindServices) {
+
+	cmd = new(BindServices)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.serviceSummaryRepo = serviceSummaryRepo
+	cmd.serviceRepo = serviceRepo
+	cmd.appRepo = appRepo
+	cmd.serviceBindingRepo = serviceBindingRepo
+	cmd.manifestRepo = manifestRepo
+	cmd.restarter = restarter
+	return
+}
+
+func (cmd *BindServices) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 0 || (c.String("f") == "" && len(c.StringSlice("b")) == 0) {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "bind-services")
+		return
+	}
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedSpaceRequirement(),
+	}
+	return
+}
+
+func (cmd *BindServices) Run(c *cli.Context) {
+	desired, err := cmd.desiredBindings(c)
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	cmd.ui.Say("Updating service bindings in org %s / space %s as %s...",
+		terminal.EntityNameColor(cmd.config.Organization.Name),
+		terminal.EntityNameColor(cmd.config.Space.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	instances, apiResponse := cmd.serviceSummaryRepo.GetSummariesInCurrentSpace()
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	changes := bindingChanges(desired, instances, c.Bool("prune"))
+	if len(changes) == 0 {
+		cmd.ui.Ok()
+		cmd.ui.Say("Service bindings are already up to date")
+		return
+	}
+
+	apps, serviceInstances, ok := cmd.findAppsAndInstances(changes)
+	if !ok {
+		return
+	}
+
+	affectedApps := []cf.Application{}
+	for _, change := range changes {
+		app := apps[change.appName]
+		instance := serviceInstances[change.serviceName]
+
+		if change.bind {
+			cmd.ui.Say("Binding service %s to app %s...", terminal.EntityNameColor(instance.Name), terminal.EntityNameColor(app.Name))
+
+			apiResponse = cmd.serviceBindingRepo.Create(instance, app)
+			if apiResponse.IsNotSuccessful() && apiResponse.ErrorCode != cf.APP_ALREADY_BOUND {
+				cmd.ui.Failed(apiResponse.Message)
+				return
+			}
+		} else {
+			cmd.ui.Say("Unbinding app %s from service %s...", terminal.EntityNameColor(app.Name), terminal.EntityNameColor(instance.Name))
+
+			_, apiResponse = cmd.serviceBindingRepo.Delete(instance, app)
+			if apiResponse.IsNotSuccessful() {
+				cmd.ui.Failed(apiResponse.Message)
+				return
+			}
+		}
+
+		if len(affectedApps) == 0 || affectedApps[len(affectedApps)-1].Name != app.Name {
+			affectedApps = append(affectedApps, app)
+		}
+	}
+
+	cmd.ui.Ok()
+
+	if !c.Bool("restart") {
+		cmd.ui.Say("TIP: Use '%s' or '%s restart' to ensure your env variable changes take effect", cf.Name()+" push", cf.Name())
+		return
+	}
+
+	for _, app := range affectedApps {
+		if app.State == "stopped" {
+			cmd.ui.Say("")
+			cmd.ui.Say("App %s is stopped, skipping restart", terminal.EntityNameColor(app.Name))
+			continue
+		}
+
+		cmd.ui.Say("")
+		cmd.restarter.ApplicationRestart(app)
+	}
+}
+
+func (cmd *BindServices) desiredBindings(c *cli.Context) (desired *desiredBindings, err error) {
+	desired = &desiredBindings{services: map[string][]string{}}
+
+	if c.String("f") != "" {
+		var appManifest *manifest.Manifest
+		appManifest, err = cmd.manifestRepo.ReadManifest(c.String("f"))
+		if err != nil {
+			err = fmt.Errorf("Error reading manifest file:\n%s", err.Error())
+			return
+		}
+
+		for _, app := range appManifest.Applications {
+			if app.Services == nil {
+				continue
+			}
+			desired.add(app.Name, app.Services)
+		}
+	}
+
+	for _, pair := range c.StringSlice("b") {
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			err = fmt.Errorf("Invalid binding '%s', expected APP:SERVICE_INSTANCE", pair)
+			return
+		}
+
+		serviceNames := []string{}
+		if parts[1] != "" {
+			serviceNames = append(serviceNames, parts[1])
+		}
+		desired.add(parts[0], serviceNames)
+	}
+
+	if len(desired.appNames) == 0 {
+		err = errors.New("No apps with services found in manifest")
+	}
+	return
+}
+
+// bindingChanges returns the binds needed to give each listed app its desired
+// services, grouped by app. With prune, services an app should not have are
+// unbound too, so that it is bound to exactly its desired services.
+func bindingChanges(desired *desiredBindings, instances []cf.ServiceInstance, prune bool) (changes []bindingChange) {
+	for _, appName := range desired.appNames {
+		current := []string{}
+		for _, instance := range instances {
+			if containsString(instance.ApplicationNames, appName) {
+				current = append(current, instance.Name)
+			}
+		}
+
+		for _, serviceName := range current {
+			if prune && !containsString(desired.services[appName], serviceName) {
+				changes = append(changes, bindingChange{appName: appName, serviceName: serviceName, bind: false})
+			}
+		}
+
+		for _, serviceName := range desired.services[appName] {
+			if !containsString(current, serviceName) {
+				changes = append(changes, bindingChange{appName: appName, serviceName: serviceName, bind: true})
+			}
+		}
+	}
+	return
+}
+
+// findAppsAndInstances looks everything up before any binding is changed so
+// that a misspelled name does not leave the space half updated.
+func (cmd *BindServices) findAppsAndInstances(changes []bindingChange) (apps map[string]cf.Application, instances map[string]cf.ServiceInstance, ok bool) {
+	apps = map[string]cf.Application{}
+	instances = map[string]cf.ServiceInstance{}
+
+	for _, change := range changes {
+		if _, found := apps[change.appName]; !found {
+			app, apiResponse := cmd.appRepo.FindByName(change.appName)
+			if apiResponse.IsNotSuccessful() {
+				cmd.ui.Failed(apiResponse.Message)
+				return
+			}
+			apps[change.appName] = app
+		}
+
+		if _, found := instances[change.serviceName]; !found {
+			instance, apiResponse := cmd.serviceRepo.FindInstanceByName(change.serviceName)
+			if apiResponse.IsNotSuccessful() {
+				cmd.ui.Failed(apiResponse.Message)
+				return
+			}
+			instances[change.serviceName] = instance
+		}
+	}
+
+	ok = true
+	return
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package service_test
+
+import (
+	"cf"
+	. "cf/commands/service"
+	"cf/configuration"
+	"cf/manifest"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testmanifest "testhelpers/manifest"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+type bindServicesDeps struct {
+	reqFactory         *testreq.FakeReqFactory
+	serviceSummaryRepo *testapi.FakeServiceSummaryRepo
+	serviceRepo        *testapi.FakeServiceRepo
+	appRepo            *testapi.FakeApplicationRepository
+	serviceBindingRepo *testapi.FakeServiceBindingRepo
+	manifestRepo       *testmanifest.FakeManifestRepository
+	restarter          *testcmd.FakeAppRestarter
+}
+
+func newBindServicesDeps() (deps bindServicesDeps) {
+	deps.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	deps.serviceSummaryRepo = &testapi.FakeServiceSummaryRepo{
+		GetSummariesInCurrentSpaceInstances: []cf.ServiceInstance{
+			{Name: "my-db", ApplicationNames: []string{"my-app", "my-worker"}},
+			{Name: "my-cache", ApplicationNames: []string{"my-app"}},
+			{Name: "my-queue"},
+		},
+	}
+	deps.serviceRepo = &testapi.FakeServiceRepo{
+		FindInstanceByNameInstances: map[string]cf.ServiceInstance{
+			"my-db":    {Name: "my-db", Guid: "my-db-guid"},
+			"my-cache": {Name: "my-cache", Guid: "my-cache-guid"},
+			"my-queue": {Name: "my-queue", Guid: "my-queue-guid"},
+		},
+	}
+	deps.appRepo = &testapi.FakeApplicationRepository{
+		FindByNameApps: map[string]cf.Application{
+			"my-app":     {Name: "my-app", Guid: "my-app-guid", State: "started"},
+			"my-worker":  {Name: "my-worker", Guid: "my-worker-guid", State: "started"},
+			"my-stopped": {Name: "my-stopped", Guid: "my-stopped-guid", State: "stopped"},
+		},
+	}
+	deps.serviceBindingRepo = &testapi.FakeServiceBindingRepo{}
+	deps.manifestRepo = &testmanifest.FakeManifestRepository{}
+	deps.restarter = &testcmd.FakeAppRestarter{}
+	return
+}
+
+func TestBindServicesFailsWithUsage(t *testing.T) {
+	deps := newBindServicesDeps()
+
+	ui := callBindServices(t, []string{}, deps)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callBindServices(t, []string{"my-app"}, deps)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callBindServices(t, []string{"-b", "my-app:my-db"}, deps)
+	assert.False(t, ui.FailedWithUsage)
+
+	ui = callBindServices(t, []string{"-f", "bindings.yml"}, deps)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestBindServicesRequirements(t *testing.T) {
+	deps := newBindServicesDeps()
+	deps.reqFactory.LoginSuccess = false
+	callBindServices(t, []string{"-b", "my-app:my-db"}, deps)
+	assert.Empty(t, deps.serviceBindingRepo.CreatedBindings)
+
+	deps = newBindServicesDeps()
+	deps.reqFactory.TargetedSpaceSuccess = false
+	callBindServices(t, []string{"-b", "my-app:my-db"}, deps)
+	assert.Empty(t, deps.serviceBindingRepo.CreatedBindings)
+}
+
+func TestBindServicesOnlyBindsWithoutPrune(t *testing.T) {
+	deps := newBindServicesDeps()
+	ui := callBindServices(t, []string{"-b", "my-app:my-db", "-b", "my-app:my-queue", "-b", "my-worker:"}, deps)
+
+	assert.Empty(t, deps.serviceBindingRepo.DeletedBindings)
+	assert.Equal(t, deps.serviceBindingRepo.CreatedBindings, []string{"my-app:my-queue"})
+	for _, output := range ui.Outputs {
+		assert.NotContains(t, output, "Unbinding")
+	}
+}
+
+func TestBindServicesOnlyChangesWhatDiffers(t *testing.T) {
+	deps := newBindServicesDeps()
+	ui := callBindServices(t, []string{"--prune", "-b", "my-app:my-db", "-b", "my-app:my-queue"}, deps)
+
+	assert.Contains(t, ui.Outputs[0], "Updating service bindings")
+	assert.Contains(t, ui.Outputs[0], "my-org")
+	assert.Contains(t, ui.Outputs[0], "my-space")
+	assert.Contains(t, ui.Outputs[0], "my-user")
+
+	assert.Equal(t, deps.serviceBindingRepo.DeletedBindings, []string{"my-app:my-cache"})
+	assert.Equal(t, deps.serviceBindingRepo.CreatedBindings, []string{"my-app:my-queue"})
+	assert.Equal(t, deps.serviceBindingRepo.CreateServiceInstance.Guid, "my-queue-guid")
+	assert.Equal(t, deps.serviceBindingRepo.CreateApplication.Guid, "my-app-guid")
+
+	assert.Contains(t, ui.Outputs[1], "Unbinding app")
+	assert.Contains(t, ui.Outputs[1], "my-cache")
+	assert.Contains(t, ui.Outputs[2], "Binding service")
+	assert.Contains(t, ui.Outputs[2], "my-queue")
+	assert.Contains(t, ui.Outputs[3], "OK")
+	assert.Contains(t, ui.Outputs[4], "TIP")
+	assert.Empty(t, deps.restarter.RestartedApps)
+}
+
+func TestBindServicesWithAnEmptyServiceListUnbindsEverything(t *testing.T) {
+	deps := newBindServicesDeps()
+	callBindServices(t, []string{"--prune", "-b", "my-worker:"}, deps)
+
+	assert.Equal(t, deps.serviceBindingRepo.DeletedBindings, []string{"my-worker:my-db"})
+	assert.Empty(t, deps.serviceBindingRepo.CreatedBindings)
+}
+
+func TestBindServicesWhenNothingChanged(t *testing.T) {
+	deps := newBindServicesDeps()
+	ui := callBindServices(t, []string{"--restart", "-b", "my-app:my-db", "-b", "my-app:my-cache"}, deps)
+
+	assert.Empty(t, deps.serviceBindingRepo.CreatedBindings)
+	assert.Empty(t, deps.serviceBindingRepo.DeletedBindings)
+	assert.Empty(t, deps.restarter.RestartedApps)
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[2], "already up to date")
+}
+
+func TestBindServicesFromManifest(t *testing.T) {
+	deps := newBindServicesDeps()
+	deps.manifestRepo.ReadManifestManifest = &manifest.Manifest{
+		Applications: []manifest.Application{
+			{Name: "my-app", Services: []string{"my-db", "my-cache", "my-queue"}},
+			{Name: "my-worker", Services: []string{}},
+			{Name: "my-untouched"},
+		},
+	}
+
+	callBindServices(t, []string{"--prune", "-f", "bindings.yml"}, deps)
+
+	assert.Equal(t, deps.manifestRepo.ReadManifestPath, "bindings.yml")
+	assert.Equal(t, deps.serviceBindingRepo.CreatedBindings, []string{"my-app:my-queue"})
+	assert.Equal(t, deps.serviceBindingRepo.DeletedBindings, []string{"my-worker:my-db"})
+}
+
+func TestBindServicesWhenManifestCannotBeRead(t *testing.T) {
+	deps := newBindServicesDeps()
+	ui := callBindServices(t, []string{"-f", "bindings.yml"}, deps)
+
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "Error reading manifest file")
+	assert.Empty(t, deps.serviceBindingRepo.CreatedBindings)
+}
+
+func TestBindServicesWithInvalidPair(t *testing.T) {
+	deps := newBindServicesDeps()
+	ui := callBindServices(t, []string{"-b", "my-app"}, deps)
+
+	assert.Contains(t, ui.Outputs[0], "FAILED")
+	assert.Contains(t, ui.Outputs[1], "Invalid binding 'my-app'")
+}
+
+func TestBindServicesLooksUpEverythingBeforeChangingBindings(t *testing.T) {
+	deps := newBindServicesDeps()
+	ui := callBindServices(t, []string{"-b", "my-app:my-db", "-b", "my-worker:my-db", "-b", "my-worker:no-such-service"}, deps)
+
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "no-such-service")
+	assert.Contains(t, ui.Outputs[2], "not found")
+	assert.Empty(t, deps.serviceBindingRepo.CreatedBindings)
+	assert.Empty(t, deps.serviceBindingRepo.DeletedBindings)
+}
+
+func TestBindServicesRestartsEachAffectedAppOnce(t *testing.T) {
+	deps := newBindServicesDeps()
+	ui := callBindServices(t, []string{"--prune", "--restart", "-b", "my-app:my-queue", "-b", "my-worker:my-cache", "-b", "my-stopped:my-db"}, deps)
+
+	assert.Equal(t, deps.serviceBindingRepo.DeletedBindings, []string{"my-app:my-db", "my-app:my-cache", "my-worker:my-db"})
+	assert.Equal(t, deps.serviceBindingRepo.CreatedBindings, []string{"my-app:my-queue", "my-worker:my-cache", "my-stopped:my-db"})
+
+	assert.Equal(t, len(deps.restarter.RestartedApps), 2)
+	assert.Equal(t, deps.restarter.RestartedApps[0].Name, "my-app")
+	assert.Equal(t, deps.restarter.RestartedApps[1].Name, "my-worker")
+	testassert.SliceContains(t, ui.Outputs, []string{"is stopped, skipping restart"})
+}
+
+func callBindServices(t *testing.T, args []string, deps bindServicesDeps) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	ctxt := testcmd.NewContext("bind-services", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+
+	config := &configuration.Configuration{
+		Space:        cf.Space{Name: "my-space"},
+		Organization: cf.Organization{Name: "my-org"},
+		AccessToken:  token,
+	}
+
+	cmd := NewBindServices(ui, config, deps.serviceSummaryRepo, deps.serviceRepo, deps.appRepo, deps.serviceBindingRepo, deps.manifestRepo, deps.restarter)
+	testcmd.RunCommand(cmd, ctxt, deps.reqFactory)
+	return
+}
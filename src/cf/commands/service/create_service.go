@@ -39,6 +39,12 @@ func (cmd CreateService) Run(c *cli.Context) {
 	planName := c.Args()[1]
 	name := c.Args()[2]
 
+	params, err := parseArbitraryParams(c.String("c"))
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
 	cmd.ui.Say("Creating service %s in org %s / space %s as %s...",
 		terminal.EntityNameColor(name),
 		terminal.EntityNameColor(cmd.config.Organization.Name),
@@ -65,7 +71,7 @@ func (cmd CreateService) Run(c *cli.Context) {
 	}
 
 	var identicalAlreadyExists bool
-	identicalAlreadyExists, apiResponse = cmd.serviceRepo.CreateServiceInstance(name, plan)
+	identicalAlreadyExists, apiResponse = cmd.serviceRepo.CreateServiceInstance(name, plan, params, reportLastOperation(cmd.ui))
 	if apiResponse.IsNotSuccessful() {
 		cmd.ui.Failed(apiResponse.Message)
 		return
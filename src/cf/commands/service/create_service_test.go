@@ -6,6 +6,8 @@ import (
 	. "cf/commands/service"
 	"cf/configuration"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
 	testapi "testhelpers/api"
 	testcmd "testhelpers/commands"
 	testconfig "testhelpers/configuration"
@@ -61,6 +63,88 @@ func TestCreateServiceWhenServiceAlreadyExists(t *testing.T) {
 	assert.Contains(t, fakeUI.Outputs[2], "already exists")
 }
 
+func TestCreateServiceWithParameters(t *testing.T) {
+	serviceOfferings := []cf.ServiceOffering{
+		cf.ServiceOffering{Label: "postgres", Plans: []cf.ServicePlan{
+			cf.ServicePlan{Name: "large", Guid: "postgres-large-guid"},
+		}},
+	}
+
+	serviceRepo := &testapi.FakeServiceRepo{ServiceOfferings: serviceOfferings}
+	fakeUI := callCreateService(t,
+		[]string{"-c", `{"storage_gb": 20}`, "postgres", "large", "my-db"},
+		[]string{},
+		serviceRepo,
+	)
+	assert.Contains(t, fakeUI.Outputs[1], "OK")
+	assert.Equal(t, serviceRepo.CreateServiceInstanceParams, map[string]interface{}{"storage_gb": float64(20)})
+
+	paramsFile, err := ioutil.TempFile("", "service-params")
+	assert.NoError(t, err)
+	defer os.Remove(paramsFile.Name())
+	paramsFile.WriteString(`{"version": "9.3"}`)
+	paramsFile.Close()
+
+	serviceRepo = &testapi.FakeServiceRepo{ServiceOfferings: serviceOfferings}
+	fakeUI = callCreateService(t,
+		[]string{"-c", paramsFile.Name(), "postgres", "large", "my-db"},
+		[]string{},
+		serviceRepo,
+	)
+	assert.Contains(t, fakeUI.Outputs[1], "OK")
+	assert.Equal(t, serviceRepo.CreateServiceInstanceParams, map[string]interface{}{"version": "9.3"})
+}
+
+func TestCreateServiceWithInvalidParameters(t *testing.T) {
+	serviceRepo := &testapi.FakeServiceRepo{}
+	fakeUI := callCreateService(t,
+		[]string{"-c", "not-json", "postgres", "large", "my-db"},
+		[]string{},
+		serviceRepo,
+	)
+
+	assert.Contains(t, fakeUI.Outputs[0], "FAILED")
+	assert.Contains(t, fakeUI.Outputs[1], "valid JSON object")
+	assert.Equal(t, serviceRepo.CreateServiceInstanceName, "")
+}
+
+func TestCreateServiceReportsAsynchronousProgress(t *testing.T) {
+	serviceOfferings := []cf.ServiceOffering{
+		cf.ServiceOffering{Label: "postgres", Plans: []cf.ServicePlan{
+			cf.ServicePlan{Name: "large", Guid: "postgres-large-guid"},
+		}},
+	}
+	serviceRepo := &testapi.FakeServiceRepo{
+		ServiceOfferings: serviceOfferings,
+		LastOperations: []cf.LastOperation{
+			{Type: "create", State: "in progress", Description: "queued"},
+			{Type: "create", State: "in progress", Description: "queued"},
+			{Type: "create", State: "in progress", Description: "allocating storage"},
+			{Type: "create", State: "succeeded"},
+		},
+	}
+
+	fakeUI := callCreateService(t, []string{"postgres", "large", "my-db"}, []string{}, serviceRepo)
+
+	assert.Contains(t, fakeUI.Outputs[1], "Create in progress: queued")
+	assert.Contains(t, fakeUI.Outputs[2], "Create in progress: allocating storage")
+	assert.Contains(t, fakeUI.Outputs[3], "OK")
+}
+
+func TestCreateServiceWhenAsynchronousOperationFails(t *testing.T) {
+	serviceOfferings := []cf.ServiceOffering{
+		cf.ServiceOffering{Label: "postgres", Plans: []cf.ServicePlan{
+			cf.ServicePlan{Name: "large", Guid: "postgres-large-guid"},
+		}},
+	}
+	serviceRepo := &testapi.FakeServiceRepo{ServiceOfferings: serviceOfferings, LastOperationErr: true}
+
+	fakeUI := callCreateService(t, []string{"postgres", "large", "my-db"}, []string{}, serviceRepo)
+
+	assert.Contains(t, fakeUI.Outputs[1], "FAILED")
+	assert.Contains(t, fakeUI.Outputs[2], "Service broker failed")
+}
+
 func callCreateService(t *testing.T, args []string, inputs []string, serviceRepo api.ServiceRepository) (fakeUI *testterm.FakeUI) {
 	fakeUI = &testterm.FakeUI{Inputs: inputs}
 	ctxt := testcmd.NewContext("create-service", args)
@@ -9,6 +9,14 @@ import (
 	"strings"
 )
 
+type serviceInstanceRecord struct {
+	Name      string   `json:"name" yaml:"name"`
+	Guid      string   `json:"guid" yaml:"guid"`
+	Service   string   `json:"service" yaml:"service"`
+	Plan      string   `json:"plan" yaml:"plan"`
+	BoundApps []string `json:"bound_apps" yaml:"bound_apps"`
+}
+
 type ListServices struct {
 	ui                 terminal.UI
 	config             *configuration.Configuration
@@ -46,6 +54,7 @@ func (cmd ListServices) Run(c *cli.Context) {
 	table := [][]string{
 		[]string{"name", "service", "plan", "bound apps"},
 	}
+	records := []serviceInstanceRecord{}
 
 	for _, instance := range serviceInstances {
 		var serviceColumn string
@@ -62,7 +71,20 @@ func (cmd ListServices) Run(c *cli.Context) {
 			instance.ServicePlan.Name,
 			strings.Join(instance.ApplicationNames, ", "),
 		})
+
+		boundApps := instance.ApplicationNames
+		if boundApps == nil {
+			boundApps = []string{}
+		}
+
+		records = append(records, serviceInstanceRecord{
+			Name:      instance.Name,
+			Guid:      instance.Guid,
+			Service:   serviceColumn,
+			Plan:      instance.ServicePlan.Name,
+			BoundApps: boundApps,
+		})
 	}
 
-	cmd.ui.DisplayTable(table)
+	cmd.ui.DisplayRecords(table, records)
 }
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"cf"
+	"cf/terminal"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// parseArbitraryParams reads the -c flag, which holds either a JSON object or the
+// path to a file containing one
+func parseArbitraryParams(value string) (params map[string]interface{}, err error) {
+	if value == "" {
+		return
+	}
+
+	data := []byte(value)
+	if fileInfo, statErr := os.Stat(value); statErr == nil && !fileInfo.IsDir() {
+		data, err = ioutil.ReadFile(value)
+		if err != nil {
+			return
+		}
+	}
+
+	err = json.Unmarshal(data, &params)
+	if err != nil {
+		err = errors.New("Invalid configuration provided for -c flag. Please provide a valid JSON object or path to a file containing a valid JSON object.")
+	}
+	return
+}
+
+// reportLastOperation prints the broker's progress each time it changes while an
+// asynchronous create or update is running
+func reportLastOperation(ui terminal.UI) func(cf.LastOperation) {
+	var reported cf.LastOperation
+	return func(lastOperation cf.LastOperation) {
+		if !lastOperation.IsInProgress() || lastOperation == reported {
+			return
+		}
+		reported = lastOperation
+
+		message := strings.Title(lastOperation.Type) + " in progress"
+		if lastOperation.Description != "" {
+			message += ": " + lastOperation.Description
+		}
+		ui.Say(message)
+	}
+}
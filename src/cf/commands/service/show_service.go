@@ -48,5 +48,13 @@ func (cmd *ShowService) Run(c *cli.Context) {
 		cmd.ui.Say("Plan: %s", terminal.EntityNameColor(serviceInstance.ServicePlan.Name))
 		cmd.ui.Say("Description: %s", terminal.EntityNameColor(serviceInstance.ServiceOffering().Description))
 		cmd.ui.Say("Documentation url: %s", terminal.EntityNameColor(serviceInstance.ServiceOffering().DocumentationUrl))
+
+		lastOperation := serviceInstance.LastOperation
+		if lastOperation.State != "" {
+			cmd.ui.Say("Status: %s", terminal.EntityNameColor(lastOperation.Type+" "+lastOperation.State))
+			if lastOperation.Description != "" {
+				cmd.ui.Say("Message: %s", terminal.EntityNameColor(lastOperation.Description))
+			}
+		}
 	}
 }
@@ -70,6 +70,29 @@ func TestShowServiceOutput(t *testing.T) {
 	assert.Contains(t, ui.Outputs[5], "http://documentation.url")
 }
 
+func TestShowServiceOutputWithLastOperation(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{
+		LoginSuccess:         true,
+		TargetedSpaceSuccess: true,
+		ServiceInstance: cf.ServiceInstance{
+			Name: "service1",
+			Guid: "service1-guid",
+			ServicePlan: cf.ServicePlan{
+				Guid:            "plan-guid",
+				Name:            "plan-name",
+				ServiceOffering: cf.ServiceOffering{Label: "mysql"},
+			},
+			LastOperation: cf.LastOperation{Type: "update", State: "in progress", Description: "resizing"},
+		},
+	}
+	ui := callShowService([]string{"service1"}, reqFactory)
+
+	assert.Contains(t, ui.Outputs[6], "Status: ")
+	assert.Contains(t, ui.Outputs[6], "update in progress")
+	assert.Contains(t, ui.Outputs[7], "Message: ")
+	assert.Contains(t, ui.Outputs[7], "resizing")
+}
+
 func TestShowUserProvidedServiceOutput(t *testing.T) {
 	reqFactory := &testreq.FakeReqFactory{
 		LoginSuccess:         true,
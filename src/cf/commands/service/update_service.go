@@ -0,0 +1,96 @@
+package service
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type UpdateService struct {
+	ui                 terminal.UI
+	config             *configuration.Configuration
+	serviceRepo        api.ServiceRepository
+	serviceInstanceReq requirements.ServiceInstanceRequirement
+}
+
+func NewUpdateService(ui terminal.UI, config *configuration.Configuration, serviceRepo api.ServiceRepository) (cmd *UpdateService) {
+	cmd = new(UpdateService)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.serviceRepo = serviceRepo
+	return
+}
+
+func (cmd *UpdateService) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 || (c.String("p") == "" && c.String("c") == "") {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "update-service")
+		return
+	}
+
+	cmd.serviceInstanceReq = reqFactory.NewServiceInstanceRequirement(c.Args()[0])
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedSpaceRequirement(),
+		cmd.serviceInstanceReq,
+	}
+	return
+}
+
+func (cmd *UpdateService) Run(c *cli.Context) {
+	instance := cmd.serviceInstanceReq.GetServiceInstance()
+	planName := c.String("p")
+
+	params, err := parseArbitraryParams(c.String("c"))
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	cmd.ui.Say("Updating service instance %s in org %s / space %s as %s...",
+		terminal.EntityNameColor(instance.Name),
+		terminal.EntityNameColor(cmd.config.Organization.Name),
+		terminal.EntityNameColor(cmd.config.Space.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	if instance.IsUserProvided() {
+		cmd.ui.Failed("Service instance %s is user-provided. Use '%s' to change its credentials.",
+			instance.Name, cf.Name()+" update-user-provided-service")
+		return
+	}
+
+	var plan cf.ServicePlan
+	if planName != "" {
+		offerings, apiResponse := cmd.serviceRepo.GetServiceOfferings()
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+
+		offering, err := findOffering(offerings, instance.ServiceOffering().Label)
+		if err != nil {
+			cmd.ui.Failed(err.Error())
+			return
+		}
+
+		plan, err = findPlan(offering.Plans, planName)
+		if err != nil {
+			cmd.ui.Failed(err.Error())
+			return
+		}
+	}
+
+	apiResponse := cmd.serviceRepo.UpdateServiceInstance(instance, plan, params, reportLastOperation(cmd.ui))
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Ok()
+}
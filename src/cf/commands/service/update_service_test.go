@@ -0,0 +1,149 @@
+package service_test
+
+import (
+	"cf"
+	"cf/api"
+	. "cf/commands/service"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+var postgresInstance = cf.ServiceInstance{
+	Name: "my-db",
+	Guid: "my-db-guid",
+	ServicePlan: cf.ServicePlan{
+		Name:            "small",
+		Guid:            "postgres-small-guid",
+		ServiceOffering: cf.ServiceOffering{Label: "postgres"},
+	},
+}
+
+var postgresOfferings = []cf.ServiceOffering{
+	cf.ServiceOffering{Label: "cleardb", Plans: []cf.ServicePlan{
+		cf.ServicePlan{Name: "large", Guid: "cleardb-large-guid"},
+	}},
+	cf.ServiceOffering{Label: "postgres", Plans: []cf.ServicePlan{
+		cf.ServicePlan{Name: "small", Guid: "postgres-small-guid"},
+		cf.ServicePlan{Name: "large", Guid: "postgres-large-guid"},
+	}},
+}
+
+func TestUpdateServiceRequirements(t *testing.T) {
+	serviceRepo := &testapi.FakeServiceRepo{ServiceOfferings: postgresOfferings}
+	args := []string{"-p", "large", "my-db"}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, ServiceInstance: postgresInstance}
+	callUpdateService(t, args, reqFactory, serviceRepo)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.ServiceInstanceName, "my-db")
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: false}
+	callUpdateService(t, args, reqFactory, serviceRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: false, TargetedSpaceSuccess: true}
+	callUpdateService(t, args, reqFactory, serviceRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestUpdateServiceFailsWithUsage(t *testing.T) {
+	serviceRepo := &testapi.FakeServiceRepo{ServiceOfferings: postgresOfferings}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, ServiceInstance: postgresInstance}
+
+	ui := callUpdateService(t, []string{"-p", "large"}, reqFactory, serviceRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callUpdateService(t, []string{"my-db"}, reqFactory, serviceRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callUpdateService(t, []string{"-p", "large", "my-db"}, reqFactory, serviceRepo)
+	assert.False(t, ui.FailedWithUsage)
+
+	ui = callUpdateService(t, []string{"-c", `{"storage_gb":50}`, "my-db"}, reqFactory, serviceRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestUpdateServicePlan(t *testing.T) {
+	serviceRepo := &testapi.FakeServiceRepo{
+		ServiceOfferings: postgresOfferings,
+		LastOperations: []cf.LastOperation{
+			{Type: "update", State: "in progress", Description: "resizing"},
+			{Type: "update", State: "succeeded"},
+		},
+	}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, ServiceInstance: postgresInstance}
+
+	ui := callUpdateService(t, []string{"-p", "large", "my-db"}, reqFactory, serviceRepo)
+
+	assert.Contains(t, ui.Outputs[0], "Updating service instance")
+	assert.Contains(t, ui.Outputs[0], "my-db")
+	assert.Contains(t, ui.Outputs[0], "my-org")
+	assert.Contains(t, ui.Outputs[0], "my-space")
+	assert.Contains(t, ui.Outputs[0], "my-user")
+	assert.Contains(t, ui.Outputs[1], "Update in progress: resizing")
+	assert.Contains(t, ui.Outputs[2], "OK")
+
+	assert.Equal(t, serviceRepo.UpdateServiceInstanceServiceInstance, postgresInstance)
+	assert.Equal(t, serviceRepo.UpdateServiceInstancePlan, cf.ServicePlan{Name: "large", Guid: "postgres-large-guid"})
+	assert.Nil(t, serviceRepo.UpdateServiceInstanceParams)
+}
+
+func TestUpdateServiceParameters(t *testing.T) {
+	serviceRepo := &testapi.FakeServiceRepo{ServiceOfferings: postgresOfferings}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, ServiceInstance: postgresInstance}
+
+	ui := callUpdateService(t, []string{"-c", `{"storage_gb":50}`, "my-db"}, reqFactory, serviceRepo)
+
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Equal(t, serviceRepo.UpdateServiceInstancePlan, cf.ServicePlan{})
+	assert.Equal(t, serviceRepo.UpdateServiceInstanceParams, map[string]interface{}{"storage_gb": float64(50)})
+}
+
+func TestUpdateServiceWhenPlanDoesNotExist(t *testing.T) {
+	serviceRepo := &testapi.FakeServiceRepo{ServiceOfferings: postgresOfferings}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, ServiceInstance: postgresInstance}
+
+	ui := callUpdateService(t, []string{"-p", "huge", "my-db"}, reqFactory, serviceRepo)
+
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "Could not find plan with name huge")
+	assert.Equal(t, serviceRepo.UpdateServiceInstanceServiceInstance.Name, "")
+}
+
+func TestUpdateServiceWhenUserProvided(t *testing.T) {
+	serviceRepo := &testapi.FakeServiceRepo{ServiceOfferings: postgresOfferings}
+	userProvidedInstance := cf.ServiceInstance{Name: "my-ups", Guid: "my-ups-guid"}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, ServiceInstance: userProvidedInstance}
+
+	ui := callUpdateService(t, []string{"-p", "large", "my-ups"}, reqFactory, serviceRepo)
+
+	assert.Contains(t, ui.Outputs[1], "FAILED")
+	assert.Contains(t, ui.Outputs[2], "user-provided")
+	assert.Equal(t, serviceRepo.UpdateServiceInstanceServiceInstance.Name, "")
+}
+
+func callUpdateService(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, serviceRepo api.ServiceRepository) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	ctxt := testcmd.NewContext("update-service", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+
+	config := &configuration.Configuration{
+		Space:        cf.Space{Name: "my-space"},
+		Organization: cf.Organization{Name: "my-org"},
+		AccessToken:  token,
+	}
+
+	cmd := NewUpdateService(ui, config, serviceRepo)
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
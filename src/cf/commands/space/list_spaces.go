@@ -1,6 +1,7 @@
 package space
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/requirements"
@@ -8,6 +9,11 @@ import (
 	"github.com/codegangsta/cli"
 )
 
+type spaceRecord struct {
+	Name string `json:"name" yaml:"name"`
+	Guid string `json:"guid" yaml:"guid"`
+}
+
 type ListSpaces struct {
 	ui        terminal.UI
 	config    *configuration.Configuration
@@ -34,16 +40,26 @@ func (cmd ListSpaces) Run(c *cli.Context) {
 		terminal.EntityNameColor(cmd.config.Organization.Name),
 		terminal.EntityNameColor(cmd.config.Username()))
 
-	spaces, apiResponse := cmd.spaceRepo.FindAll()
+	records := []spaceRecord{}
+	apiResponse := cmd.spaceRepo.ListSpaces(func(space cf.Space) bool {
+		if len(records) == 0 {
+			cmd.ui.Ok()
+			cmd.ui.Say("")
+		}
+
+		cmd.ui.Say(space.Name)
+		records = append(records, spaceRecord{Name: space.Name, Guid: space.Guid})
+		return true
+	})
 	if apiResponse.IsNotSuccessful() {
 		cmd.ui.Failed(apiResponse.Message)
 		return
 	}
 
-	cmd.ui.Ok()
-	cmd.ui.Say("")
-
-	for _, space := range spaces {
-		cmd.ui.Say(space.Name)
+	if len(records) == 0 {
+		cmd.ui.Ok()
+		cmd.ui.Say("")
 	}
+
+	cmd.ui.DisplayRecords(nil, records)
 }
@@ -39,17 +39,27 @@ func (cmd Target) GetRequirements(reqFactory requirements.Factory, c *cli.Contex
 		return
 	}
 
-	if c.String("o") != "" || c.String("s") != "" {
+	// a profile switch happens in Run, so the org and space checks there cover logging in
+	if c.String("profile") == "" && (c.String("o") != "" || c.String("s") != "") {
 		reqs = append(reqs, reqFactory.NewLoginRequirement())
 	}
 	return
 }
 
 func (cmd Target) Run(c *cli.Context) {
+	profileName := c.String("profile")
 	orgName := c.String("o")
 	spaceName := c.String("s")
 	shouldShowTarget := (orgName == "" && spaceName == "")
 
+	if profileName != "" {
+		err := cmd.configRepo.SwitchProfile(profileName)
+		if err != nil {
+			cmd.ui.Failed("Error switching profile in config file.\n%s", err.Error())
+			return
+		}
+	}
+
 	if shouldShowTarget {
 		cmd.ui.ShowConfiguration(cmd.config)
 
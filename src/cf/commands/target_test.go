@@ -57,6 +57,29 @@ func TestTargetWithoutArgumentAndLoggedIn(t *testing.T) {
 	assert.Contains(t, ui.Outputs[1], "No space targeted")
 }
 
+func TestTargetProfileSwitchesProfile(t *testing.T) {
+	orgRepo, spaceRepo, configRepo, reqFactory := getTargetDependencies()
+	configRepo.Delete()
+
+	config := configRepo.Login()
+	config.Organization = cf.Organization{Name: "my-org", Guid: "my-org-guid"}
+
+	ui := callTarget([]string{"--profile", "prod"}, reqFactory, configRepo, orgRepo, spaceRepo)
+
+	assert.True(t, ui.ShowConfigurationCalled)
+	assert.Equal(t, config.ProfileName(), "prod")
+	assert.False(t, config.IsLoggedIn())
+	assert.Equal(t, testconfig.SavedConfiguration.CurrentProfile, "prod")
+
+	callTarget([]string{"--profile", "default"}, reqFactory, configRepo, orgRepo, spaceRepo)
+
+	assert.Equal(t, config.ProfileName(), "default")
+	assert.True(t, config.IsLoggedIn())
+	assert.Equal(t, config.Organization.Name, "my-org")
+
+	configRepo.Delete()
+}
+
 // Start test with organization option
 func TestTargetOrganizationWhenUserHasAccess(t *testing.T) {
 	orgRepo, spaceRepo, configRepo, reqFactory := getTargetDependencies()
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type Unalias struct {
+	ui         terminal.UI
+	configRepo configuration.ConfigurationRepository
+}
+
+func NewUnalias(ui terminal.UI, configRepo configuration.ConfigurationRepository) (cmd Unalias) {
+	cmd.ui = ui
+	cmd.configRepo = configRepo
+	return
+}
+
+func (cmd Unalias) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "unalias")
+	}
+	return
+}
+
+func (cmd Unalias) Run(c *cli.Context) {
+	name := c.Args()[0]
+
+	cmd.ui.Say("Deleting alias %s...", terminal.EntityNameColor(name))
+
+	config, err := cmd.configRepo.Get()
+	if err != nil {
+		cmd.ui.ConfigFailure(err)
+		return
+	}
+
+	if _, found := config.Aliases[name]; !found {
+		cmd.ui.Ok()
+		cmd.ui.Warn("Alias %s does not exist.", name)
+		return
+	}
+
+	err = cmd.configRepo.DeleteAlias(name)
+	if err != nil {
+		cmd.ui.ConfigFailure(err)
+		return
+	}
+
+	cmd.ui.Ok()
+}
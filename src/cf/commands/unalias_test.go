@@ -0,0 +1,58 @@
+package commands_test
+
+import (
+	. "cf/commands"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestUnaliasFailsWithUsage(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+
+	ui := callUnalias([]string{}, configRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callUnalias([]string{"deploy"}, configRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestUnalias(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	defer configRepo.Delete()
+
+	configRepo.SetAlias("deploy", "push -m 512M -i 2")
+
+	ui := callUnalias([]string{"deploy"}, configRepo)
+
+	assert.Contains(t, ui.Outputs[0], "Deleting alias")
+	assert.Contains(t, ui.Outputs[0], "deploy")
+	assert.Contains(t, ui.Outputs[1], "OK")
+
+	config, _ := configRepo.Get()
+	assert.Empty(t, config.Aliases)
+}
+
+func TestUnaliasWhenAliasDoesNotExist(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	defer configRepo.Delete()
+
+	ui := callUnalias([]string{"deploy"}, configRepo)
+
+	assert.Contains(t, ui.Outputs[1], "OK")
+	assert.Contains(t, ui.Outputs[2], "deploy does not exist")
+}
+
+func callUnalias(args []string, configRepo configuration.ConfigurationRepository) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	cmd := NewUnalias(ui, configRepo)
+	ctxt := testcmd.NewContext("unalias", args)
+	testcmd.RunCommand(cmd, ctxt, &testreq.FakeReqFactory{})
+	return
+}
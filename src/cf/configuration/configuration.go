@@ -3,10 +3,15 @@ package configuration
 import (
 	"cf"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
 	"time"
 )
 
-type Configuration struct {
+const DefaultProfileName = "default"
+
+type Profile struct {
 	Target                  string
 	ApiVersion              string
 	AuthorizationEndpoint   string
@@ -14,7 +19,38 @@ type Configuration struct {
 	RefreshToken            string
 	Organization            cf.Organization
 	Space                   cf.Space
-	ApplicationStartTimeout time.Duration // will be used as seconds
+	SSLDisabled             bool
+	UAAOAuthClient          string
+	UAAOAuthClientSecret    string
+	UAAGrantType            string
+	ClientCredentialsId     string
+	ClientCredentialsSecret string
+}
+
+type Configuration struct {
+	Target                   string
+	ApiVersion               string
+	AuthorizationEndpoint    string
+	AccessToken              string
+	RefreshToken             string
+	Organization             cf.Organization
+	Space                    cf.Space
+	SSLDisabled              bool
+	UAAOAuthClient           string
+	UAAOAuthClientSecret     string
+	UAAGrantType             string
+	ClientCredentialsId      string
+	ClientCredentialsSecret  string
+	ApplicationStartTimeout  time.Duration // will be used as seconds
+	ApplicationUploadTimeout time.Duration // will be used as seconds
+	HttpRetries              *int          // nil for the default, 0 to turn retries off
+	HttpTimeout              time.Duration // will be used as seconds
+	CurrentProfile           string
+	Profiles                 map[string]Profile
+	Aliases                  map[string]string
+
+	// set when CF_PROFILE picks a profile for this process only
+	savedProfile string
 }
 
 func (c Configuration) UserEmail() (email string) {
@@ -41,14 +77,33 @@ func (c Configuration) HasSpace() bool {
 	return c.Space.Guid != "" && c.Space.Name != ""
 }
 
+func (c Configuration) AccessTokenExpiry() time.Time {
+	return TokenExpiry(c.AccessToken)
+}
+
 type TokenInfo struct {
 	Username string `json:"user_name"`
 	Email    string `json:"email"`
 	UserGuid string `json:"user_id"`
+	Expiry   int64  `json:"exp"`
+}
+
+// TokenExpiry reads when an access token expires from its exp claim. It is the
+// zero time when the token has no expiry, or is not a JWT at all.
+func TokenExpiry(accessToken string) (expiry time.Time) {
+	info := tokenInfo(accessToken)
+	if info.Expiry == 0 {
+		return
+	}
+	return time.Unix(info.Expiry, 0)
 }
 
 func (c Configuration) getTokenInfo() (info TokenInfo) {
-	clearInfo, err := DecodeTokenInfo(c.AccessToken)
+	return tokenInfo(c.AccessToken)
+}
+
+func tokenInfo(accessToken string) (info TokenInfo) {
+	clearInfo, err := DecodeTokenInfo(accessToken)
 
 	if err != nil {
 		return
@@ -57,3 +112,154 @@ func (c Configuration) getTokenInfo() (info TokenInfo) {
 	err = json.Unmarshal(clearInfo, &info)
 	return
 }
+
+func (c *Configuration) ProfileName() string {
+	if c.CurrentProfile == "" {
+		return DefaultProfileName
+	}
+	return c.CurrentProfile
+}
+
+func (c *Configuration) ProfileNames() (names []string) {
+	c.storeProfile()
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+func (c *Configuration) FindProfile(name string) (profile Profile, found bool) {
+	c.storeProfile()
+	profile, found = c.Profiles[name]
+	return
+}
+
+// SwitchProfile stores the active target under its profile name and makes name the
+// active one. Switching to a profile that does not exist yet starts it empty.
+func (c *Configuration) SwitchProfile(name string) {
+	c.storeProfile()
+	c.applyProfile(c.Profiles[name])
+	c.CurrentProfile = name
+}
+
+func (c *Configuration) RenameProfile(name, newName string) (err error) {
+	c.storeProfile()
+
+	profile, found := c.Profiles[name]
+	if !found {
+		err = fmt.Errorf("Profile %s does not exist.", name)
+		return
+	}
+
+	if _, found := c.Profiles[newName]; found {
+		err = fmt.Errorf("Profile %s already exists.", newName)
+		return
+	}
+
+	delete(c.Profiles, name)
+	c.Profiles[newName] = profile
+
+	if c.ProfileName() == name {
+		c.CurrentProfile = newName
+	}
+	if c.savedProfile == name {
+		c.savedProfile = newName
+	}
+	return
+}
+
+func (c *Configuration) DeleteProfile(name string) (err error) {
+	if c.ProfileName() == name || c.savedProfile == name {
+		err = errors.New("The current profile cannot be deleted. Switch to another profile first.")
+		return
+	}
+
+	delete(c.Profiles, name)
+	return
+}
+
+func (c Configuration) AliasNames() (names []string) {
+	for name := range c.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+func (c *Configuration) SetAlias(name, command string) {
+	if c.Aliases == nil {
+		c.Aliases = map[string]string{}
+	}
+	c.Aliases[name] = command
+}
+
+func (c *Configuration) DeleteAlias(name string) {
+	delete(c.Aliases, name)
+}
+
+func (c *Configuration) useProfileForSession(name string) {
+	if name == c.ProfileName() {
+		return
+	}
+
+	savedProfile := c.ProfileName()
+	c.SwitchProfile(name)
+	c.savedProfile = savedProfile
+}
+
+// forDisk keeps the top level fields pointing at the saved profile, so that a
+// profile picked with CF_PROFILE does not become the current one.
+func (c *Configuration) forDisk() Configuration {
+	c.storeProfile()
+
+	saved := *c
+	if saved.savedProfile != "" {
+		saved.applyProfile(saved.Profiles[saved.savedProfile])
+		saved.CurrentProfile = saved.savedProfile
+	}
+	return saved
+}
+
+func (c *Configuration) storeProfile() {
+	if c.Profiles == nil {
+		c.Profiles = map[string]Profile{}
+	}
+
+	c.Profiles[c.ProfileName()] = c.session()
+}
+
+// session holds the fields that are kept per profile.
+func (c *Configuration) session() Profile {
+	return Profile{
+		Target:                  c.Target,
+		ApiVersion:              c.ApiVersion,
+		AuthorizationEndpoint:   c.AuthorizationEndpoint,
+		AccessToken:             c.AccessToken,
+		RefreshToken:            c.RefreshToken,
+		Organization:            c.Organization,
+		Space:                   c.Space,
+		SSLDisabled:             c.SSLDisabled,
+		UAAOAuthClient:          c.UAAOAuthClient,
+		UAAOAuthClientSecret:    c.UAAOAuthClientSecret,
+		UAAGrantType:            c.UAAGrantType,
+		ClientCredentialsId:     c.ClientCredentialsId,
+		ClientCredentialsSecret: c.ClientCredentialsSecret,
+	}
+}
+
+func (c *Configuration) applyProfile(profile Profile) {
+	c.Target = profile.Target
+	c.ApiVersion = profile.ApiVersion
+	c.AuthorizationEndpoint = profile.AuthorizationEndpoint
+	c.AccessToken = profile.AccessToken
+	c.RefreshToken = profile.RefreshToken
+	c.Organization = profile.Organization
+	c.Space = profile.Space
+	c.SSLDisabled = profile.SSLDisabled
+	c.UAAOAuthClient = profile.UAAOAuthClient
+	c.UAAOAuthClientSecret = profile.UAAOAuthClientSecret
+	c.UAAGrantType = profile.UAAGrantType
+	c.ClientCredentialsId = profile.ClientCredentialsId
+	c.ClientCredentialsSecret = profile.ClientCredentialsSecret
+}
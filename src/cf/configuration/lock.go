@@ -0,0 +1,49 @@
+package configuration
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	lockTimeout    = 10 * time.Second
+	lockRetryDelay = 50 * time.Millisecond
+)
+
+// withConfigLock runs cb while holding an OS level lock on config.json.lock. The
+// lock file itself is left in place; the operating system drops the lock when the
+// file is closed or the cf process dies, so a killed process never leaves it held.
+func withConfigLock(cb func() error) (err error) {
+	file, err := ConfigFile()
+	if err != nil {
+		return
+	}
+
+	lockFile, err := acquireLock(file + ".lock")
+	if err != nil {
+		return
+	}
+	defer releaseLock(lockFile)
+
+	return cb()
+}
+
+func acquireLock(path string) (file *os.File, err error) {
+	deadline := time.Now().Add(lockTimeout)
+
+	for {
+		var locked bool
+		file, locked, err = tryLock(path)
+		if err != nil || locked {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			err = fmt.Errorf("Timed out waiting for the lock on %s", path)
+			return
+		}
+
+		time.Sleep(lockRetryDelay)
+	}
+}
@@ -0,0 +1,33 @@
+// +build darwin freebsd linux netbsd openbsd
+
+package configuration
+
+import (
+	"os"
+	"syscall"
+)
+
+func tryLock(path string) (file *os.File, locked bool, err error) {
+	file, err = os.OpenFile(path, os.O_CREATE|os.O_RDWR, filePermissions)
+	if err != nil {
+		return
+	}
+
+	err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if err != nil {
+		if err == syscall.EWOULDBLOCK {
+			err = nil
+		}
+		file.Close()
+		file = nil
+		return
+	}
+
+	locked = true
+	return
+}
+
+func releaseLock(file *os.File) {
+	syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+	file.Close()
+}
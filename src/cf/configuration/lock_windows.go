@@ -0,0 +1,37 @@
+// +build windows
+
+package configuration
+
+import (
+	"os"
+	"syscall"
+)
+
+// ERROR_SHARING_VIOLATION, returned while another process has the file open
+const errorSharingViolation syscall.Errno = 32
+
+// tryLock opens the file without sharing it, which is as exclusive as LockFileEx
+// and is likewise released by Windows when the handle is closed.
+func tryLock(path string) (file *os.File, locked bool, err error) {
+	pathp, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return
+	}
+
+	handle, err := syscall.CreateFile(pathp, syscall.GENERIC_READ|syscall.GENERIC_WRITE, 0, nil, syscall.OPEN_ALWAYS, syscall.FILE_ATTRIBUTE_NORMAL, 0)
+	if err == errorSharingViolation {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	file = os.NewFile(uintptr(handle), path)
+	locked = true
+	return
+}
+
+func releaseLock(file *os.File) {
+	file.Close()
+}
@@ -6,16 +6,23 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 )
 
+// the config holds tokens and client secrets, so only its owner may read it,
+// even when the directory it is in is readable by others
 const (
-	filePermissions = 0644
+	filePermissions = 0600
 	dirPermissions  = 0700
 )
 
 var singleton *Configuration
 
+// the session as it was last read from or written to disk, to tell which fields
+// of the singleton have been changed since
+var savedSession Profile
+
 type ConfigurationRepository interface {
 	Get() (config *Configuration, err error)
 	Delete()
@@ -24,6 +31,12 @@ type ConfigurationRepository interface {
 	ClearSession() (err error)
 	SetOrganization(org cf.Organization) (err error)
 	SetSpace(space cf.Space) (err error)
+	ListProfiles() (names []string, err error)
+	SwitchProfile(name string) (err error)
+	RenameProfile(name, newName string) (err error)
+	DeleteProfile(name string) (err error)
+	SetAlias(name, command string) (err error)
+	DeleteAlias(name string) (err error)
 }
 
 type ConfigurationDiskRepository struct {
@@ -34,35 +47,76 @@ func NewConfigurationDiskRepository() (repo ConfigurationDiskRepository) {
 }
 
 func (repo ConfigurationDiskRepository) SetOrganization(org cf.Organization) (err error) {
-	config, err := repo.Get()
-	if err != nil {
+	return repo.update(func(config *Configuration) (err error) {
+		config.Organization = org
+		config.Space = cf.Space{}
 		return
-	}
-
-	config.Organization = org
-	config.Space = cf.Space{}
-
-	return saveConfiguration(config)
+	})
 }
 
 func (repo ConfigurationDiskRepository) SetSpace(space cf.Space) (err error) {
+	return repo.update(func(config *Configuration) (err error) {
+		config.Space = space
+		return
+	})
+}
+
+func (repo ConfigurationDiskRepository) ListProfiles() (names []string, err error) {
 	config, err := repo.Get()
 	if err != nil {
 		return
 	}
 
-	config.Space = space
+	names = config.ProfileNames()
+	return
+}
 
-	return saveConfiguration(config)
+func (repo ConfigurationDiskRepository) SwitchProfile(name string) (err error) {
+	return repo.update(func(config *Configuration) (err error) {
+		config.SwitchProfile(name)
+		config.savedProfile = ""
+		return
+	})
+}
+
+func (repo ConfigurationDiskRepository) RenameProfile(name, newName string) (err error) {
+	return repo.update(func(config *Configuration) error {
+		return config.RenameProfile(name, newName)
+	})
+}
+
+func (repo ConfigurationDiskRepository) DeleteProfile(name string) (err error) {
+	return repo.update(func(config *Configuration) error {
+		return config.DeleteProfile(name)
+	})
+}
+
+func (repo ConfigurationDiskRepository) SetAlias(name, command string) (err error) {
+	return repo.update(func(config *Configuration) (err error) {
+		config.SetAlias(name, command)
+		return
+	})
+}
+
+func (repo ConfigurationDiskRepository) DeleteAlias(name string) (err error) {
+	return repo.update(func(config *Configuration) (err error) {
+		config.DeleteAlias(name)
+		return
+	})
 }
 
 func (repo ConfigurationDiskRepository) Get() (c *Configuration, err error) {
 	if singleton == nil {
-		singleton, err = load()
+		err = withConfigLock(func() (err error) {
+			singleton, err = load()
+			return
+		})
 
 		if err != nil {
+			singleton = nil
 			return
 		}
+		savedSession = singleton.session()
 	}
 
 	return singleton, nil
@@ -75,16 +129,18 @@ func (repo ConfigurationDiskRepository) Delete() {
 		return
 	}
 
-	os.Remove(file)
+	withConfigLock(func() error {
+		return os.Remove(file)
+	})
 	singleton = nil
 }
 
+// Save writes the session fields changed in memory since the config was read, such
+// as a refreshed token or a new target, without undoing what other cf processes saved.
 func (repo ConfigurationDiskRepository) Save() (err error) {
-	c, err := repo.Get()
-	if err != nil {
+	return repo.update(func(config *Configuration) (err error) {
 		return
-	}
-	return saveConfiguration(c)
+	})
 }
 
 func (repo ConfigurationDiskRepository) ClearTokens() (err error) {
@@ -98,25 +154,71 @@ func (repo ConfigurationDiskRepository) ClearTokens() (err error) {
 }
 
 func (repo ConfigurationDiskRepository) ClearSession() (err error) {
-	err = repo.ClearTokens()
-	if err != nil {
+	return repo.update(func(config *Configuration) (err error) {
+		config.AccessToken = ""
+		config.RefreshToken = ""
+		config.Organization = cf.Organization{}
+		config.Space = cf.Space{}
+
+		// don't leave a service account's secret behind
+		config.ClientCredentialsId = ""
+		config.ClientCredentialsSecret = ""
+		config.UAAGrantType = ""
 		return
-	}
+	})
+}
 
-	c, err := repo.Get()
+// update applies change to a fresh read of the config file while holding the lock,
+// along with any session fields changed in memory, so that whatever another cf
+// process saved in the meantime is kept. The singleton is then refreshed in place.
+func (repo ConfigurationDiskRepository) update(change func(*Configuration) error) (err error) {
+	config, err := repo.Get()
 	if err != nil {
 		return
 	}
-	c.Organization = cf.Organization{}
-	c.Space = cf.Space{}
 
-	return saveConfiguration(c)
+	return withConfigLock(func() (err error) {
+		onDisk, err := load()
+		if err != nil {
+			return
+		}
+
+		onDisk.applyProfile(mergeChangedFields(savedSession, config.session(), onDisk.session()))
+
+		err = change(onDisk)
+		if err != nil {
+			return
+		}
+
+		err = saveConfiguration(onDisk)
+		if err != nil {
+			return
+		}
+
+		*config = *onDisk
+		savedSession = config.session()
+		return
+	})
+}
+
+// mergeChangedFields copies onto onDisk each field that differs between saved and current.
+func mergeChangedFields(saved, current, onDisk Profile) Profile {
+	savedValue := reflect.ValueOf(saved)
+	currentValue := reflect.ValueOf(current)
+	mergedValue := reflect.ValueOf(&onDisk).Elem()
+
+	for i := 0; i < mergedValue.NumField(); i++ {
+		if !reflect.DeepEqual(savedValue.Field(i).Interface(), currentValue.Field(i).Interface()) {
+			mergedValue.Field(i).Set(currentValue.Field(i))
+		}
+	}
+	return onDisk
 }
 
 // Keep this one public for configtest/configuration.go
 func ConfigFile() (file string, err error) {
 
-	configDir := filepath.Join(userHomeDir(), ".cf")
+	configDir := filepath.Join(cfHomeDir(), ".cf")
 
 	err = os.MkdirAll(configDir, dirPermissions)
 
@@ -128,6 +230,15 @@ func ConfigFile() (file string, err error) {
 	return
 }
 
+// CF_HOME stands in for the user's home directory, so that separate jobs on one
+// machine can each keep their own config.
+func cfHomeDir() string {
+	if home := os.Getenv("CF_HOME"); home != "" {
+		return home
+	}
+	return userHomeDir()
+}
+
 // See: http://stackoverflow.com/questions/7922270/obtain-users-home-directory
 // we can't cross compile using cgo and use user.Current()
 func userHomeDir() string {
@@ -147,7 +258,9 @@ func defaultConfig() (c *Configuration) {
 	c.Target = ""
 	c.ApiVersion = ""
 	c.AuthorizationEndpoint = ""
-	c.ApplicationStartTimeout = 30 // seconds
+	c.ApplicationStartTimeout = 30       // seconds
+	c.ApplicationUploadTimeout = 15 * 60 // seconds
+	c.HttpTimeout = 120                  // seconds
 
 	return
 }
@@ -169,12 +282,18 @@ func load() (c *Configuration, parseError error) {
 	}
 
 	parseError = json.Unmarshal(data, c)
+	if parseError != nil {
+		return
+	}
 
+	if name := os.Getenv("CF_PROFILE"); name != "" {
+		c.useProfileForSession(name)
+	}
 	return
 }
 
 func saveConfiguration(config *Configuration) (err error) {
-	bytes, err := json.Marshal(config)
+	bytes, err := json.Marshal(config.forDisk())
 	if err != nil {
 		return
 	}
@@ -184,7 +303,37 @@ func saveConfiguration(config *Configuration) (err error) {
 	if err != nil {
 		return
 	}
-	err = ioutil.WriteFile(file, bytes, filePermissions)
+	err = writeFileAtomically(file, bytes)
 
 	return
 }
+
+// writeFileAtomically writes to a temp file next to path and renames it into place,
+// so readers see either the old contents or the new ones and never a partial file.
+func writeFileAtomically(path string, data []byte) (err error) {
+	tempFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path))
+	if err != nil {
+		return
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.Write(data)
+	if err == nil {
+		err = tempFile.Sync()
+	}
+
+	closeErr := tempFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return
+	}
+
+	err = os.Chmod(tempFile.Name(), filePermissions)
+	if err != nil {
+		return
+	}
+
+	return os.Rename(tempFile.Name(), path)
+}
@@ -4,6 +4,8 @@ import (
 	"cf"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 )
 
@@ -127,6 +129,270 @@ func TestClearSession(t *testing.T) {
 	assert.Equal(t, savedConfig.Space, cf.Space{})
 }
 
+func TestClearSessionForgetsClientCredentials(t *testing.T) {
+	repo := NewConfigurationDiskRepository()
+	config := repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	config.AccessToken = "some old access token"
+	config.UAAGrantType = "client_credentials"
+	config.ClientCredentialsId = "ci-client"
+	config.ClientCredentialsSecret = "ci-secret"
+	repo.Save()
+
+	err := repo.ClearSession()
+	assert.NoError(t, err)
+
+	savedConfig, err := repo.Get()
+	assert.NoError(t, err)
+	assert.Empty(t, savedConfig.UAAGrantType)
+	assert.Empty(t, savedConfig.ClientCredentialsId)
+	assert.Empty(t, savedConfig.ClientCredentialsSecret)
+}
+
+func TestSwitchProfile(t *testing.T) {
+	repo := NewConfigurationDiskRepository()
+	config := repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	config.Target = "https://api.staging.example.com"
+	config.AccessToken = "bearer staging_token"
+	config.Organization = cf.Organization{Name: "staging-org"}
+	repo.Save()
+
+	err := repo.SwitchProfile("prod")
+	assert.NoError(t, err)
+	assert.Equal(t, config.ProfileName(), "prod")
+	assert.Empty(t, config.Target)
+	assert.Empty(t, config.AccessToken)
+	assert.Equal(t, config.Organization, cf.Organization{})
+
+	config.Target = "https://api.prod.example.com"
+	repo.Save()
+
+	singleton = nil
+	savedConfig, err := repo.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, savedConfig.ProfileName(), "prod")
+	assert.Equal(t, savedConfig.Target, "https://api.prod.example.com")
+
+	names, err := repo.ListProfiles()
+	assert.NoError(t, err)
+	assert.Equal(t, names, []string{"default", "prod"})
+
+	err = repo.SwitchProfile("default")
+	assert.NoError(t, err)
+	assert.Equal(t, savedConfig.Target, "https://api.staging.example.com")
+	assert.Equal(t, savedConfig.AccessToken, "bearer staging_token")
+	assert.Equal(t, savedConfig.Organization, cf.Organization{Name: "staging-org"})
+}
+
+func TestRenameAndDeleteProfile(t *testing.T) {
+	repo := NewConfigurationDiskRepository()
+	config := repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	config.Target = "https://api.staging.example.com"
+	repo.SwitchProfile("prod")
+
+	err := repo.RenameProfile("default", "prod")
+	assert.Error(t, err)
+
+	err = repo.RenameProfile("default", "staging")
+	assert.NoError(t, err)
+
+	err = repo.RenameProfile("prod", "production")
+	assert.NoError(t, err)
+	assert.Equal(t, config.ProfileName(), "production")
+
+	err = repo.DeleteProfile("production")
+	assert.Error(t, err)
+
+	err = repo.DeleteProfile("staging")
+	assert.NoError(t, err)
+
+	names, err := repo.ListProfiles()
+	assert.NoError(t, err)
+	assert.Equal(t, names, []string{"production"})
+}
+
+func TestSetAndDeleteAlias(t *testing.T) {
+	repo := NewConfigurationDiskRepository()
+	repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	err := repo.SetAlias("deploy", "push -m 512M -i 2")
+	assert.NoError(t, err)
+	err = repo.SetAlias("ls", "apps")
+	assert.NoError(t, err)
+
+	singleton = nil
+	savedConfig, err := repo.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, savedConfig.AliasNames(), []string{"deploy", "ls"})
+	assert.Equal(t, savedConfig.Aliases["deploy"], "push -m 512M -i 2")
+
+	err = repo.DeleteAlias("ls")
+	assert.NoError(t, err)
+
+	singleton = nil
+	savedConfig, err = repo.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, savedConfig.AliasNames(), []string{"deploy"})
+}
+
+func TestProfileFromEnvironmentIsNotSaved(t *testing.T) {
+	repo := NewConfigurationDiskRepository()
+	config := repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	config.Target = "https://api.staging.example.com"
+	repo.SwitchProfile("prod")
+	config.Target = "https://api.prod.example.com"
+	repo.SwitchProfile("default")
+
+	os.Setenv("CF_PROFILE", "prod")
+	defer os.Setenv("CF_PROFILE", "")
+
+	singleton = nil
+	config, err := repo.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, config.ProfileName(), "prod")
+	assert.Equal(t, config.Target, "https://api.prod.example.com")
+
+	config.AccessToken = "bearer prod_token"
+	repo.Save()
+
+	os.Setenv("CF_PROFILE", "")
+	singleton = nil
+	config, err = repo.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, config.ProfileName(), "default")
+	assert.Equal(t, config.Target, "https://api.staging.example.com")
+
+	profile, found := config.FindProfile("prod")
+	assert.True(t, found)
+	assert.Equal(t, profile.AccessToken, "bearer prod_token")
+}
+
+func TestSetOrganizationKeepsChangesSavedByAnotherProcess(t *testing.T) {
+	repo := NewConfigurationDiskRepository()
+	repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	otherProcessConfig := defaultConfig()
+	otherProcessConfig.AccessToken = "bearer refreshed_token"
+	err := saveConfiguration(otherProcessConfig)
+	assert.NoError(t, err)
+
+	org := cf.Organization{Name: "my-org", Guid: "my-org-guid"}
+	err = repo.SetOrganization(org)
+	assert.NoError(t, err)
+
+	singleton = nil
+	savedConfig, err := repo.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, savedConfig.Organization, org)
+	assert.Equal(t, savedConfig.AccessToken, "bearer refreshed_token")
+}
+
+func TestSaveKeepsTokensSavedByAnotherProcess(t *testing.T) {
+	repo := NewConfigurationDiskRepository()
+	config := repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	otherProcessConfig := defaultConfig()
+	otherProcessConfig.AccessToken = "bearer refreshed_token"
+	otherProcessConfig.RefreshToken = "refreshed_refresh_token"
+	err := saveConfiguration(otherProcessConfig)
+	assert.NoError(t, err)
+
+	config.Target = "https://api.example.com"
+	err = repo.Save()
+	assert.NoError(t, err)
+	assert.Equal(t, config.AccessToken, "bearer refreshed_token")
+
+	singleton = nil
+	savedConfig, err := repo.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, savedConfig.Target, "https://api.example.com")
+	assert.Equal(t, savedConfig.AccessToken, "bearer refreshed_token")
+	assert.Equal(t, savedConfig.RefreshToken, "refreshed_refresh_token")
+}
+
+func TestSaveLeavesNoTempFilesAndReleasesTheLock(t *testing.T) {
+	repo := NewConfigurationDiskRepository()
+	repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	err := repo.Save()
+	assert.NoError(t, err)
+
+	file, err := ConfigFile()
+	assert.NoError(t, err)
+
+	tempFiles, err := filepath.Glob(file + "[0-9]*")
+	assert.NoError(t, err)
+	assert.Empty(t, tempFiles)
+
+	lockFile, locked, err := tryLock(file + ".lock")
+	assert.NoError(t, err)
+	assert.True(t, locked)
+	releaseLock(lockFile)
+}
+
+func TestSaveMakesTheConfigFileReadableOnlyByItsOwner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	repo := NewConfigurationDiskRepository()
+	config := repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	file, err := ConfigFile()
+	assert.NoError(t, err)
+	os.Chmod(file, 0644)
+
+	config.ClientCredentialsSecret = "my-secret"
+	err = repo.Save()
+	assert.NoError(t, err)
+
+	fileInfo, err := os.Stat(file)
+	assert.NoError(t, err)
+	assert.Equal(t, fileInfo.Mode().Perm(), os.FileMode(0600))
+}
+
+func TestConfigFileUsesCFHome(t *testing.T) {
+	os.Setenv("CF_HOME", "/some/cf/home")
+	defer os.Setenv("CF_HOME", "")
+
+	assert.Equal(t, cfHomeDir(), "/some/cf/home")
+}
+
+func TestTryLockFailsWhileTheLockIsHeld(t *testing.T) {
+	file, err := ConfigFile()
+	assert.NoError(t, err)
+
+	path := file + ".lock-test"
+	defer os.Remove(path)
+
+	held, locked, err := tryLock(path)
+	assert.NoError(t, err)
+	assert.True(t, locked)
+
+	_, locked, err = tryLock(path)
+	assert.NoError(t, err)
+	assert.False(t, locked)
+
+	releaseLock(held)
+
+	held, locked, err = tryLock(path)
+	assert.NoError(t, err)
+	assert.True(t, locked)
+	releaseLock(held)
+}
+
 func (repo ConfigurationDiskRepository) loadDefaultConfig(t *testing.T) (config *Configuration) {
 	file, err := ConfigFile()
 	assert.NoError(t, err)
@@ -137,6 +403,7 @@ func (repo ConfigurationDiskRepository) loadDefaultConfig(t *testing.T) (config
 		assert.NoError(t, err)
 	}
 
+	singleton = nil
 	config, err = repo.Get()
 	assert.NoError(t, err)
 
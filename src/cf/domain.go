@@ -11,14 +11,16 @@ const (
 	InstanceStarting InstanceState = "starting"
 	InstanceRunning                = "running"
 	InstanceFlapping               = "flapping"
+	InstanceCrashed                = "crashed"
 	InstanceDown                   = "down"
 )
 
 type Organization struct {
-	Name    string
-	Guid    string
-	Spaces  []Space
-	Domains []Domain
+	Name      string
+	Guid      string
+	Spaces    []Space
+	Domains   []Domain
+	QuotaGuid string
 }
 
 type Space struct {
@@ -28,6 +30,7 @@ type Space struct {
 	ServiceInstances []ServiceInstance
 	Organization     Organization
 	Domains          []Domain
+	SpaceQuotaGuid   string
 }
 
 func (space Space) String() string {
@@ -129,6 +132,7 @@ type ServiceInstance struct {
 	ApplicationNames []string
 	Params           map[string]string
 	SysLogDrainUrl   string
+	LastOperation    LastOperation
 }
 
 func (inst ServiceInstance) IsUserProvided() bool {
@@ -139,16 +143,48 @@ func (inst ServiceInstance) ServiceOffering() ServiceOffering {
 	return inst.ServicePlan.ServiceOffering
 }
 
+const (
+	LastOperationInProgress = "in progress"
+	LastOperationSucceeded  = "succeeded"
+	LastOperationFailed     = "failed"
+)
+
+// LastOperation is the state of the latest create or update of a service instance,
+// which brokers may carry out asynchronously
+type LastOperation struct {
+	Type        string
+	State       string
+	Description string
+}
+
+func (op LastOperation) IsInProgress() bool {
+	return op.State == LastOperationInProgress
+}
+
 type ServiceBinding struct {
-	Guid    string
-	Url     string
-	AppGuid string
+	Guid        string
+	Url         string
+	AppGuid     string
+	AppName     string
+	Credentials map[string]interface{}
 }
 
+const UnlimitedQuota = -1
+
 type Quota struct {
-	Guid        string
-	Name        string
-	MemoryLimit uint64 // in Megabytes
+	Guid                    string
+	Name                    string
+	MemoryLimit             uint64 // in Megabytes
+	InstanceMemoryLimit     int64  // in Megabytes
+	RoutesLimit             int
+	ServicesLimit           int
+	NonBasicServicesAllowed bool
+	AppInstanceLimit        int
+}
+
+type SpaceQuota struct {
+	Quota
+	OrgGuid string
 }
 
 type ServiceAuthToken struct {
@@ -0,0 +1,108 @@
+package cf
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+type fingerprint struct {
+	Size    int64
+	ModTime int64
+	Sha1    string
+}
+
+// fingerprintCache remembers the SHA1 of each file in an app directory, keyed by its
+// size and modification time, so that pushing an unchanged app does not rehash it.
+type fingerprintCache struct {
+	file    string
+	appDir  string
+	entries map[string]map[string]fingerprint
+	seen    map[string]fingerprint
+	mutex   *sync.Mutex
+}
+
+// loadFingerprintCache reads the cache from file; with no file nothing is cached.
+func loadFingerprintCache(appDir, file string) (cache *fingerprintCache) {
+	cache = &fingerprintCache{
+		file:    file,
+		entries: map[string]map[string]fingerprint{},
+		seen:    map[string]fingerprint{},
+		mutex:   &sync.Mutex{},
+	}
+
+	cache.appDir, _ = filepath.Abs(appDir)
+
+	if cache.file == "" {
+		return
+	}
+
+	data, err := ioutil.ReadFile(cache.file)
+	if err != nil {
+		return
+	}
+
+	json.Unmarshal(data, &cache.entries)
+	return
+}
+
+func (cache *fingerprintCache) lookup(fileName string, info os.FileInfo) (sha1 string, found bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry, found := cache.entries[cache.appDir][fileName]
+	if !found || entry.Size != info.Size() || entry.ModTime != info.ModTime().UnixNano() {
+		found = false
+		return
+	}
+
+	cache.seen[fileName] = entry
+	sha1 = entry.Sha1
+	return
+}
+
+func (cache *fingerprintCache) store(fileName string, info os.FileInfo, sha1 string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.seen[fileName] = fingerprint{
+		Size:    info.Size(),
+		ModTime: info.ModTime().UnixNano(),
+		Sha1:    sha1,
+	}
+}
+
+// save keeps only the files seen this time for the app directory, and is best effort:
+// a cache that cannot be written only costs a rehash on the next push.
+func (cache *fingerprintCache) save() {
+	if cache.file == "" {
+		return
+	}
+
+	cache.entries[cache.appDir] = cache.seen
+	data, err := json.Marshal(cache.entries)
+	if err != nil {
+		return
+	}
+
+	tempFile, err := ioutil.TempFile(filepath.Dir(cache.file), filepath.Base(cache.file))
+	if err != nil {
+		return
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.Write(data)
+	tempFile.Close()
+	if err != nil {
+		return
+	}
+
+	err = os.Chmod(tempFile.Name(), 0600)
+	if err != nil {
+		return
+	}
+
+	os.Rename(tempFile.Name(), cache.file)
+}
@@ -6,7 +6,10 @@ const (
 	ORG_EXISTS                  = "30002"
 	SPACE_EXISTS                = "40002"
 	SERVICE_INSTANCE_NAME_TAKEN = "60002"
+	APP_ALREADY_BOUND           = "90003"
 	APP_NOT_STAGED              = "170002"
 	APP_STOPPED                 = "220001"
+	QUOTA_EXISTS                = "240002"
 	BUILDPACK_EXISTS            = "290001"
+	SPACE_QUOTA_EXISTS          = "310001"
 )
@@ -0,0 +1,204 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/fraenkel/candiedyaml"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const FileName = "manifest.yml"
+
+type Manifest struct {
+	Applications []Application
+}
+
+type Application struct {
+	Name            string
+	Instances       int
+	Memory          string
+	BuildpackUrl    string
+	Command         string
+	Domain          string
+	Host            string
+	StackName       string
+	Path            string
+	EnvironmentVars map[string]string
+	Services        []string
+}
+
+type ManifestRepository interface {
+	ReadManifest(path string) (manifest *Manifest, err error)
+}
+
+type ManifestDiskRepository struct{}
+
+func NewManifestDiskRepository() (repo ManifestDiskRepository) {
+	return ManifestDiskRepository{}
+}
+
+func (repo ManifestDiskRepository) ReadManifest(path string) (manifest *Manifest, err error) {
+	path, err = manifestPath(path)
+	if err != nil {
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	manifest, err = Parse(file)
+	if err != nil {
+		return
+	}
+
+	manifest.resolvePaths(filepath.Dir(path))
+	return
+}
+
+func manifestPath(path string) (manifestPath string, err error) {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+
+	manifestPath = path
+	if fileInfo.IsDir() {
+		manifestPath = filepath.Join(path, FileName)
+	}
+	return
+}
+
+type manifestDocument struct {
+	Applications []map[string]interface{}
+}
+
+func Parse(reader io.Reader) (manifest *Manifest, err error) {
+	document := new(manifestDocument)
+	err = candiedyaml.NewDecoder(reader).Decode(document)
+	if err != nil {
+		return
+	}
+
+	manifest = new(Manifest)
+	for _, fields := range document.Applications {
+		var app Application
+		app, err = newApplication(fields)
+		if err != nil {
+			return
+		}
+		manifest.Applications = append(manifest.Applications, app)
+	}
+	return
+}
+
+func newApplication(fields map[string]interface{}) (app Application, err error) {
+	app.Name = stringValue(fields["name"])
+	app.Memory = stringValue(fields["memory"])
+	app.BuildpackUrl = stringValue(fields["buildpack"])
+	app.Command = stringValue(fields["command"])
+	app.Domain = stringValue(fields["domain"])
+	app.Host = stringValue(fields["host"])
+	app.StackName = stringValue(fields["stack"])
+	app.Path = stringValue(fields["path"])
+
+	app.Instances, err = intValue(fields["instances"])
+	if err != nil {
+		err = fmt.Errorf("Invalid value for instances in application %s: %s", app.Name, err.Error())
+		return
+	}
+
+	app.EnvironmentVars, err = stringMapValue(fields["env"])
+	if err != nil {
+		err = fmt.Errorf("Invalid value for env in application %s: %s", app.Name, err.Error())
+		return
+	}
+
+	app.Services, err = stringSliceValue(fields["services"])
+	if err != nil {
+		err = fmt.Errorf("Invalid value for services in application %s: %s", app.Name, err.Error())
+	}
+	return
+}
+
+func stringValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+func intValue(value interface{}) (number int, err error) {
+	switch value := value.(type) {
+	case nil:
+	case int:
+		number = value
+	case int64:
+		number = int(value)
+	case string:
+		number, err = strconv.Atoi(value)
+	default:
+		err = errors.New("expected a number")
+	}
+	return
+}
+
+func stringMapValue(value interface{}) (stringMap map[string]string, err error) {
+	switch value := value.(type) {
+	case nil:
+	case map[interface{}]interface{}:
+		stringMap = map[string]string{}
+		for key, val := range value {
+			stringMap[stringValue(key)] = stringValue(val)
+		}
+	case map[string]interface{}:
+		stringMap = map[string]string{}
+		for key, val := range value {
+			stringMap[key] = stringValue(val)
+		}
+	default:
+		err = errors.New("expected a map")
+	}
+	return
+}
+
+// stringSliceValue returns nil for a missing key but an empty slice for an
+// empty list, so that "services: []" can be told apart from no services key.
+func stringSliceValue(value interface{}) (values []string, err error) {
+	switch value := value.(type) {
+	case nil:
+	case []interface{}:
+		values = make([]string, 0, len(value))
+		for _, val := range value {
+			values = append(values, stringValue(val))
+		}
+	default:
+		err = errors.New("expected a list")
+	}
+	return
+}
+
+func (manifest *Manifest) resolvePaths(baseDir string) {
+	for index, app := range manifest.Applications {
+		if app.Path == "" || filepath.IsAbs(app.Path) {
+			continue
+		}
+		manifest.Applications[index].Path = filepath.Join(baseDir, app.Path)
+	}
+}
+
+func (manifest *Manifest) FindApplication(name string) (app Application, found bool) {
+	for _, app = range manifest.Applications {
+		if app.Name == name {
+			found = true
+			return
+		}
+	}
+	app = Application{}
+	return
+}
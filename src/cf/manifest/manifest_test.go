@@ -0,0 +1,138 @@
+package manifest
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParsingManifest(t *testing.T) {
+	manifest, err := Parse(strings.NewReader(`---
+applications:
+- name: my-app
+  memory: 256M
+  instances: 3
+  buildpack: https://example.com/buildpack.git
+  command: ./start.sh
+  domain: example.com
+  host: my-host
+  stack: lucid64
+  path: ./app
+  env:
+    FOO: bar
+    COUNT: 2
+  services:
+  - my-db
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, len(manifest.Applications), 1)
+
+	app := manifest.Applications[0]
+	assert.Equal(t, app.Name, "my-app")
+	assert.Equal(t, app.Memory, "256M")
+	assert.Equal(t, app.Instances, 3)
+	assert.Equal(t, app.BuildpackUrl, "https://example.com/buildpack.git")
+	assert.Equal(t, app.Command, "./start.sh")
+	assert.Equal(t, app.Domain, "example.com")
+	assert.Equal(t, app.Host, "my-host")
+	assert.Equal(t, app.StackName, "lucid64")
+	assert.Equal(t, app.Path, "./app")
+	assert.Equal(t, app.EnvironmentVars, map[string]string{"FOO": "bar", "COUNT": "2"})
+	assert.Equal(t, app.Services, []string{"my-db"})
+}
+
+func TestParsingManifestWithNumericMemory(t *testing.T) {
+	manifest, err := Parse(strings.NewReader(`---
+applications:
+- name: my-app
+  memory: 512
+  instances: "2"
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.Applications[0].Memory, "512")
+	assert.Equal(t, manifest.Applications[0].Instances, 2)
+}
+
+func TestParsingInvalidManifest(t *testing.T) {
+	_, err := Parse(strings.NewReader("applications: [:"))
+	assert.Error(t, err)
+
+	_, err = Parse(strings.NewReader(`---
+applications:
+- name: my-app
+  instances: lots
+`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "instances")
+
+	_, err = Parse(strings.NewReader(`---
+applications:
+- name: my-app
+  services: my-db
+`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "services")
+}
+
+func TestReadingManifestFromFile(t *testing.T) {
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	manifestDir := filepath.Join(cwd, "../../fixtures/manifests")
+
+	repo := NewManifestDiskRepository()
+	manifest, err := repo.ReadManifest(filepath.Join(manifestDir, "multiple-apps.yml"))
+	assert.NoError(t, err)
+	assert.Equal(t, len(manifest.Applications), 2)
+
+	assert.Equal(t, manifest.Applications[0].Name, "app1")
+	assert.Equal(t, manifest.Applications[0].Path, filepath.Join(cwd, "../../fixtures/example-app"))
+	assert.Equal(t, manifest.Applications[0].Services, []string{"app1-db", "app1-cache"})
+
+	assert.Equal(t, manifest.Applications[1].Name, "app2")
+	assert.Equal(t, manifest.Applications[1].Path, "/var/apps/app2")
+}
+
+func TestReadingManifestFromDirectory(t *testing.T) {
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+
+	repo := NewManifestDiskRepository()
+	manifest, err := repo.ReadManifest(filepath.Join(cwd, "../../fixtures/example-app"))
+	assert.NoError(t, err)
+	assert.Equal(t, len(manifest.Applications), 1)
+	assert.Equal(t, manifest.Applications[0].Name, "hello")
+	assert.Equal(t, manifest.Applications[0].Path, filepath.Join(cwd, "../../fixtures/example-app"))
+}
+
+func TestReadingManifestThatDoesNotExist(t *testing.T) {
+	repo := NewManifestDiskRepository()
+	_, err := repo.ReadManifest("/this/path/does/not/exist/manifest.yml")
+	assert.Error(t, err)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFindingApplicationInManifest(t *testing.T) {
+	manifest := Manifest{Applications: []Application{{Name: "app1"}, {Name: "app2"}}}
+
+	app, found := manifest.FindApplication("app2")
+	assert.True(t, found)
+	assert.Equal(t, app.Name, "app2")
+
+	_, found = manifest.FindApplication("app3")
+	assert.False(t, found)
+}
+
+func TestParsingManifestDistinguishesEmptyServicesFromMissingServices(t *testing.T) {
+	manifest, err := Parse(strings.NewReader(`---
+applications:
+- name: app-without-services
+  services: []
+- name: app-with-unspecified-services
+`))
+	assert.NoError(t, err)
+	assert.NotNil(t, manifest.Applications[0].Services)
+	assert.Equal(t, len(manifest.Applications[0].Services), 0)
+	assert.Nil(t, manifest.Applications[1].Services)
+}
@@ -19,6 +19,7 @@ func TestCloudControllerGatewayErrorHandling(t *testing.T) {
 	gateway := NewCloudControllerGateway()
 
 	ts := httptest.NewTLSServer(http.HandlerFunc(failingCloudControllerRequest))
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	defer ts.Close()
 
 	request, apiResponse := gateway.NewRequest("GET", ts.URL, "TOKEN", nil)
@@ -41,6 +42,7 @@ func TestCloudControllerGatewayInvalidTokenHandling(t *testing.T) {
 	gateway := NewCloudControllerGateway()
 
 	ts := httptest.NewTLSServer(http.HandlerFunc(invalidTokenCloudControllerRequest))
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	defer ts.Close()
 
 	request, apiResponse := gateway.NewRequest("GET", ts.URL, "TOKEN", nil)
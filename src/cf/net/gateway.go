@@ -1,18 +1,28 @@
 package net
 
 import (
+	"bytes"
 	"cf"
+	"cf/configuration"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"net/http"
 	"os"
 	"runtime"
+	"strconv"
+	"time"
 )
 
 const INVALID_TOKEN_CODE = "GATEWAY INVALID TOKEN CODE"
 
+// Tokens this close to expiring are refreshed before a request is sent, so
+// that they don't run out while a long request is still being sent.
+const tokenRefreshMargin = 5 * time.Minute
+
 type errorResponse struct {
 	Code        string
 	Description string
@@ -27,15 +37,25 @@ type tokenRefresher interface {
 type Request struct {
 	HttpReq      *http.Request
 	SeekableBody io.ReadSeeker
+
+	// set by callers that retry the request themselves
+	NoRetries bool
+
+	// set for requests the server may take longer than the timeout to answer
+	NoResponseTimeout bool
 }
 
 type Gateway struct {
 	authenticator tokenRefresher
 	errHandler    errorHandler
+	config        *configuration.Configuration
+	trustedCerts  []tls.Certificate
+	clients       *httpClients
 }
 
 func newGateway(errHandler errorHandler) (gateway Gateway) {
 	gateway.errHandler = errHandler
+	gateway.clients = newHttpClients()
 	return
 }
 
@@ -43,6 +63,17 @@ func (gateway *Gateway) SetTokenRefresher(auth tokenRefresher) {
 	gateway.authenticator = auth
 }
 
+// SetConfiguration lets the gateway follow the SSL validation choice saved in config.
+func (gateway *Gateway) SetConfiguration(config *configuration.Configuration) {
+	gateway.config = config
+}
+
+// SetTrustedCerts adds certificates to verify servers against, on top of the system roots.
+func (gateway *Gateway) SetTrustedCerts(certs []tls.Certificate) {
+	gateway.trustedCerts = certs
+	gateway.clients = newHttpClients()
+}
+
 func (gateway Gateway) GetResource(url, accessToken string, resource interface{}) (apiResponse ApiResponse) {
 	request, apiResponse := gateway.NewRequest("GET", url, accessToken, nil)
 	if apiResponse.IsNotSuccessful() {
@@ -53,6 +84,34 @@ func (gateway Gateway) GetResource(url, accessToken string, resource interface{}
 	return
 }
 
+// ListPaginatedResources follows next_url from path onwards, handing each resource to cb
+// as its page arrives. Returning false from cb stops before the next resource is handled.
+func (gateway Gateway) ListPaginatedResources(target, accessToken, path string, resource interface{}, cb func(interface{}) bool) (apiResponse ApiResponse) {
+	for path != "" {
+		pagination := NewPaginatedResources(resource)
+
+		apiResponse = gateway.GetResource(fmt.Sprintf("%s%s", target, path), accessToken, &pagination)
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+
+		resources, err := pagination.Resources()
+		if err != nil {
+			apiResponse = NewApiResponseWithError("Invalid JSON response from server", err)
+			return
+		}
+
+		for _, resource := range resources {
+			if !cb(resource) {
+				return
+			}
+		}
+
+		path = pagination.NextURL
+	}
+	return
+}
+
 func (gateway Gateway) CreateResource(url, accessToken string, body io.ReadSeeker) (apiResponse ApiResponse) {
 	return gateway.createUpdateOrDeleteResource("POST", url, accessToken, body, nil)
 }
@@ -126,6 +185,10 @@ func (gateway Gateway) PerformRequest(request *Request) (apiResponse ApiResponse
 	return
 }
 
+func (gateway Gateway) PerformRequestForResponse(request *Request) (rawResponse *http.Response, apiResponse ApiResponse) {
+	return gateway.doRequestHandlingAuth(request)
+}
+
 func (gateway Gateway) PerformRequestForResponseBytes(request *Request) (bytes []byte, headers http.Header, apiResponse ApiResponse) {
 	rawResponse, apiResponse := gateway.doRequestHandlingAuth(request)
 	if apiResponse.IsNotSuccessful() {
@@ -163,6 +226,11 @@ func (gateway Gateway) PerformRequestForJSONResponse(request *Request, response
 func (gateway Gateway) doRequestHandlingAuth(request *Request) (rawResponse *http.Response, apiResponse ApiResponse) {
 	httpReq := request.HttpReq
 
+	apiResponse = gateway.refreshExpiringToken(request)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
 	// perform request
 	rawResponse, apiResponse = gateway.doRequestAndHandlerError(request)
 	if apiResponse.IsSuccessful() || gateway.authenticator == nil {
@@ -191,15 +259,72 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (rawResponse *htt
 	return
 }
 
+// refreshExpiringToken swaps an access token that is about to expire for the
+// one in config, refreshing that first if it is about to expire as well.
+func (gateway Gateway) refreshExpiringToken(request *Request) (apiResponse ApiResponse) {
+	if gateway.authenticator == nil || gateway.config == nil {
+		return
+	}
+
+	if !tokenIsExpiring(request.HttpReq.Header.Get("Authorization")) {
+		return
+	}
+
+	token, apiResponse := gateway.AccessToken()
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	request.HttpReq.Header.Set("Authorization", token)
+	return
+}
+
+// AccessToken returns the access token in config, refreshing it first if it is
+// about to expire. Connections that don't go through PerformRequest, such as
+// the logs websocket, use it to send a token that is still valid.
+func (gateway Gateway) AccessToken() (token string, apiResponse ApiResponse) {
+	if gateway.config == nil {
+		return
+	}
+
+	token = gateway.config.AccessToken
+	if gateway.authenticator != nil && tokenIsExpiring(token) {
+		token, apiResponse = gateway.authenticator.RefreshAuthToken()
+	}
+	return
+}
+
+func tokenIsExpiring(token string) bool {
+	expiry := configuration.TokenExpiry(token)
+	return !expiry.IsZero() && time.Now().Add(tokenRefreshMargin).After(expiry)
+}
+
 func (gateway Gateway) doRequestAndHandlerError(request *Request) (rawResponse *http.Response, apiResponse ApiResponse) {
-	rawResponse, err := doRequest(request.HttpReq)
+	sslDisabled := gateway.config != nil && gateway.config.SSLDisabled
+	httpClient, err := gateway.clients.get(gateway.trustedCerts, sslDisabled, gateway.timeout(), !request.NoResponseTimeout)
+	if err != nil {
+		apiResponse = NewApiResponseWithError("Error loading SSL certificates", err)
+		return
+	}
+
+	rawResponse, err = gateway.doRequestWithRetries(request, httpClient)
+	if IsInvalidSSLCertError(err) {
+		apiResponse = NewInvalidSSLCertApiResponse(request.HttpReq.URL.Scheme + "://" + request.HttpReq.URL.Host)
+		return
+	}
 	if err != nil {
 		apiResponse = NewApiResponseWithError("Error performing request", err)
 		return
 	}
 
 	if rawResponse.StatusCode > 299 {
+		// the error handler consumes the body, so keep a copy for callers that want it raw
+		body, _ := ioutil.ReadAll(rawResponse.Body)
+		rawResponse.Body.Close()
+		rawResponse.Body = ioutil.NopCloser(bytes.NewReader(body))
 		errorResponse := gateway.errHandler(rawResponse)
+		rawResponse.Body = ioutil.NopCloser(bytes.NewReader(body))
+
 		message := fmt.Sprintf(
 			"Server error, status code: %d, error code: %s, message: %s",
 			rawResponse.StatusCode,
@@ -210,3 +335,102 @@ func (gateway Gateway) doRequestAndHandlerError(request *Request) (rawResponse *
 	}
 	return
 }
+
+const (
+	defaultHttpRetries = 3
+	defaultHttpTimeout = 120 // seconds
+)
+
+var retryBaseDelay = 250 * time.Millisecond
+
+// without a seed every cf process would wait the same random delays
+func init() {
+	rand.Seed(time.Now().UnixNano())
+}
+
+// doRequestWithRetries sends idempotent requests again when the connection
+// failed or the server had a problem, waiting about twice as long each time.
+func (gateway Gateway) doRequestWithRetries(request *Request, httpClient *http.Client) (rawResponse *http.Response, err error) {
+	attempts := 1
+	if isIdempotent(request.HttpReq.Method) && !request.NoRetries {
+		attempts += gateway.retries()
+	}
+
+	for attempt := 1; ; attempt++ {
+		rawResponse, err = doRequest(request.HttpReq, httpClient)
+
+		var reason string
+		switch {
+		case err != nil && !IsInvalidSSLCertError(err):
+			reason = err.Error()
+		case err == nil && rawResponse.StatusCode >= 500:
+			reason = rawResponse.Status
+		}
+
+		if reason == "" || attempt == attempts {
+			return
+		}
+
+		if rawResponse != nil {
+			ioutil.ReadAll(rawResponse.Body)
+			rawResponse.Body.Close()
+		}
+
+		delay := retryDelay(attempt)
+		if TraceEnabled() {
+			TraceRetry(request.HttpReq, reason, delay, attempt+1, attempts)
+		}
+		time.Sleep(delay)
+
+		if request.SeekableBody != nil {
+			request.SeekableBody.Seek(0, 0)
+			request.HttpReq.Body = ioutil.NopCloser(request.SeekableBody)
+		}
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// retryDelay doubles with every attempt, plus up to half again at random so
+// that many clients retrying at once don't all hit the server together.
+func retryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay << uint(attempt-1)
+	return delay + time.Duration(rand.Int63n(int64(delay)/2+1))
+}
+
+// CF_HTTP_RETRIES wins over the retries in the config file
+func (gateway Gateway) retries() int {
+	retries, err := strconv.Atoi(os.Getenv("CF_HTTP_RETRIES"))
+	if err == nil && retries >= 0 {
+		return retries
+	}
+
+	if gateway.config != nil && gateway.config.HttpRetries != nil && *gateway.config.HttpRetries >= 0 {
+		return *gateway.config.HttpRetries
+	}
+	return defaultHttpRetries
+}
+
+// CF_HTTP_TIMEOUT, in seconds, wins over the timeout in the config file
+func (gateway Gateway) timeout() time.Duration {
+	var timeout time.Duration
+	if gateway.config != nil {
+		timeout = gateway.config.HttpTimeout
+	}
+
+	seconds, err := strconv.Atoi(os.Getenv("CF_HTTP_TIMEOUT"))
+	if err == nil {
+		timeout = time.Duration(seconds)
+	}
+
+	if timeout <= 0 {
+		timeout = defaultHttpTimeout
+	}
+	return timeout * time.Second
+}
@@ -5,8 +5,12 @@ import (
 	"cf/api"
 	"cf/configuration"
 	. "cf/net"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -16,6 +20,7 @@ import (
 	testconfig "testhelpers/configuration"
 	testnet "testhelpers/net"
 	"testing"
+	"time"
 )
 
 func TestNewRequest(t *testing.T) {
@@ -142,6 +147,7 @@ func testRefreshToken(t *testing.T, gateway Gateway, endpoint http.HandlerFunc)
 
 	config, auth := createAuthenticationRepository(t, apiServer, authServer)
 	gateway.SetTokenRefresher(auth)
+	gateway.SetTrustedCerts(apiServer.TLS.Certificates)
 
 	request, apiResponse := gateway.NewRequest("POST", config.Target+"/v2/foo", config.AccessToken, strings.NewReader("expected body"))
 	assert.False(t, apiResponse.IsNotSuccessful())
@@ -162,7 +168,352 @@ func createAuthenticationRepository(t *testing.T, apiServer *httptest.Server, au
 	config.RefreshToken = "initial-refresh-token"
 
 	authGateway := NewUAAGateway()
+	authGateway.SetTrustedCerts(authServer.TLS.Certificates)
 	authenticator := api.NewUAAAuthenticationRepository(authGateway, configRepo)
 
 	return config, authenticator
 }
+
+func TestRequestToServerWithUntrustedCertificateFails(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+	defer ts.Close()
+
+	gateway := NewCloudControllerGateway()
+	request, apiResponse := gateway.NewRequest("GET", ts.URL+"/v2/apps", "BEARER my-access-token", nil)
+	assert.False(t, apiResponse.IsNotSuccessful())
+
+	apiResponse = gateway.PerformRequest(request)
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, apiResponse.ErrorCode, INVALID_SSL_CERT_CODE)
+	assert.Contains(t, apiResponse.Message, "Invalid SSL Cert for "+ts.URL)
+	assert.Contains(t, apiResponse.Message, "--skip-ssl-validation")
+}
+
+func TestRequestToServerWithUntrustedCertificateWhenSSLIsDisabled(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+	defer ts.Close()
+
+	gateway := NewCloudControllerGateway()
+	gateway.SetConfiguration(&configuration.Configuration{SSLDisabled: true})
+	request, apiResponse := gateway.NewRequest("GET", ts.URL+"/v2/apps", "BEARER my-access-token", nil)
+	assert.False(t, apiResponse.IsNotSuccessful())
+
+	apiResponse = gateway.PerformRequest(request)
+	assert.False(t, apiResponse.IsNotSuccessful())
+}
+
+func TestNewTLSConfigWithMissingCACertsFile(t *testing.T) {
+	os.Setenv("CF_CA_CERTS", "/does/not/exist.pem")
+	defer os.Setenv("CF_CA_CERTS", "")
+
+	_, err := NewTLSConfig(nil, false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "/does/not/exist.pem")
+}
+
+func TestTrustedCertsAreAddedToTheSystemRoots(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+	defer ts.Close()
+
+	systemPool, err := x509.SystemCertPool()
+	if err != nil {
+		return
+	}
+	systemPool.AddCert(ts.Certificate())
+
+	tlsConfig, err := NewTLSConfig(ts.TLS.Certificates, false)
+	assert.NoError(t, err)
+	assert.True(t, tlsConfig.RootCAs.Equal(systemPool))
+}
+
+func TestRequestToServerTrustedByCACertsFile(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+	defer ts.Close()
+
+	caCertsFile, err := ioutil.TempFile("", "ca-certs")
+	assert.NoError(t, err)
+	defer os.Remove(caCertsFile.Name())
+	pem.Encode(caCertsFile, &pem.Block{Type: "CERTIFICATE", Bytes: ts.TLS.Certificates[0].Certificate[0]})
+	caCertsFile.Close()
+
+	os.Setenv("CF_CA_CERTS", caCertsFile.Name())
+	defer os.Setenv("CF_CA_CERTS", "")
+
+	tlsConfig, err := NewTLSConfig(nil, false)
+	assert.NoError(t, err)
+	assert.NotNil(t, tlsConfig.RootCAs)
+
+	gateway := NewCloudControllerGateway()
+	request, apiResponse := gateway.NewRequest("GET", ts.URL+"/v2/apps", "BEARER my-access-token", nil)
+	assert.False(t, apiResponse.IsNotSuccessful())
+
+	apiResponse = gateway.PerformRequest(request)
+	assert.False(t, apiResponse.IsNotSuccessful())
+}
+
+func tokenExpiringAt(expiry time.Time) string {
+	claims := fmt.Sprintf(`{"user_name":"user@example.com","exp":%d}`, expiry.Unix())
+	return "bearer eyJhbGciOiJSUzI1NiJ9." + base64.StdEncoding.EncodeToString([]byte(claims)) + ".signature"
+}
+
+func TestExpiringTokenIsRefreshedBeforeTheRequest(t *testing.T) {
+	apiRequests := 0
+	apiEndpoint := func(writer http.ResponseWriter, request *http.Request) {
+		apiRequests++
+		if request.Header.Get("Authorization") != "bearer new-access-token" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintln(writer, `{ "code": 1000, "description": "Auth token is invalid" }`)
+		}
+	}
+	authEndpoint := func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprintln(writer, `{ "access_token": "new-access-token", "token_type": "bearer", "refresh_token": "new-refresh-token"}`)
+	}
+
+	apiServer := httptest.NewTLSServer(http.HandlerFunc(apiEndpoint))
+	defer apiServer.Close()
+	authServer := httptest.NewTLSServer(http.HandlerFunc(authEndpoint))
+	defer authServer.Close()
+
+	config, auth := createAuthenticationRepository(t, apiServer, authServer)
+	config.AccessToken = tokenExpiringAt(time.Now().Add(time.Minute))
+
+	gateway := NewCloudControllerGateway()
+	gateway.SetConfiguration(config)
+	gateway.SetTokenRefresher(auth)
+	gateway.SetTrustedCerts(apiServer.TLS.Certificates)
+
+	request, apiResponse := gateway.NewRequest("GET", config.Target+"/v2/foo", config.AccessToken, nil)
+	assert.False(t, apiResponse.IsNotSuccessful())
+
+	apiResponse = gateway.PerformRequest(request)
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, apiRequests, 1)
+	assert.Equal(t, testconfig.SavedConfiguration.AccessToken, "bearer new-access-token")
+}
+
+func TestTokenThatIsNotExpiringIsNotRefreshed(t *testing.T) {
+	validToken := tokenExpiringAt(time.Now().Add(time.Hour))
+	apiEndpoint := func(writer http.ResponseWriter, request *http.Request) {
+		if request.Header.Get("Authorization") != validToken {
+			writer.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+	authEndpoint := func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusInternalServerError)
+	}
+
+	apiServer := httptest.NewTLSServer(http.HandlerFunc(apiEndpoint))
+	defer apiServer.Close()
+	authServer := httptest.NewTLSServer(http.HandlerFunc(authEndpoint))
+	defer authServer.Close()
+
+	config, auth := createAuthenticationRepository(t, apiServer, authServer)
+	config.AccessToken = validToken
+
+	gateway := NewCloudControllerGateway()
+	gateway.SetConfiguration(config)
+	gateway.SetTokenRefresher(auth)
+	gateway.SetTrustedCerts(apiServer.TLS.Certificates)
+
+	request, apiResponse := gateway.NewRequest("GET", config.Target+"/v2/foo", config.AccessToken, nil)
+	assert.False(t, apiResponse.IsNotSuccessful())
+
+	apiResponse = gateway.PerformRequest(request)
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
+func TestFailingToRefreshAnExpiringTokenIsReported(t *testing.T) {
+	apiRequests := 0
+	apiEndpoint := func(writer http.ResponseWriter, request *http.Request) {
+		apiRequests++
+	}
+	authEndpoint := func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintln(writer, `{ "error": "invalid_token", "error_description": "Refresh token expired" }`)
+	}
+
+	apiServer := httptest.NewTLSServer(http.HandlerFunc(apiEndpoint))
+	defer apiServer.Close()
+	authServer := httptest.NewTLSServer(http.HandlerFunc(authEndpoint))
+	defer authServer.Close()
+
+	config, auth := createAuthenticationRepository(t, apiServer, authServer)
+	config.AccessToken = tokenExpiringAt(time.Now().Add(-time.Minute))
+
+	gateway := NewCloudControllerGateway()
+	gateway.SetConfiguration(config)
+	gateway.SetTokenRefresher(auth)
+	gateway.SetTrustedCerts(apiServer.TLS.Certificates)
+
+	request, apiResponse := gateway.NewRequest("GET", config.Target+"/v2/foo", config.AccessToken, nil)
+	assert.False(t, apiResponse.IsNotSuccessful())
+
+	apiResponse = gateway.PerformRequest(request)
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Contains(t, apiResponse.Message, "Error refreshing auth token")
+	assert.Contains(t, apiResponse.Message, "Not logged in")
+	assert.Equal(t, apiRequests, 0)
+}
+
+func TestIdempotentRequestsAreRetriedOnServerErrors(t *testing.T) {
+	requests := 0
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		body, _ := ioutil.ReadAll(request.Body)
+		if string(body) != "expected body" {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if requests == 1 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	apiResponse := performRequestTo(ts, "PUT", strings.NewReader("expected body"))
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, requests, 2)
+}
+
+func TestIdempotentRequestsAreRetriedOnNetworkErrors(t *testing.T) {
+	requests := 0
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		if requests == 1 {
+			conn, _, err := writer.(http.Hijacker).Hijack()
+			assert.NoError(t, err)
+			conn.Close()
+		}
+	}))
+	defer ts.Close()
+
+	apiResponse := performRequestTo(ts, "GET", nil)
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, requests, 2)
+}
+
+func TestRetriesGiveUpAfterCFHttpRetries(t *testing.T) {
+	os.Setenv("CF_HTTP_RETRIES", "1")
+	defer os.Setenv("CF_HTTP_RETRIES", "")
+
+	requests := 0
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		writer.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	apiResponse := performRequestTo(ts, "DELETE", nil)
+
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, apiResponse.StatusCode, http.StatusBadGateway)
+	assert.Equal(t, requests, 2)
+}
+
+func TestRetriesCanBeTurnedOffInTheConfig(t *testing.T) {
+	requests := 0
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		writer.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	retries := 0
+	gateway := NewCloudControllerGateway()
+	gateway.SetConfiguration(&configuration.Configuration{HttpRetries: &retries})
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
+
+	request, apiResponse := gateway.NewRequest("GET", ts.URL+"/v2/foo", "BEARER my-access-token", nil)
+	assert.False(t, apiResponse.IsNotSuccessful())
+
+	apiResponse = gateway.PerformRequest(request)
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, requests, 1)
+}
+
+func TestNonIdempotentRequestsAreNotRetried(t *testing.T) {
+	requests := 0
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	apiResponse := performRequestTo(ts, "POST", strings.NewReader("expected body"))
+
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, requests, 1)
+}
+
+func TestRequestsTimeOutAfterCFHttpTimeout(t *testing.T) {
+	os.Setenv("CF_HTTP_TIMEOUT", "1")
+	os.Setenv("CF_HTTP_RETRIES", "0")
+	defer os.Setenv("CF_HTTP_TIMEOUT", "")
+	defer os.Setenv("CF_HTTP_RETRIES", "")
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		time.Sleep(2 * time.Second)
+	}))
+	defer ts.Close()
+
+	apiResponse := performRequestTo(ts, "GET", nil)
+
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Contains(t, apiResponse.Message, "Error performing request")
+}
+
+func TestRequestsWithoutResponseTimeoutWaitForTheServer(t *testing.T) {
+	os.Setenv("CF_HTTP_TIMEOUT", "1")
+	defer os.Setenv("CF_HTTP_TIMEOUT", "")
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		time.Sleep(2 * time.Second)
+	}))
+	defer ts.Close()
+
+	gateway := NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
+
+	request, apiResponse := gateway.NewRequest("PUT", ts.URL+"/v2/foo", "BEARER my-access-token", nil)
+	assert.False(t, apiResponse.IsNotSuccessful())
+	request.NoResponseTimeout = true
+
+	apiResponse = gateway.PerformRequest(request)
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
+func TestConnectionsAreReusedBetweenRequests(t *testing.T) {
+	clientAddrs := map[string]bool{}
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		clientAddrs[request.RemoteAddr] = true
+		fmt.Fprintln(writer, `{}`)
+	}))
+	defer ts.Close()
+
+	gateway := NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
+
+	for i := 0; i < 3; i++ {
+		request, apiResponse := gateway.NewRequest("GET", ts.URL+"/v2/foo", "BEARER my-access-token", nil)
+		assert.False(t, apiResponse.IsNotSuccessful())
+
+		_, _, apiResponse = gateway.PerformRequestForResponseBytes(request)
+		assert.True(t, apiResponse.IsSuccessful())
+	}
+
+	assert.Equal(t, len(clientAddrs), 1)
+}
+
+func performRequestTo(ts *httptest.Server, method string, body io.ReadSeeker) (apiResponse ApiResponse) {
+	gateway := NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
+
+	request, apiResponse := gateway.NewRequest(method, ts.URL+"/v2/foo", "BEARER my-access-token", body)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	return gateway.PerformRequest(request)
+}
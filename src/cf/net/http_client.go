@@ -1,25 +1,41 @@
 package net
 
 import (
+	"cf"
 	"cf/terminal"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	gonet "net"
 	"net/http"
 	"net/http/httputil"
 	"os"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
 	PRIVATE_DATA_PLACEHOLDER = "[PRIVATE DATA HIDDEN]"
 )
 
-func newHttpClient() *http.Client {
+const INVALID_SSL_CERT_CODE = "INVALID SSL CERT"
+
+// newHttpClient gives up on connecting after timeout, and on waiting for the
+// response headers too when responseTimeout is set.
+func newHttpClient(tlsConfig *tls.Config, timeout time.Duration, responseTimeout bool) *http.Client {
 	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		TLSClientConfig: tlsConfig,
 		Proxy:           http.ProxyFromEnvironment,
+		Dial: func(network, addr string) (gonet.Conn, error) {
+			return gonet.DialTimeout(network, addr, timeout)
+		},
+	}
+	if responseTimeout {
+		tr.ResponseHeaderTimeout = timeout
 	}
 	return &http.Client{
 		Transport:     tr,
@@ -27,6 +43,104 @@ func newHttpClient() *http.Client {
 	}
 }
 
+type httpClientKey struct {
+	sslDisabled     bool
+	timeout         time.Duration
+	responseTimeout bool
+}
+
+// httpClients keeps one client for each SSL and timeout setting, so that
+// connections are kept alive and reused from one request to the next.
+type httpClients struct {
+	sync.Mutex
+	byKey map[httpClientKey]*http.Client
+}
+
+func newHttpClients() *httpClients {
+	return &httpClients{byKey: map[httpClientKey]*http.Client{}}
+}
+
+func (clients *httpClients) get(trustedCerts []tls.Certificate, sslDisabled bool, timeout time.Duration, responseTimeout bool) (client *http.Client, err error) {
+	clients.Lock()
+	defer clients.Unlock()
+
+	key := httpClientKey{sslDisabled: sslDisabled, timeout: timeout, responseTimeout: responseTimeout}
+	client, found := clients.byKey[key]
+	if found {
+		return
+	}
+
+	tlsConfig, err := NewTLSConfig(trustedCerts, sslDisabled)
+	if err != nil {
+		return
+	}
+
+	client = newHttpClient(tlsConfig, timeout, responseTimeout)
+	clients.byKey[key] = client
+	return
+}
+
+// NewTLSConfig verifies certificates against the system roots, the PEM file
+// named by CF_CA_CERTS and trustedCerts, unless SSL validation is disabled.
+func NewTLSConfig(trustedCerts []tls.Certificate, sslDisabled bool) (tlsConfig *tls.Config, err error) {
+	tlsConfig = &tls.Config{InsecureSkipVerify: sslDisabled}
+	if sslDisabled {
+		return
+	}
+
+	caCertsFile := os.Getenv("CF_CA_CERTS")
+	if caCertsFile == "" && len(trustedCerts) == 0 {
+		return
+	}
+
+	// the extra certificates are added to the system roots rather than replacing them
+	pool, systemErr := x509.SystemCertPool()
+	if systemErr != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if caCertsFile != "" {
+		var data []byte
+		data, err = ioutil.ReadFile(caCertsFile)
+		if err != nil {
+			err = fmt.Errorf("Error reading CF_CA_CERTS file %s: %s", caCertsFile, err.Error())
+			return
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			err = fmt.Errorf("No PEM certificates found in CF_CA_CERTS file %s", caCertsFile)
+			return
+		}
+	}
+
+	for _, tlsCert := range trustedCerts {
+		for _, data := range tlsCert.Certificate {
+			cert, parseErr := x509.ParseCertificate(data)
+			if parseErr == nil {
+				pool.AddCert(cert)
+			}
+		}
+	}
+
+	tlsConfig.RootCAs = pool
+	return
+}
+
+// IsInvalidSSLCertError reports whether err comes from failing to verify a
+// server certificate. The verification error is wrapped differently by the
+// http and websocket clients, so only its message is reliable.
+func IsInvalidSSLCertError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "x509: ")
+}
+
+func NewInvalidSSLCertApiResponse(url string) ApiResponse {
+	return NewApiResponse(InvalidSSLCertMessage(url), INVALID_SSL_CERT_CODE, 0)
+}
+
+func InvalidSSLCertMessage(url string) string {
+	return fmt.Sprintf("Invalid SSL Cert for %s\nTIP: Use '%s' to continue with an insecure API endpoint, or set CF_CA_CERTS to a PEM file with your CA certificates",
+		url, terminal.CommandColor(cf.Name()+" api --skip-ssl-validation"))
+}
+
 func PrepareRedirect(req *http.Request, via []*http.Request) error {
 	if len(via) > 1 {
 		return errors.New("stopped after 1 redirect")
@@ -36,7 +150,7 @@ func PrepareRedirect(req *http.Request, via []*http.Request) error {
 
 	req.Header.Set("Authorization", prevReq.Header.Get("Authorization"))
 
-	if TraceEnabled() {
+	if TraceEnabled() && !TraceFormatIsJSON() {
 		dumpRequest(req)
 	}
 
@@ -57,49 +171,57 @@ func Sanitize(input string) (sanitized string) {
 	sanitized = sanitizeJson("access_token", sanitized)
 	sanitized = sanitizeJson("refresh_token", sanitized)
 	sanitized = sanitizeJson("token", sanitized)
+	sanitized = sanitizeJson("password", sanitized)
 
 	return
 }
 
-func doRequest(request *http.Request) (response *http.Response, err error) {
-	httpClient := newHttpClient()
+func doRequest(request *http.Request, httpClient *http.Client) (response *http.Response, err error) {
+	if !TraceEnabled() {
+		return httpClient.Do(request)
+	}
 
-	if TraceEnabled() {
-		dumpRequest(request)
+	if TraceFormatIsJSON() {
+		record := newRequestTraceRecord(request)
+		startedAt := time.Now()
+		response, err = httpClient.Do(request)
+		record.finish(response, err, startedAt)
+		WriteTraceRecord(record)
+		return
 	}
 
-	response, err = httpClient.Do(request)
+	dumpRequest(request)
 
+	response, err = httpClient.Do(request)
 	if err != nil {
 		return
 	}
 
-	if TraceEnabled() {
-		dumpedResponse, err := httputil.DumpResponse(response, true)
-		if err != nil {
-			fmt.Println("Error dumping response")
-		} else {
-			fmt.Printf("\n%s\n%s\n", terminal.HeaderColor("RESPONSE:"), Sanitize(string(dumpedResponse)))
-		}
+	dumpedResponse, dumpErr := httputil.DumpResponse(response, true)
+	if dumpErr != nil {
+		WriteTrace("RESPONSE:", "Error dumping response")
+	} else {
+		WriteTrace("RESPONSE:", Sanitize(string(dumpedResponse)))
 	}
 
 	return
 }
 
-func TraceEnabled() bool {
-	traceEnv := strings.ToLower(os.Getenv("CF_TRACE"))
-	return traceEnv == "true" || traceEnv == "yes"
-}
-
 func dumpRequest(req *http.Request) {
-	shouldDisplayBody := !strings.Contains(req.Header.Get("Content-Type"), "multipart/form-data")
+	shouldDisplayBody := !isMultipart(req.Header)
 	dumpedRequest, err := httputil.DumpRequest(req, shouldDisplayBody)
 	if err != nil {
-		fmt.Println("Error dumping request")
-	} else {
-		fmt.Printf("\n%s\n%s\n", terminal.HeaderColor("REQUEST:"), Sanitize(string(dumpedRequest)))
-		if !shouldDisplayBody {
-			fmt.Println("[MULTIPART/FORM-DATA CONTENT HIDDEN]")
-		}
+		WriteTrace("REQUEST:", "Error dumping request")
+		return
 	}
+
+	text := Sanitize(string(dumpedRequest))
+	if !shouldDisplayBody {
+		text += "\n[MULTIPART/FORM-DATA CONTENT HIDDEN]"
+	}
+	WriteTrace("REQUEST:", text)
+}
+
+func isMultipart(header http.Header) bool {
+	return strings.Contains(header.Get("Content-Type"), "multipart/form-data")
 }
@@ -0,0 +1,34 @@
+package net
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+type PaginatedResources struct {
+	NextURL        string          `json:"next_url"`
+	ResourcesBytes json.RawMessage `json:"resources"`
+	resourceType   reflect.Type
+}
+
+func NewPaginatedResources(exampleResource interface{}) PaginatedResources {
+	return PaginatedResources{resourceType: reflect.TypeOf(exampleResource)}
+}
+
+func (this PaginatedResources) Resources() (resources []interface{}, err error) {
+	if len(this.ResourcesBytes) == 0 {
+		return
+	}
+
+	slicePtr := reflect.New(reflect.SliceOf(this.resourceType))
+	err = json.Unmarshal([]byte(this.ResourcesBytes), slicePtr.Interface())
+	if err != nil {
+		return
+	}
+
+	slice := slicePtr.Elem()
+	for i := 0; i < slice.Len(); i++ {
+		resources = append(resources, slice.Index(i).Interface())
+	}
+	return
+}
@@ -0,0 +1,179 @@
+package net
+
+import (
+	"bytes"
+	"cf/terminal"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// CF_TRACE is true, yes or 1 to print traces, or the path of a file to append
+// them to. CF_TRACE_FORMAT=json writes one JSON record per request instead.
+func TraceEnabled() bool {
+	switch strings.ToLower(os.Getenv("CF_TRACE")) {
+	case "", "false", "no", "0":
+		return false
+	}
+	return true
+}
+
+func TraceFormatIsJSON() bool {
+	return strings.ToLower(os.Getenv("CF_TRACE_FORMAT")) == "json"
+}
+
+func traceFile() string {
+	switch strings.ToLower(os.Getenv("CF_TRACE")) {
+	case "", "false", "no", "0", "true", "yes", "1":
+		return ""
+	}
+	return os.Getenv("CF_TRACE")
+}
+
+// WriteTrace writes a section of text trace output. Section headers are only
+// colored on the terminal.
+func WriteTrace(header, text string) {
+	if traceFile() == "" {
+		header = terminal.HeaderColor(header)
+	}
+	writeTraceOutput(fmt.Sprintf("\n%s\n%s\n", header, text))
+}
+
+func WriteTraceRecord(record TraceRecord) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	writeTraceOutput(string(data) + "\n")
+}
+
+// the trace file is opened for every write, so that nothing is lost when a
+// command exits without cleaning up
+func writeTraceOutput(output string) {
+	path := traceFile()
+	if path == "" {
+		fmt.Print(output)
+		return
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening trace file %s: %s\n", path, err.Error())
+		return
+	}
+	defer file.Close()
+
+	file.WriteString(output)
+}
+
+type TraceRecord struct {
+	Timestamp       string      `json:"timestamp"`
+	Method          string      `json:"method"`
+	URL             string      `json:"url"`
+	Status          int         `json:"status,omitempty"`
+	LatencyMs       int64       `json:"latency_ms"`
+	RequestHeaders  http.Header `json:"request_headers,omitempty"`
+	RequestBody     string      `json:"request_body,omitempty"`
+	ResponseHeaders http.Header `json:"response_headers,omitempty"`
+	ResponseBody    string      `json:"response_body,omitempty"`
+	Error           string      `json:"error,omitempty"`
+	Attempt         int         `json:"attempt,omitempty"`
+	RetryInMs       int64       `json:"retry_in_ms,omitempty"`
+}
+
+func newRequestTraceRecord(request *http.Request) (record TraceRecord) {
+	record.Method = request.Method
+	record.URL = request.URL.String()
+	record.RequestHeaders = sanitizeHeaders(request.Header)
+
+	if request.Body == nil {
+		return
+	}
+	if isMultipart(request.Header) {
+		record.RequestBody = "[MULTIPART/FORM-DATA CONTENT HIDDEN]"
+		return
+	}
+
+	body, err := ioutil.ReadAll(request.Body)
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err == nil {
+		record.RequestBody = Sanitize(string(body))
+	}
+	return
+}
+
+func (record *TraceRecord) finish(response *http.Response, err error, startedAt time.Time) {
+	record.Timestamp = startedAt.Format(time.RFC3339Nano)
+	record.LatencyMs = int64(time.Since(startedAt) / time.Millisecond)
+
+	if err != nil {
+		record.Error = err.Error()
+		return
+	}
+
+	record.Status = response.StatusCode
+	record.ResponseHeaders = sanitizeHeaders(response.Header)
+
+	body, readErr := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if readErr == nil {
+		record.ResponseBody = Sanitize(string(body))
+	}
+}
+
+// TraceWebsocket traces connecting to a websocket, such as the one that logs
+// are streamed over.
+func TraceWebsocket(location string, header http.Header, startedAt time.Time, err error) {
+	if !TraceFormatIsJSON() {
+		WriteTrace("CONNECTING TO WEBSOCKET:", location)
+		return
+	}
+
+	record := TraceRecord{
+		Timestamp:      startedAt.Format(time.RFC3339Nano),
+		Method:         "GET",
+		URL:            location,
+		LatencyMs:      int64(time.Since(startedAt) / time.Millisecond),
+		RequestHeaders: sanitizeHeaders(header),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	} else {
+		record.Status = http.StatusSwitchingProtocols
+	}
+	WriteTraceRecord(record)
+}
+
+// TraceRetry traces a failed request that is about to be sent again.
+func TraceRetry(request *http.Request, reason string, delay time.Duration, attempt, attempts int) {
+	if !TraceFormatIsJSON() {
+		WriteTrace("RETRY:", fmt.Sprintf("%s %s failed: %s\nRetrying in %s (attempt %d of %d)",
+			request.Method, request.URL, reason, delay, attempt, attempts))
+		return
+	}
+
+	WriteTraceRecord(TraceRecord{
+		Timestamp: time.Now().Format(time.RFC3339Nano),
+		Method:    request.Method,
+		URL:       request.URL.String(),
+		Error:     reason,
+		Attempt:   attempt,
+		RetryInMs: int64(delay / time.Millisecond),
+	})
+}
+
+func sanitizeHeaders(header http.Header) (sanitized http.Header) {
+	sanitized = http.Header{}
+	for name, values := range header {
+		if http.CanonicalHeaderKey(name) == "Authorization" {
+			values = []string{PRIVATE_DATA_PLACEHOLDER}
+		}
+		sanitized[name] = values
+	}
+	return
+}
@@ -0,0 +1,189 @@
+package net_test
+
+import (
+	. "cf/net"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTraceEnabled(t *testing.T) {
+	defer os.Setenv("CF_TRACE", "")
+
+	for _, value := range []string{"true", "YES", "1", "trace.log", "/tmp/cf-trace.log"} {
+		os.Setenv("CF_TRACE", value)
+		assert.True(t, TraceEnabled(), value)
+	}
+
+	for _, value := range []string{"", "false", "no", "0"} {
+		os.Setenv("CF_TRACE", value)
+		assert.False(t, TraceEnabled(), value)
+	}
+}
+
+func TestTraceBooleansAreNotFileNames(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cf-trace")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	os.Chdir(dir)
+	defer os.Chdir(wd)
+
+	os.Setenv("CF_TRACE", "1")
+	defer os.Setenv("CF_TRACE", "")
+	performTracedRequest(t)
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+
+	os.Setenv("CF_TRACE", "trace.log")
+	performTracedRequest(t)
+
+	contents, err := ioutil.ReadFile(filepath.Join(dir, "trace.log"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(contents), "PUT /v2/foo")
+}
+
+func TestTraceIsAppendedToFile(t *testing.T) {
+	traceFile := setupTraceFile(t, "")
+	defer os.Setenv("CF_TRACE", "")
+	defer os.RemoveAll(filepath.Dir(traceFile))
+
+	ioutil.WriteFile(traceFile, []byte("earlier trace\n"), 0600)
+	performTracedRequest(t)
+
+	contents, err := ioutil.ReadFile(traceFile)
+	assert.NoError(t, err)
+
+	trace := string(contents)
+	assert.True(t, strings.HasPrefix(trace, "earlier trace\n"))
+	assert.Contains(t, trace, "\nREQUEST:\n")
+	assert.Contains(t, trace, "PUT /v2/foo")
+	assert.Contains(t, trace, "\nRESPONSE:\n")
+	assert.Contains(t, trace, "Authorization: "+PRIVATE_DATA_PLACEHOLDER)
+	assert.NotContains(t, trace, "my-access-token")
+	assert.NotContains(t, trace, "\033[")
+}
+
+func TestTraceAsJSONRecords(t *testing.T) {
+	traceFile := setupTraceFile(t, "json")
+	defer os.Setenv("CF_TRACE", "")
+	defer os.Setenv("CF_TRACE_FORMAT", "")
+	defer os.RemoveAll(filepath.Dir(traceFile))
+
+	performTracedRequest(t)
+
+	contents, err := ioutil.ReadFile(traceFile)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	assert.Equal(t, len(lines), 1)
+
+	record := TraceRecord{}
+	err = json.Unmarshal([]byte(lines[0]), &record)
+	assert.NoError(t, err)
+
+	_, err = time.Parse(time.RFC3339Nano, record.Timestamp)
+	assert.NoError(t, err)
+	assert.Equal(t, record.Method, "PUT")
+	assert.True(t, strings.HasSuffix(record.URL, "/v2/foo"))
+	assert.Equal(t, record.Status, http.StatusCreated)
+	assert.True(t, record.LatencyMs >= 0)
+	assert.Equal(t, record.RequestHeaders.Get("Authorization"), PRIVATE_DATA_PLACEHOLDER)
+	assert.Equal(t, record.RequestBody, `{"password":"`+PRIVATE_DATA_PLACEHOLDER+`","name":"foo"}`)
+	assert.Equal(t, record.ResponseHeaders.Get("X-Foo"), "bar")
+	assert.Equal(t, record.ResponseBody, `{"access_token":"`+PRIVATE_DATA_PLACEHOLDER+`"}`)
+}
+
+func TestTraceWebsocketAsJSONRecord(t *testing.T) {
+	traceFile := setupTraceFile(t, "json")
+	defer os.Setenv("CF_TRACE", "")
+	defer os.Setenv("CF_TRACE_FORMAT", "")
+	defer os.RemoveAll(filepath.Dir(traceFile))
+
+	header := http.Header{"Authorization": {"bearer my-access-token"}}
+	TraceWebsocket("wss://loggregator.example.com/tail/?app=my-app-guid", header, time.Now(), nil)
+
+	contents, err := ioutil.ReadFile(traceFile)
+	assert.NoError(t, err)
+
+	record := TraceRecord{}
+	err = json.Unmarshal(contents, &record)
+	assert.NoError(t, err)
+	assert.Equal(t, record.URL, "wss://loggregator.example.com/tail/?app=my-app-guid")
+	assert.Equal(t, record.Status, http.StatusSwitchingProtocols)
+	assert.Equal(t, record.RequestHeaders.Get("Authorization"), PRIVATE_DATA_PLACEHOLDER)
+}
+
+func TestTraceRetriesAsJSONRecords(t *testing.T) {
+	traceFile := setupTraceFile(t, "json")
+	defer os.Setenv("CF_TRACE", "")
+	defer os.Setenv("CF_TRACE_FORMAT", "")
+	defer os.RemoveAll(filepath.Dir(traceFile))
+
+	requests := 0
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		if requests == 1 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	performRequestTo(ts, "GET", nil)
+
+	contents, err := ioutil.ReadFile(traceFile)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	assert.Equal(t, len(lines), 3)
+
+	record := TraceRecord{}
+	err = json.Unmarshal([]byte(lines[1]), &record)
+	assert.NoError(t, err)
+	assert.Equal(t, record.Method, "GET")
+	assert.Equal(t, record.Error, "503 Service Unavailable")
+	assert.Equal(t, record.Attempt, 2)
+	assert.True(t, record.RetryInMs > 0)
+}
+
+func setupTraceFile(t *testing.T, format string) string {
+	dir, err := ioutil.TempDir("", "cf-trace")
+	assert.NoError(t, err)
+
+	traceFile := filepath.Join(dir, "trace.log")
+	os.Setenv("CF_TRACE", traceFile)
+	os.Setenv("CF_TRACE_FORMAT", format)
+	return traceFile
+}
+
+func performTracedRequest(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("X-Foo", "bar")
+		writer.WriteHeader(http.StatusCreated)
+		fmt.Fprint(writer, `{"access_token":"new-token"}`)
+	}))
+	defer ts.Close()
+
+	gateway := NewCloudControllerGateway()
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
+
+	body := strings.NewReader(`{"password":"secret","name":"foo"}`)
+	request, apiResponse := gateway.NewRequest("PUT", ts.URL+"/v2/foo", "BEARER my-access-token", body)
+	assert.False(t, apiResponse.IsNotSuccessful())
+
+	bytes, _, apiResponse := gateway.PerformRequestForResponseBytes(request)
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, string(bytes), `{"access_token":"new-token"}`)
+}
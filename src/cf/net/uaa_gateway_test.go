@@ -19,6 +19,7 @@ func TestUAAGatewayErrorHandling(t *testing.T) {
 	gateway := NewUAAGateway()
 
 	ts := httptest.NewTLSServer(http.HandlerFunc(failingUAARequest))
+	gateway.SetTrustedCerts(ts.TLS.Certificates)
 	defer ts.Close()
 
 	request, apiResponse := gateway.NewRequest("GET", ts.URL, "TOKEN", nil)
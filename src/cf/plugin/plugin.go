@@ -0,0 +1,128 @@
+// Package plugin runs third party executables as cf commands.
+//
+// A plugin describes itself when run with the single argument --cf-plugin-metadata,
+// by printing a JSON Plugin (name, version and commands) to stdout and exiting 0.
+//
+// When one of its commands is run, the plugin is started with the command name and
+// the user's arguments, attached to the user's stdin, stdout and stderr. The
+// CF_PLUGIN_CONTEXT environment variable holds a JSON Context with the target, tokens,
+// org and space, so that the plugin can call the API without logging in again.
+package plugin
+
+import (
+	"cf"
+	"cf/configuration"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+const (
+	MetadataArg = "--cf-plugin-metadata"
+	ContextEnv  = "CF_PLUGIN_CONTEXT"
+)
+
+type Command struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Usage       string `json:"usage"`
+}
+
+type Plugin struct {
+	Name       string    `json:"name"`
+	Version    string    `json:"version"`
+	Executable string    `json:"executable"`
+	Commands   []Command `json:"commands"`
+}
+
+func (plugin Plugin) FindCommand(name string) (command Command, found bool) {
+	for _, command = range plugin.Commands {
+		if command.Name == name {
+			found = true
+			return
+		}
+	}
+	command = Command{}
+	return
+}
+
+type ContextEntity struct {
+	Name string `json:"name"`
+	Guid string `json:"guid"`
+}
+
+type Context struct {
+	CliVersion            string        `json:"cli_version"`
+	Target                string        `json:"target"`
+	ApiVersion            string        `json:"api_version"`
+	AuthorizationEndpoint string        `json:"authorization_endpoint"`
+	AccessToken           string        `json:"access_token"`
+	RefreshToken          string        `json:"refresh_token"`
+	Username              string        `json:"username"`
+	Organization          ContextEntity `json:"organization"`
+	Space                 ContextEntity `json:"space"`
+}
+
+func NewContext(config *configuration.Configuration) (context Context) {
+	context.CliVersion = cf.Version
+	context.Target = config.Target
+	context.ApiVersion = config.ApiVersion
+	context.AuthorizationEndpoint = config.AuthorizationEndpoint
+	context.AccessToken = config.AccessToken
+	context.RefreshToken = config.RefreshToken
+	context.Username = config.Username()
+	context.Organization = ContextEntity{Name: config.Organization.Name, Guid: config.Organization.Guid}
+	context.Space = ContextEntity{Name: config.Space.Name, Guid: config.Space.Guid}
+	return
+}
+
+func readMetadata(executable string) (plugin Plugin, err error) {
+	output, err := exec.Command(executable, MetadataArg).Output()
+	if err != nil {
+		err = fmt.Errorf("Error reading plugin metadata from %s: %s", executable, err.Error())
+		return
+	}
+
+	err = json.Unmarshal(output, &plugin)
+	if err != nil {
+		err = fmt.Errorf("Invalid plugin metadata from %s: %s", executable, err.Error())
+		return
+	}
+
+	if plugin.Name == "" || plugin.Name != filepath.Base(plugin.Name) {
+		err = fmt.Errorf("Invalid plugin metadata: %q is not a valid plugin name", plugin.Name)
+		return
+	}
+
+	if len(plugin.Commands) == 0 {
+		err = fmt.Errorf("Invalid plugin metadata: plugin %s has no commands", plugin.Name)
+		return
+	}
+
+	for _, command := range plugin.Commands {
+		if command.Name == "" {
+			err = fmt.Errorf("Invalid plugin metadata: plugin %s has a command without a name", plugin.Name)
+			return
+		}
+	}
+
+	plugin.Executable = executable
+	return
+}
+
+func run(plugin Plugin, commandName string, args []string, context Context) (err error) {
+	contextJson, err := json.Marshal(context)
+	if err != nil {
+		return
+	}
+
+	cmd := exec.Command(plugin.Executable, append([]string{commandName}, args...)...)
+	cmd.Env = append(os.Environ(), ContextEnv+"="+string(contextJson))
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
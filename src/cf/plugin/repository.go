@@ -0,0 +1,196 @@
+package plugin
+
+import (
+	"cf/configuration"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const executablePermissions = 0755
+
+type PluginRepository interface {
+	List() (plugins []Plugin, err error)
+	FindByCommandName(commandName string) (plugin Plugin, found bool, err error)
+	ReadMetadata(executable string) (plugin Plugin, err error)
+	Install(plugin Plugin) (err error)
+	Uninstall(name string) (err error)
+	Run(plugin Plugin, commandName string, args []string, context Context) (err error)
+}
+
+// PluginDiskRepository copies plugin executables into the plugins directory next to
+// the config file and keeps their metadata in plugins.json, so installed plugins
+// keep working when the original executable is moved.
+type PluginDiskRepository struct{}
+
+func NewPluginDiskRepository() (repo PluginDiskRepository) {
+	return PluginDiskRepository{}
+}
+
+func (repo PluginDiskRepository) List() (plugins []Plugin, err error) {
+	pluginsByName, err := readRegistry()
+	if err != nil {
+		return
+	}
+
+	names := []string{}
+	for name := range pluginsByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		plugins = append(plugins, pluginsByName[name])
+	}
+	return
+}
+
+func (repo PluginDiskRepository) FindByCommandName(commandName string) (plugin Plugin, found bool, err error) {
+	plugins, err := repo.List()
+	if err != nil {
+		return
+	}
+
+	for _, plugin = range plugins {
+		_, found = plugin.FindCommand(commandName)
+		if found {
+			return
+		}
+	}
+	plugin = Plugin{}
+	return
+}
+
+func (repo PluginDiskRepository) ReadMetadata(executable string) (plugin Plugin, err error) {
+	return readMetadata(executable)
+}
+
+func (repo PluginDiskRepository) Install(plugin Plugin) (err error) {
+	dir, err := pluginsDir()
+	if err != nil {
+		return
+	}
+
+	pluginsByName, err := readRegistry()
+	if err != nil {
+		return
+	}
+
+	if _, found := pluginsByName[plugin.Name]; found {
+		err = fmt.Errorf("Plugin %s is already installed.", plugin.Name)
+		return
+	}
+
+	installedPath := filepath.Join(dir, plugin.Name+filepath.Ext(plugin.Executable))
+	err = copyExecutable(plugin.Executable, installedPath)
+	if err != nil {
+		return
+	}
+
+	plugin.Executable = installedPath
+	pluginsByName[plugin.Name] = plugin
+	return writeRegistry(pluginsByName)
+}
+
+func (repo PluginDiskRepository) Uninstall(name string) (err error) {
+	pluginsByName, err := readRegistry()
+	if err != nil {
+		return
+	}
+
+	plugin, found := pluginsByName[name]
+	if !found {
+		err = fmt.Errorf("Plugin %s is not installed.", name)
+		return
+	}
+
+	delete(pluginsByName, name)
+	err = writeRegistry(pluginsByName)
+	if err != nil {
+		return
+	}
+
+	os.Remove(plugin.Executable)
+	return
+}
+
+func (repo PluginDiskRepository) Run(plugin Plugin, commandName string, args []string, context Context) (err error) {
+	return run(plugin, commandName, args, context)
+}
+
+func pluginsDir() (dir string, err error) {
+	configFile, err := configuration.ConfigFile()
+	if err != nil {
+		return
+	}
+
+	dir = filepath.Join(filepath.Dir(configFile), "plugins")
+	err = os.MkdirAll(dir, 0700)
+	return
+}
+
+func registryFile() (file string, err error) {
+	dir, err := pluginsDir()
+	if err != nil {
+		return
+	}
+
+	file = filepath.Join(dir, "plugins.json")
+	return
+}
+
+func readRegistry() (pluginsByName map[string]Plugin, err error) {
+	pluginsByName = map[string]Plugin{}
+
+	file, err := registryFile()
+	if err != nil {
+		return
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(data, &pluginsByName)
+	return
+}
+
+func writeRegistry(pluginsByName map[string]Plugin) (err error) {
+	file, err := registryFile()
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(pluginsByName)
+	if err != nil {
+		return
+	}
+
+	return ioutil.WriteFile(file, data, 0600)
+}
+
+func copyExecutable(fromPath, toPath string) (err error) {
+	src, err := os.Open(fromPath)
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(toPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, executablePermissions)
+	if err != nil {
+		return
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, src)
+	return
+}
@@ -0,0 +1,136 @@
+package plugin
+
+import (
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func withTempCfHome(t *testing.T, cb func(repo PluginDiskRepository)) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin fixtures are shell scripts")
+	}
+
+	home, err := ioutil.TempDir("", "cf-plugins")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	os.Setenv("CF_HOME", home)
+	defer os.Setenv("CF_HOME", "")
+
+	cb(NewPluginDiskRepository())
+}
+
+func fixturePath(name string) string {
+	path, _ := filepath.Abs(filepath.Join("..", "..", "fixtures", "plugins", name))
+	return path
+}
+
+func TestReadMetadata(t *testing.T) {
+	withTempCfHome(t, func(repo PluginDiskRepository) {
+		plugin, err := repo.ReadMetadata(fixturePath("hello.sh"))
+		assert.NoError(t, err)
+
+		assert.Equal(t, plugin.Name, "hello")
+		assert.Equal(t, plugin.Version, "1.0.0")
+		assert.Equal(t, plugin.Executable, fixturePath("hello.sh"))
+		assert.Equal(t, len(plugin.Commands), 1)
+		assert.Equal(t, plugin.Commands[0].Name, "hello")
+		assert.Equal(t, plugin.Commands[0].Description, "Say hello")
+	})
+}
+
+func TestReadMetadataRejectsInvalidNames(t *testing.T) {
+	withTempCfHome(t, func(repo PluginDiskRepository) {
+		_, err := repo.ReadMetadata(fixturePath("bad_metadata.sh"))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not a valid plugin name")
+	})
+}
+
+func TestInstallListAndUninstall(t *testing.T) {
+	withTempCfHome(t, func(repo PluginDiskRepository) {
+		plugin, err := repo.ReadMetadata(fixturePath("hello.sh"))
+		assert.NoError(t, err)
+
+		err = repo.Install(plugin)
+		assert.NoError(t, err)
+
+		err = repo.Install(plugin)
+		assert.Error(t, err)
+
+		plugins, err := repo.List()
+		assert.NoError(t, err)
+		assert.Equal(t, len(plugins), 1)
+		assert.Equal(t, plugins[0].Name, "hello")
+
+		configFile, err := configuration.ConfigFile()
+		assert.NoError(t, err)
+		installedPath := filepath.Join(filepath.Dir(configFile), "plugins", "hello.sh")
+		assert.Equal(t, plugins[0].Executable, installedPath)
+
+		fileInfo, err := os.Stat(installedPath)
+		assert.NoError(t, err)
+		assert.Equal(t, fileInfo.Mode().Perm(), os.FileMode(executablePermissions))
+
+		found, ok, err := repo.FindByCommandName("hello")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, found.Name, "hello")
+
+		_, ok, err = repo.FindByCommandName("goodbye")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		err = repo.Uninstall("hello")
+		assert.NoError(t, err)
+
+		plugins, err = repo.List()
+		assert.NoError(t, err)
+		assert.Empty(t, plugins)
+
+		_, err = os.Stat(installedPath)
+		assert.True(t, os.IsNotExist(err))
+
+		err = repo.Uninstall("hello")
+		assert.Error(t, err)
+	})
+}
+
+func TestRunPassesArgsAndContext(t *testing.T) {
+	withTempCfHome(t, func(repo PluginDiskRepository) {
+		output, err := ioutil.TempFile("", "hello-output")
+		assert.NoError(t, err)
+		output.Close()
+		defer os.Remove(output.Name())
+
+		os.Setenv("HELLO_OUTPUT", output.Name())
+		defer os.Setenv("HELLO_OUTPUT", "")
+
+		plugin, err := repo.ReadMetadata(fixturePath("hello.sh"))
+		assert.NoError(t, err)
+
+		context := Context{
+			Target:       "https://api.example.com",
+			AccessToken:  "BEARER my_access_token",
+			Organization: ContextEntity{Name: "my-org", Guid: "my-org-guid"},
+		}
+
+		err = repo.Run(plugin, "hello", []string{"world"}, context)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(output.Name())
+		assert.NoError(t, err)
+
+		lines := strings.Split(string(data), "\n")
+		assert.Equal(t, lines[0], "hello world")
+		assert.Contains(t, lines[1], `"target":"https://api.example.com"`)
+		assert.Contains(t, lines[1], `"access_token":"BEARER my_access_token"`)
+		assert.Contains(t, lines[1], `"organization":{"name":"my-org","guid":"my-org-guid"}`)
+	})
+}
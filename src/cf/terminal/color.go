@@ -2,6 +2,7 @@ package terminal
 
 import (
 	"fmt"
+	"hash/fnv"
 	"regexp"
 	"runtime"
 )
@@ -107,3 +108,13 @@ func LogAppHeaderColor(message string) string {
 func LogSysHeaderColor(message string) string {
 	return colorize(message, cyan, true)
 }
+
+var logAppNameColors = []Color{cyan, green, yellow, magenta, grey, white}
+
+// LogAppNameColor always picks the same color for the same app name, so each
+// app keeps its color when logs from several apps are merged.
+func LogAppNameColor(appName string) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(appName))
+	return colorize(appName, logAppNameColors[hash.Sum32()%uint32(len(logAppNameColors))], false)
+}
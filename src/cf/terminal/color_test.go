@@ -16,3 +16,12 @@ func TestColorize(t *testing.T) {
 		assert.Equal(t, colorizedText, "\033[1;31mHello World\033[0m")
 	}
 }
+
+func TestLogAppNameColorIsStablePerApp(t *testing.T) {
+	assert.Equal(t, LogAppNameColor("my-app"), LogAppNameColor("my-app"))
+	assert.Equal(t, decolorize(LogAppNameColor("my-app")), "my-app")
+
+	if runtime.GOOS != "windows" {
+		assert.NotEqual(t, LogAppNameColor("my-app"), LogAppNameColor("my-worker"))
+	}
+}
@@ -3,14 +3,23 @@ package terminal
 import (
 	"cf"
 	"cf/configuration"
+	"cf/formatters"
+	"encoding/json"
 	"fmt"
 	"github.com/codegangsta/cli"
+	"github.com/fraenkel/candiedyaml"
 	"io"
 	"os"
 	"strings"
 	"time"
 )
 
+const (
+	TableOutput = "table"
+	JsonOutput  = "json"
+	YamlOutput  = "yaml"
+)
+
 type ColoringFunction func(value string, row int, col int) string
 
 func NotLoggedInText() string {
@@ -28,22 +37,51 @@ type UI interface {
 	FailWithUsage(ctxt *cli.Context, cmdName string)
 	ConfigFailure(err error)
 	ShowConfiguration(*configuration.Configuration)
+	ShowProgress(sent, total int64, elapsed time.Duration)
 	LoadingIndication()
 	Wait(duration time.Duration)
+	ClearScreen()
 	DisplayTable(table [][]string)
+	DisplayRecords(table [][]string, records interface{})
+	SetOutputFormat(format string) (err error)
 }
 
 type terminalUI struct {
+	outputFormat string
 }
 
 var stdin io.Reader = os.Stdin
 
 func NewUI() UI {
-	return terminalUI{}
+	return new(terminalUI)
+}
+
+func (c *terminalUI) SetOutputFormat(format string) (err error) {
+	switch format {
+	case "", TableOutput:
+		c.outputFormat = TableOutput
+	case JsonOutput, YamlOutput:
+		c.outputFormat = format
+	default:
+		err = fmt.Errorf("Invalid output format %s. Valid formats are %s, %s and %s.", format, TableOutput, JsonOutput, YamlOutput)
+	}
+	return
+}
+
+func (c terminalUI) isStructuredOutput() bool {
+	return c.outputFormat == JsonOutput || c.outputFormat == YamlOutput
+}
+
+// Messages go to stderr when printing json or yaml, so stdout only holds the records
+func (c terminalUI) messageWriter() io.Writer {
+	if c.isStructuredOutput() {
+		return os.Stderr
+	}
+	return os.Stdout
 }
 
 func (c terminalUI) Say(message string, args ...interface{}) {
-	fmt.Printf(message+"\n", args...)
+	fmt.Fprintf(c.messageWriter(), message+"\n", args...)
 	return
 }
 
@@ -63,8 +101,8 @@ func (c terminalUI) Confirm(message string, args ...interface{}) bool {
 }
 
 func (c terminalUI) Ask(prompt string, args ...interface{}) (answer string) {
-	fmt.Println("")
-	fmt.Printf(prompt+" ", args...)
+	fmt.Fprintln(c.messageWriter(), "")
+	fmt.Fprintf(c.messageWriter(), prompt+" ", args...)
 	fmt.Fscanln(stdin, &answer)
 	return
 }
@@ -93,6 +131,10 @@ func (c terminalUI) ConfigFailure(err error) {
 }
 
 func (ui terminalUI) ShowConfiguration(config *configuration.Configuration) {
+	if config.ProfileName() != configuration.DefaultProfileName {
+		ui.Say("Profile:      %s", EntityNameColor(config.ProfileName()))
+	}
+
 	ui.Say("API endpoint: %s (API version: %s)",
 		EntityNameColor(config.Target),
 		EntityNameColor(config.ApiVersion))
@@ -112,15 +154,49 @@ func (ui terminalUI) ShowConfiguration(config *configuration.Configuration) {
 	}
 }
 
+func (ui terminalUI) ShowProgress(sent, total int64, elapsed time.Duration) {
+	rate := uint64(0)
+	if elapsed > 0 {
+		rate = uint64(float64(sent) / elapsed.Seconds())
+	}
+
+	fmt.Fprintf(ui.messageWriter(), "\r%s of %s sent (%s/s)   ",
+		formatters.ByteSize(uint64(sent)),
+		formatters.ByteSize(uint64(total)),
+		formatters.ByteSize(rate),
+	)
+
+	if sent >= total {
+		fmt.Fprintln(ui.messageWriter())
+	}
+}
+
 func (c terminalUI) LoadingIndication() {
-	fmt.Print(".")
+	fmt.Fprint(c.messageWriter(), ".")
 }
 
 func (c terminalUI) Wait(duration time.Duration) {
 	time.Sleep(duration)
 }
 
+// ClearScreen moves the cursor home and clears the terminal so output can be
+// redrawn in place. It does nothing for json or yaml output.
+func (ui terminalUI) ClearScreen() {
+	if ui.isStructuredOutput() {
+		return
+	}
+	clearScreen()
+}
+
 func (ui terminalUI) DisplayTable(table [][]string) {
+	if ui.isStructuredOutput() {
+		ui.displayStructured(tableRecords(table))
+		return
+	}
+
+	if len(table) == 0 {
+		return
+	}
 
 	columnCount := len(table[0])
 	maxSizes := make([]int, columnCount)
@@ -144,6 +220,56 @@ func (ui terminalUI) DisplayTable(table [][]string) {
 	}
 }
 
+func (ui terminalUI) DisplayRecords(table [][]string, records interface{}) {
+	if ui.isStructuredOutput() {
+		ui.displayStructured(records)
+		return
+	}
+
+	ui.DisplayTable(table)
+}
+
+func (ui terminalUI) displayStructured(records interface{}) {
+	var (
+		output []byte
+		err    error
+	)
+
+	switch ui.outputFormat {
+	case JsonOutput:
+		output, err = json.MarshalIndent(records, "", "  ")
+		output = append(output, '\n')
+	case YamlOutput:
+		output, err = candiedyaml.Marshal(records)
+	}
+
+	if err != nil {
+		ui.Failed("Error encoding output:\n%s", err.Error())
+		return
+	}
+
+	os.Stdout.Write(output)
+}
+
+func tableRecords(table [][]string) (records []map[string]string) {
+	records = []map[string]string{}
+	if len(table) == 0 {
+		return
+	}
+
+	headers := table[0]
+	for _, line := range table[1:] {
+		record := map[string]string{}
+		for index, value := range line {
+			if index < len(headers) {
+				record[decolorize(headers[index])] = decolorize(value)
+			}
+		}
+		records = append(records, record)
+	}
+	return
+}
+
 func tableColoringFunc(value string, row int, col int) string {
 	switch {
 	case row == 0:
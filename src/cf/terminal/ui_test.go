@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestSayWithStringOnly(t *testing.T) {
@@ -54,6 +55,76 @@ func TestConfirmNo(t *testing.T) {
 	})
 }
 
+func TestSetOutputFormat(t *testing.T) {
+	ui := new(terminalUI)
+
+	assert.NoError(t, ui.SetOutputFormat(""))
+	assert.Equal(t, ui.outputFormat, TableOutput)
+
+	assert.NoError(t, ui.SetOutputFormat("json"))
+	assert.Equal(t, ui.outputFormat, JsonOutput)
+
+	assert.NoError(t, ui.SetOutputFormat("yaml"))
+	assert.Equal(t, ui.outputFormat, YamlOutput)
+
+	err := ui.SetOutputFormat("xml")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid output format xml")
+}
+
+func TestDisplayRecordsAsTable(t *testing.T) {
+	ui := new(terminalUI)
+	out := captureOutput(func() {
+		ui.DisplayRecords([][]string{{"name"}, {"app1"}}, []map[string]string{{"name": "app1"}})
+	})
+
+	assert.Contains(t, out, "name")
+	assert.Contains(t, out, "app1")
+	assert.NotContains(t, out, "{")
+}
+
+func TestDisplayRecordsAsJson(t *testing.T) {
+	ui := &terminalUI{outputFormat: JsonOutput}
+	out := captureOutput(func() {
+		ui.Say("Getting apps...")
+		ui.DisplayRecords([][]string{{"name"}, {"app1"}}, []map[string]string{{"name": "app1"}})
+	})
+
+	assert.Equal(t, out, "[\n  {\n    \"name\": \"app1\"\n  }\n]\n")
+}
+
+func TestDisplayRecordsAsYaml(t *testing.T) {
+	ui := &terminalUI{outputFormat: YamlOutput}
+	out := captureOutput(func() {
+		ui.DisplayRecords([][]string{{"name"}, {"app1"}}, []map[string]string{{"name": "app1"}})
+	})
+
+	assert.Equal(t, out, "- name: app1\n")
+}
+
+func TestDisplayTableAsJson(t *testing.T) {
+	ui := &terminalUI{outputFormat: JsonOutput}
+	out := captureOutput(func() {
+		ui.DisplayTable([][]string{{HeaderColor("name"), "state"}, {"app1", StartedColor("started")}})
+	})
+
+	assert.Equal(t, out, "[\n  {\n    \"name\": \"app1\",\n    \"state\": \"started\"\n  }\n]\n")
+}
+
+func TestShowProgress(t *testing.T) {
+	ui := new(terminalUI)
+
+	out := captureOutput(func() {
+		ui.ShowProgress(512*1024, 2*1024*1024, 2*time.Second)
+	})
+	assert.Equal(t, "\r512K of 2M sent (256K/s)   ", out)
+
+	out = captureOutput(func() {
+		ui.ShowProgress(2*1024*1024, 2*1024*1024, 4*time.Second)
+	})
+	assert.Equal(t, "\r2M of 2M sent (512K/s)   \n", out)
+}
+
 func simulateStdin(input string, block func()) {
 	defer func() {
 		stdin = os.Stdin
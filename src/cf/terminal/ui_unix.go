@@ -99,3 +99,7 @@ func catchSignal(fd []uintptr, sig chan os.Signal) {
 		os.Exit(2)
 	}
 }
+
+func clearScreen() {
+	fmt.Print("\033[H\033[2J")
+}
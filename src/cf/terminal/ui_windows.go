@@ -5,6 +5,7 @@ package terminal
 import (
 	"os"
 	"syscall"
+	"unsafe"
 )
 
 // see SetConsoleMode documentation for bit flags
@@ -43,3 +44,35 @@ func setConsoleMode(console syscall.Handle, mode uint32) (err error) {
 	}
 	return nil
 }
+
+type consoleCoord struct {
+	x, y int16
+}
+
+type consoleScreenBufferInfo struct {
+	size              consoleCoord
+	cursorPosition    consoleCoord
+	attributes        uint16
+	window            [4]int16
+	maximumWindowSize consoleCoord
+}
+
+// clearScreen goes through the console API because the Windows console does
+// not understand the escape codes used elsewhere.
+func clearScreen() {
+	hStdout := syscall.Handle(os.Stdout.Fd())
+	dll := syscall.MustLoadDLL("kernel32")
+
+	var info consoleScreenBufferInfo
+	r, _, _ := dll.MustFindProc("GetConsoleScreenBufferInfo").Call(uintptr(hStdout), uintptr(unsafe.Pointer(&info)))
+	if r == 0 {
+		return
+	}
+
+	// a COORD is passed by value packed into one word, so 0 is the top left cell
+	cells := uint32(info.size.x) * uint32(info.size.y)
+	var written uint32
+	dll.MustFindProc("FillConsoleOutputCharacterW").Call(uintptr(hStdout), uintptr(' '), uintptr(cells), 0, uintptr(unsafe.Pointer(&written)))
+	dll.MustFindProc("FillConsoleOutputAttribute").Call(uintptr(hStdout), uintptr(info.attributes), uintptr(cells), 0, uintptr(unsafe.Pointer(&written)))
+	dll.MustFindProc("SetConsoleCursorPosition").Call(uintptr(hStdout), 0)
+}
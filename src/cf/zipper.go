@@ -7,7 +7,6 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
 )
 
 type Zipper interface {
@@ -86,69 +85,6 @@ func createZipFile(dir string) (zipFile *os.File, err error) {
 	return
 }
 
-func fileShouldBeIgnored(exclusions []string, relativePath string) bool {
-	for _, exclusion := range exclusions {
-		if exclusion == relativePath {
-			return true
-		}
-	}
-	return false
-}
-
-func readCfIgnore(dir string) (exclusions []string) {
-	cfIgnore, err := os.Open(filepath.Join(dir, ".cfignore"))
-	if err != nil {
-		return
-	}
-
-	ignores := strings.Split(readFile(cfIgnore), "\n")
-	ignores = append([]string{".cfignore"}, ignores...)
-
-	for _, pattern := range ignores {
-		pattern = strings.TrimSpace(pattern)
-		if pattern == "" {
-			continue
-		}
-		pattern = filepath.Clean(pattern)
-		patternExclusions := exclusionsForPattern(dir, pattern)
-		exclusions = append(exclusions, patternExclusions...)
-	}
-
-	return
-}
-
-func exclusionsForPattern(dir string, pattern string) (exclusions []string) {
-	starting_dir := dir
-
-	findPatternMatches := func(dir string, f os.FileInfo, inErr error) (err error) {
-		err = inErr
-		if err != nil {
-			return
-		}
-
-		absolutePaths := []string{}
-		if f.IsDir() && f.Name() == pattern {
-			absolutePaths, _ = filepath.Glob(filepath.Join(dir, "*"))
-		} else {
-			absolutePaths, _ = filepath.Glob(filepath.Join(dir, pattern))
-		}
-
-		for _, p := range absolutePaths {
-			relpath, _ := filepath.Rel(starting_dir, p)
-
-			exclusions = append(exclusions, relpath)
-		}
-		return
-	}
-
-	err := filepath.Walk(dir, findPatternMatches)
-	if err != nil {
-		return
-	}
-
-	return
-}
-
 func readFile(file *os.File) string {
 	buf := &bytes.Buffer{}
 	_, err := io.Copy(buf, file)
@@ -0,0 +1,2 @@
+#!/bin/sh
+echo '{"name":"../bad","version":"1.0.0","commands":[{"name":"bad"}]}'
@@ -0,0 +1,8 @@
+#!/bin/sh
+if [ "$1" = "--cf-plugin-metadata" ]; then
+  echo '{"name":"hello","version":"1.0.0","commands":[{"name":"hello","description":"Say hello","usage":"cf hello NAME"}]}'
+  exit 0
+fi
+
+echo "$1 $2" > "$HELLO_OUTPUT"
+echo "$CF_PLUGIN_CONTEXT" >> "$HELLO_OUTPUT"
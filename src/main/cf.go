@@ -12,6 +12,7 @@ import (
 	"cf/configuration"
 	"github.com/codegangsta/cli"
 	"cf/net"
+	"cf/plugin"
 )
 
 func main() {
@@ -28,9 +29,11 @@ func main() {
 
 	cmdFactory := commands.NewFactory(termUI, config, configRepo, repoLocator)
 	reqFactory := requirements.NewFactory(termUI, config, repoLocator)
-	cmdRunner := commands.NewRunner(cmdFactory, reqFactory)
+	cmdRunner := commands.NewRunner(termUI, cmdFactory, reqFactory)
 
-	app, err := app.NewApp(cmdRunner)
+	plugins, _ := plugin.NewPluginDiskRepository().List()
+
+	app, err := app.NewApp(cmdRunner, plugins)
 	if err != nil {
 		return
 	}
@@ -3,6 +3,7 @@ package api
 import (
 	"cf"
 	"cf/net"
+	"time"
 )
 
 type FakeApplicationBitsRepository struct {
@@ -11,7 +12,7 @@ type FakeApplicationBitsRepository struct {
 	UploadAppErr bool
 }
 
-func (repo *FakeApplicationBitsRepository) UploadApp(app cf.Application, dir string) (apiResponse net.ApiResponse) {
+func (repo *FakeApplicationBitsRepository) UploadApp(app cf.Application, dir string, onProgress func(sent, total int64, elapsed time.Duration)) (apiResponse net.ApiResponse) {
 	repo.UploadedDir = dir
 	repo.UploadedApp = app
 
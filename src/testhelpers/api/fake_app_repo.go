@@ -20,6 +20,7 @@ type FakeApplicationRepository struct {
 	StopUpdatedApp cf.Application
 
 	DeletedApp cf.Application
+	DeleteErr  bool
 
 	FindAllApps []cf.Application
 
@@ -28,6 +29,7 @@ type FakeApplicationRepository struct {
 	FindByNameErr       bool
 	FindByNameAuthErr   bool
 	FindByNameNotFound  bool
+	FindByNameApps      map[string]cf.Application
 
 	SetEnvApp   cf.Application
 	SetEnvVars  map[string]string
@@ -38,6 +40,7 @@ type FakeApplicationRepository struct {
 
 	RenameApp     cf.Application
 	RenameNewName string
+	RenameErr     bool
 
 	GetInstancesResponses  [][]cf.ApplicationInstance
 	GetInstancesErrorCodes []string
@@ -47,6 +50,15 @@ func (repo *FakeApplicationRepository) FindByName(name string) (app cf.Applicati
 	repo.FindByNameName = name
 	app = repo.FindByNameApp
 
+	if repo.FindByNameApps != nil {
+		var found bool
+		app, found = repo.FindByNameApps[name]
+		if !found {
+			apiResponse = net.NewNotFoundApiResponse("%s %s not found","App", name)
+		}
+		return
+	}
+
 	if repo.FindByNameErr {
 		apiResponse = net.NewApiResponseWithMessage("Error finding app by name.")
 	}
@@ -83,12 +95,20 @@ func (repo *FakeApplicationRepository) Create(newApp cf.Application) (createdApp
 
 func (repo *FakeApplicationRepository) Delete(app cf.Application) (apiResponse net.ApiResponse) {
 	repo.DeletedApp = app
+
+	if repo.DeleteErr {
+		apiResponse = net.NewApiResponseWithMessage("Error deleting app")
+	}
 	return
 }
 
 func (repo *FakeApplicationRepository) Rename(app cf.Application, newName string) (apiResponse net.ApiResponse) {
 	repo.RenameApp = app
 	repo.RenameNewName = newName
+
+	if repo.RenameErr {
+		apiResponse = net.NewApiResponseWithMessage("Error renaming app")
+	}
 	return
 }
 
@@ -8,10 +8,16 @@ import (
 
 type FakeAppSummaryRepo struct{
 	GetSummariesInCurrentSpaceApps []cf.Application
+	GetSummariesInSpaceApps map[string][]cf.Application
 
 	GetSummaryErrorCode string
 	GetSummaryApp cf.Application
 	GetSummarySummary cf.AppSummary
+	GetSummaryCalls int
+
+	// When set, each call to GetSummary returns the next summary and error code
+	GetSummaryResponses []cf.AppSummary
+	GetSummaryErrorCodes []string
 }
 
 func (repo *FakeAppSummaryRepo)GetSummariesInCurrentSpace() (apps []cf.Application, apiResponse net.ApiResponse) {
@@ -19,12 +25,26 @@ func (repo *FakeAppSummaryRepo)GetSummariesInCurrentSpace() (apps []cf.Applicati
 	return
 }
 
+func (repo *FakeAppSummaryRepo)GetSummariesInSpace(space cf.Space) (apps []cf.Application, apiResponse net.ApiResponse) {
+	apps = repo.GetSummariesInSpaceApps[space.Guid]
+	return
+}
+
 func (repo *FakeAppSummaryRepo)GetSummary(app cf.Application) (summary cf.AppSummary, apiResponse net.ApiResponse) {
 	repo.GetSummaryApp= app
+	repo.GetSummaryCalls++
 	summary = repo.GetSummarySummary
+	errorCode := repo.GetSummaryErrorCode
+
+	if repo.GetSummaryResponses != nil {
+		summary = repo.GetSummaryResponses[0]
+		repo.GetSummaryResponses = repo.GetSummaryResponses[1:]
+		errorCode = repo.GetSummaryErrorCodes[0]
+		repo.GetSummaryErrorCodes = repo.GetSummaryErrorCodes[1:]
+	}
 
-	if repo.GetSummaryErrorCode != "" {
-		apiResponse = net.NewApiResponse("Error", repo.GetSummaryErrorCode, http.StatusBadRequest)
+	if errorCode != "" {
+		apiResponse = net.NewApiResponse("Error", errorCode, http.StatusBadRequest)
 	}
 
 	return
@@ -9,22 +9,43 @@ import (
 type FakeAuthenticationRepository struct {
 	ConfigRepo testconfig.FakeConfigRepository
 
-	Config *configuration.Configuration
-	Email string
-	Password string
+	Config       *configuration.Configuration
+	Email        string
+	Password     string
+	Passcode     string
+	ClientId     string
+	ClientSecret string
 
-	AuthError bool
-	AccessToken string
+	AuthError    bool
+	AccessToken  string
 	RefreshToken string
+
+	RefreshTokenCalled bool
+	RefreshTokenError  bool
 }
 
 func (auth *FakeAuthenticationRepository) Authenticate(email string, password string) (apiResponse net.ApiResponse) {
-	auth.Config, _ = auth.ConfigRepo.Get()
 	auth.Email = email
 	auth.Password = password
+	return auth.saveTokens()
+}
+
+func (auth *FakeAuthenticationRepository) AuthenticateWithPasscode(passcode string) (apiResponse net.ApiResponse) {
+	auth.Passcode = passcode
+	return auth.saveTokens()
+}
+
+func (auth *FakeAuthenticationRepository) AuthenticateWithClientCredentials(clientId, clientSecret string) (apiResponse net.ApiResponse) {
+	auth.ClientId = clientId
+	auth.ClientSecret = clientSecret
+	return auth.saveTokens()
+}
+
+func (auth *FakeAuthenticationRepository) saveTokens() (apiResponse net.ApiResponse) {
+	auth.Config, _ = auth.ConfigRepo.Get()
 
 	if auth.AuthError {
-		apiResponse =  net.NewApiResponseWithMessage("Error authenticating.")
+		apiResponse = net.NewApiResponseWithMessage("Error authenticating.")
 		return
 	}
 
@@ -40,5 +61,13 @@ func (auth *FakeAuthenticationRepository) Authenticate(email string, password st
 }
 
 func (auth *FakeAuthenticationRepository) RefreshAuthToken() (updatedToken string, apiResponse net.ApiResponse) {
+	auth.RefreshTokenCalled = true
+
+	if auth.RefreshTokenError {
+		apiResponse = net.NewApiResponseWithMessage("Error refreshing auth token.")
+		return
+	}
+
+	updatedToken = auth.AccessToken
 	return
 }
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"cf/net"
+)
+
+type FakeCurlRepository struct {
+	Method  string
+	Path    string
+	Headers string
+	Body    string
+
+	ResponseHeaders string
+	ResponseBody    string
+	Error           bool
+}
+
+func (repo *FakeCurlRepository) Request(method, path, headers, body string) (resHeaders, resBody string, apiResponse net.ApiResponse) {
+	repo.Method = method
+	repo.Path = path
+	repo.Headers = headers
+	repo.Body = body
+
+	if repo.Error {
+		apiResponse = net.NewApiResponse("Server error, status code: 404, error code: 10000, message: Unknown request", "10000", 404)
+		return
+	}
+
+	resHeaders = repo.ResponseHeaders
+	resBody = repo.ResponseBody
+	return
+}
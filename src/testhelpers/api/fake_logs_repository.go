@@ -9,6 +9,7 @@ import (
 
 type FakeLogsRepository struct {
 	AppLogged cf.Application
+	AppsLogged []cf.Application
 	RecentLogs []logmessage.LogMessage
 	TailLogMessages []logmessage.LogMessage
 	TailLogStopCalled bool
@@ -26,6 +27,16 @@ func (l *FakeLogsRepository) TailLogsFor(app cf.Application, onConnect func(), l
 	return
 }
 
+func (l *FakeLogsRepository) RecentLogsForApps(apps []cf.Application, onConnect func(), logChan chan *logmessage.Message) (err error){
+	l.AppsLogged = apps
+	return l.RecentLogsFor(apps[0], onConnect, logChan)
+}
+
+func (l *FakeLogsRepository) TailLogsForApps(apps []cf.Application, onConnect func(), logChan chan *logmessage.Message, stopLoggingChan chan bool,  printInterval time.Duration) (err error){
+	l.AppsLogged = apps
+	return l.TailLogsFor(apps[0], onConnect, logChan, stopLoggingChan, printInterval)
+}
+
 func (l *FakeLogsRepository) logsFor(app cf.Application, logMessages []logmessage.LogMessage, onConnect func(), logChan chan *logmessage.Message, stopLoggingChan chan bool) {
 	l.AppLogged = app
 	onConnect()
@@ -30,6 +30,15 @@ type FakeOrgRepository struct {
 	UpdateQuotaQuota cf.Quota
 }
 
+func (repo FakeOrgRepository) ListOrgs(cb func(cf.Organization) bool) (apiResponse net.ApiResponse) {
+	for _, org := range repo.Organizations {
+		if !cb(org) {
+			break
+		}
+	}
+	return
+}
+
 func (repo FakeOrgRepository) FindAll() (orgs []cf.Organization, apiResponse net.ApiResponse) {
 	orgs = repo.Organizations
 	return
@@ -3,6 +3,7 @@ package api
 import (
 	"cf"
 	"cf/net"
+	"net/http"
 )
 
 type FakeQuotaRepository struct {
@@ -11,10 +12,20 @@ type FakeQuotaRepository struct {
 	FindByNameNotFound bool
 	FindByNameErr      bool
 
+	FindByGuidGuid  string
+	FindByGuidQuota cf.Quota
+
 	FindAllQuotas []cf.Quota
 
-	UpdateOrg   cf.Organization
+	CreateQuota     cf.Quota
+	CreateErrorCode string
+
 	UpdateQuota cf.Quota
+
+	DeleteQuota cf.Quota
+
+	AssignQuotaToOrgOrg   cf.Organization
+	AssignQuotaToOrgQuota cf.Quota
 }
 
 func (repo *FakeQuotaRepository) FindByName(name string) (quota cf.Quota, apiResponse net.ApiResponse) {
@@ -31,12 +42,37 @@ func (repo *FakeQuotaRepository) FindByName(name string) (quota cf.Quota, apiRes
 	return
 }
 
-func (repo *FakeQuotaRepository) Update(org cf.Organization, quota cf.Quota) (apiResponse net.ApiResponse) {
-	repo.UpdateOrg = org
+func (repo *FakeQuotaRepository) FindByGuid(guid string) (quota cf.Quota, apiResponse net.ApiResponse) {
+	repo.FindByGuidGuid = guid
+	quota = repo.FindByGuidQuota
+	return
+}
+
+func (repo *FakeQuotaRepository) Create(quota cf.Quota) (apiResponse net.ApiResponse) {
+	repo.CreateQuota = quota
+
+	if repo.CreateErrorCode != "" {
+		apiResponse = net.NewApiResponse("Error creating quota", repo.CreateErrorCode, http.StatusBadRequest)
+	}
+	return
+}
+
+func (repo *FakeQuotaRepository) Update(quota cf.Quota) (apiResponse net.ApiResponse) {
 	repo.UpdateQuota = quota
 	return
 }
 
+func (repo *FakeQuotaRepository) Delete(quota cf.Quota) (apiResponse net.ApiResponse) {
+	repo.DeleteQuota = quota
+	return
+}
+
+func (repo *FakeQuotaRepository) AssignQuotaToOrg(org cf.Organization, quota cf.Quota) (apiResponse net.ApiResponse) {
+	repo.AssignQuotaToOrgOrg = org
+	repo.AssignQuotaToOrgQuota = quota
+	return
+}
+
 func (repo *FakeQuotaRepository) FindAll() (quotas []cf.Quota, apiResponse net.ApiResponse) {
 	quotas = repo.FindAllQuotas
 
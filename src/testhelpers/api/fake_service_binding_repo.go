@@ -10,15 +10,21 @@ type FakeServiceBindingRepo struct {
 	CreateServiceInstance cf.ServiceInstance
 	CreateApplication cf.Application
 	CreateErrorCode string
+	CreatedBindings []string
 
 	DeleteServiceInstance cf.ServiceInstance
 	DeleteApplication cf.Application
 	DeleteBindingNotFound bool
+	DeletedBindings []string
+
+	ListForInstanceServiceInstance cf.ServiceInstance
+	ListForInstanceBindings []cf.ServiceBinding
 }
 
 func (repo *FakeServiceBindingRepo) Create(instance cf.ServiceInstance, app cf.Application) (apiResponse net.ApiResponse) {
 	repo.CreateServiceInstance = instance
 	repo.CreateApplication = app
+	repo.CreatedBindings = append(repo.CreatedBindings, app.Name+":"+instance.Name)
 
 	if repo.CreateErrorCode != "" {
 		apiResponse = net.NewApiResponse("Error binding service", repo.CreateErrorCode, http.StatusBadRequest)
@@ -30,6 +36,13 @@ func (repo *FakeServiceBindingRepo) Create(instance cf.ServiceInstance, app cf.A
 func (repo *FakeServiceBindingRepo) Delete(instance cf.ServiceInstance, app cf.Application) (found bool, apiResponse net.ApiResponse) {
 	repo.DeleteServiceInstance = instance
 	repo.DeleteApplication = app
+	repo.DeletedBindings = append(repo.DeletedBindings, app.Name+":"+instance.Name)
 	found = !repo.DeleteBindingNotFound
 	return
 }
+
+func (repo *FakeServiceBindingRepo) ListForInstance(instance cf.ServiceInstance) (bindings []cf.ServiceBinding, apiResponse net.ApiResponse) {
+	repo.ListForInstanceServiceInstance = instance
+	bindings = repo.ListForInstanceBindings
+	return
+}
@@ -10,8 +10,16 @@ type FakeServiceRepo struct {
 
 	CreateServiceInstanceName string
 	CreateServiceInstancePlan cf.ServicePlan
+	CreateServiceInstanceParams map[string]interface{}
 	CreateServiceAlreadyExists bool
 
+	UpdateServiceInstanceServiceInstance cf.ServiceInstance
+	UpdateServiceInstancePlan cf.ServicePlan
+	UpdateServiceInstanceParams map[string]interface{}
+
+	LastOperations []cf.LastOperation
+	LastOperationErr bool
+
 	CreateUserProvidedServiceInstanceName string
 	CreateUserProvidedServiceInstanceParameters map[string]string
 
@@ -22,6 +30,7 @@ type FakeServiceRepo struct {
 	FindInstanceByNameServiceInstance cf.ServiceInstance
 	FindInstanceByNameErr bool
 	FindInstanceByNameNotFound bool
+	FindInstanceByNameInstances map[string]cf.ServiceInstance
 
 	DeleteServiceServiceInstance cf.ServiceInstance
 
@@ -34,11 +43,33 @@ func (repo *FakeServiceRepo) GetServiceOfferings() (offerings []cf.ServiceOfferi
 	return
 }
 
-func (repo *FakeServiceRepo) CreateServiceInstance(name string, plan cf.ServicePlan) (identicalAlreadyExists bool, apiResponse net.ApiResponse) {
+func (repo *FakeServiceRepo) CreateServiceInstance(name string, plan cf.ServicePlan, params map[string]interface{}, onProgress func(cf.LastOperation)) (identicalAlreadyExists bool, apiResponse net.ApiResponse) {
 	repo.CreateServiceInstanceName = name
 	repo.CreateServiceInstancePlan = plan
+	repo.CreateServiceInstanceParams = params
 	identicalAlreadyExists = repo.CreateServiceAlreadyExists
 
+	apiResponse = repo.reportLastOperations(onProgress)
+	return
+}
+
+func (repo *FakeServiceRepo) UpdateServiceInstance(instance cf.ServiceInstance, plan cf.ServicePlan, params map[string]interface{}, onProgress func(cf.LastOperation)) (apiResponse net.ApiResponse) {
+	repo.UpdateServiceInstanceServiceInstance = instance
+	repo.UpdateServiceInstancePlan = plan
+	repo.UpdateServiceInstanceParams = params
+
+	apiResponse = repo.reportLastOperations(onProgress)
+	return
+}
+
+func (repo *FakeServiceRepo) reportLastOperations(onProgress func(cf.LastOperation)) (apiResponse net.ApiResponse) {
+	for _, lastOperation := range repo.LastOperations {
+		onProgress(lastOperation)
+	}
+
+	if repo.LastOperationErr {
+		apiResponse = net.NewApiResponseWithMessage("Service broker failed")
+	}
 	return
 }
 
@@ -58,6 +89,15 @@ func (repo *FakeServiceRepo) FindInstanceByName(name string) (instance cf.Servic
 	repo.FindInstanceByNameName = name
 	instance = repo.FindInstanceByNameServiceInstance
 
+	if repo.FindInstanceByNameInstances != nil {
+		var found bool
+		instance, found = repo.FindInstanceByNameInstances[name]
+		if !found {
+			apiResponse = net.NewNotFoundApiResponse("%s %s not found","Service instance", name)
+		}
+		return
+	}
+
 	if repo.FindInstanceByNameErr {
 		apiResponse = net.NewApiResponseWithMessage("Error finding instance")
 	}
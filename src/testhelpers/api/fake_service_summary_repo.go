@@ -7,9 +7,20 @@ import (
 
 type FakeServiceSummaryRepo struct{
 	GetSummariesInCurrentSpaceInstances []cf.ServiceInstance
+	GetSummariesInCurrentSpaceErr bool
+	GetSummariesInSpaceInstances map[string][]cf.ServiceInstance
 }
 
 func (repo *FakeServiceSummaryRepo)GetSummariesInCurrentSpace() (instances []cf.ServiceInstance, apiResponse net.ApiResponse) {
 	instances = repo.GetSummariesInCurrentSpaceInstances
+
+	if repo.GetSummariesInCurrentSpaceErr {
+		apiResponse = net.NewApiResponseWithMessage("Error getting service summaries")
+	}
+	return
+}
+
+func (repo *FakeServiceSummaryRepo)GetSummariesInSpace(space cf.Space) (instances []cf.ServiceInstance, apiResponse net.ApiResponse) {
+	instances = repo.GetSummariesInSpaceInstances[space.Guid]
 	return
 }
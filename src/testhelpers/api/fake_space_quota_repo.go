@@ -0,0 +1,47 @@
+package api
+
+import (
+	"cf"
+	"cf/net"
+)
+
+type FakeSpaceQuotaRepository struct {
+	FindByNameName     string
+	FindByNameQuota    cf.SpaceQuota
+	FindByNameNotFound bool
+
+	FindByGuidGuid  string
+	FindByGuidQuota cf.SpaceQuota
+
+	CreateQuota cf.SpaceQuota
+
+	AssignQuotaToSpaceSpace cf.Space
+	AssignQuotaToSpaceQuota cf.SpaceQuota
+}
+
+func (repo *FakeSpaceQuotaRepository) FindByName(name string) (quota cf.SpaceQuota, apiResponse net.ApiResponse) {
+	repo.FindByNameName = name
+	quota = repo.FindByNameQuota
+
+	if repo.FindByNameNotFound {
+		apiResponse = net.NewNotFoundApiResponse("%s %s not found", "Space quota", name)
+	}
+	return
+}
+
+func (repo *FakeSpaceQuotaRepository) FindByGuid(guid string) (quota cf.SpaceQuota, apiResponse net.ApiResponse) {
+	repo.FindByGuidGuid = guid
+	quota = repo.FindByGuidQuota
+	return
+}
+
+func (repo *FakeSpaceQuotaRepository) Create(quota cf.SpaceQuota) (apiResponse net.ApiResponse) {
+	repo.CreateQuota = quota
+	return
+}
+
+func (repo *FakeSpaceQuotaRepository) AssignQuotaToSpace(space cf.Space, quota cf.SpaceQuota) (apiResponse net.ApiResponse) {
+	repo.AssignQuotaToSpaceSpace = space
+	repo.AssignQuotaToSpaceQuota = quota
+	return
+}
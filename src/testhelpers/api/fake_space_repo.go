@@ -34,6 +34,15 @@ func (repo FakeSpaceRepository) GetCurrentSpace() (space cf.Space) {
 	return repo.CurrentSpace
 }
 
+func (repo FakeSpaceRepository) ListSpaces(cb func(cf.Space) bool) (apiResponse net.ApiResponse) {
+	for _, space := range repo.Spaces {
+		if !cb(space) {
+			break
+		}
+	}
+	return
+}
+
 func (repo FakeSpaceRepository) FindAll() (spaces []cf.Space, apiResponse net.ApiResponse) {
 	spaces = repo.Spaces
 	return
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"cf/commands"
 	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
 )
 
 func NewContext(cmdName string, args []string) (*cli.Context) {
@@ -41,8 +42,8 @@ func NewContext(cmdName string, args []string) (*cli.Context) {
 func findCommand(cmdName string) (cmd cli.Command) {
 	cmdFactory := commands.ConcreteFactory{}
 	reqFactory := &testreq.FakeReqFactory{}
-	cmdRunner := commands.NewRunner(cmdFactory, reqFactory)
-	myApp, _ := app.NewApp(cmdRunner)
+	cmdRunner := commands.NewRunner(&testterm.FakeUI{}, cmdFactory, reqFactory)
+	myApp, _ := app.NewApp(cmdRunner, nil)
 
 	for _, cmd := range myApp.Commands {
 		if cmd.Name == cmdName {
@@ -5,10 +5,12 @@ import (
 )
 
 type FakeAppRestarter struct {
-	AppToRestart cf.Application
+	AppToRestart  cf.Application
+	RestartedApps []cf.Application
 }
 
 func (restarter *FakeAppRestarter) ApplicationRestart(appToRestart cf.Application) {
 	restarter.AppToRestart = appToRestart
+	restarter.RestartedApps = append(restarter.RestartedApps, appToRestart)
 	return
 }
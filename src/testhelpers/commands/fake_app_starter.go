@@ -2,15 +2,21 @@ package commands
 
 import (
 	"cf"
+	"errors"
 )
 
 type FakeAppStarter struct {
 	AppToStart cf.Application
 	StartedApp cf.Application
+	StartErr   bool
 }
 
 func (starter *FakeAppStarter) ApplicationStart(appToStart cf.Application) (startedApp cf.Application, err error) {
 	starter.AppToStart = appToStart
 	startedApp = starter.StartedApp
+
+	if starter.StartErr {
+		err = errors.New("Error starting application")
+	}
 	return
 }
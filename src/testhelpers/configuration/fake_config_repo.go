@@ -72,6 +72,48 @@ func (repo FakeConfigRepository) ClearSession() (err error) {
 	return nil
 }
 
+func (repo FakeConfigRepository) ListProfiles() (names []string, err error) {
+	c, _ := repo.Get()
+	names = c.ProfileNames()
+	return
+}
+
+func (repo FakeConfigRepository) SwitchProfile(name string) (err error) {
+	c, _ := repo.Get()
+	c.SwitchProfile(name)
+	return repo.Save()
+}
+
+func (repo FakeConfigRepository) RenameProfile(name, newName string) (err error) {
+	c, _ := repo.Get()
+	err = c.RenameProfile(name, newName)
+	if err != nil {
+		return
+	}
+	return repo.Save()
+}
+
+func (repo FakeConfigRepository) DeleteProfile(name string) (err error) {
+	c, _ := repo.Get()
+	err = c.DeleteProfile(name)
+	if err != nil {
+		return
+	}
+	return repo.Save()
+}
+
+func (repo FakeConfigRepository) SetAlias(name, command string) (err error) {
+	c, _ := repo.Get()
+	c.SetAlias(name, command)
+	return repo.Save()
+}
+
+func (repo FakeConfigRepository) DeleteAlias(name string) (err error) {
+	c, _ := repo.Get()
+	c.DeleteAlias(name)
+	return repo.Save()
+}
+
 func (repo FakeConfigRepository) Login() (c *configuration.Configuration) {
 	c, _ = repo.Get()
 	c.AccessToken = `BEARER eyJhbGciOiJSUzI1NiJ9.eyJqdGkiOiJjNDE4OTllNS1kZTE1LTQ5NGQtYWFiNC04ZmNlYzUxN2UwMDUiLCJzdWIiOiI3NzJkZGEzZi02NjlmLTQyNzYtYjJiZC05MDQ4NmFiZTFmNmYiLCJzY29wZSI6WyJjbG91ZF9jb250cm9sbGVyLnJlYWQiLCJjbG91ZF9jb250cm9sbGVyLndyaXRlIiwib3BlbmlkIiwicGFzc3dvcmQud3JpdGUiXSwiY2xpZW50X2lkIjoiY2YiLCJjaWQiOiJjZiIsImdyYW50X3R5cGUiOiJwYXNzd29yZCIsInVzZXJfaWQiOiI3NzJkZGEzZi02NjlmLTQyNzYtYjJiZC05MDQ4NmFiZTFmNmYiLCJ1c2VyX25hbWUiOiJ1c2VyMUBleGFtcGxlLmNvbSIsImVtYWlsIjoidXNlcjFAZXhhbXBsZS5jb20iLCJpYXQiOjEzNzcwMjgzNTYsImV4cCI6MTM3NzAzNTU1NiwiaXNzIjoiaHR0cHM6Ly91YWEuYXJib3JnbGVuLmNmLWFwcC5jb20vb2F1dGgvdG9rZW4iLCJhdWQiOlsib3BlbmlkIiwiY2xvdWRfY29udHJvbGxlciIsInBhc3N3b3JkIl19.kjFJHi0Qir9kfqi2eyhHy6kdewhicAFu8hrPR1a5AxFvxGB45slKEjuP0_72cM_vEYICgZn3PcUUkHU9wghJO9wjZ6kiIKK1h5f2K9g-Iprv9BbTOWUODu1HoLIvg2TtGsINxcRYy_8LW1RtvQc1b4dBPoopaEH4no-BIzp0E5E`
@@ -0,0 +1,23 @@
+package manifest
+
+import (
+	"cf/manifest"
+	"os"
+)
+
+type FakeManifestRepository struct {
+	ReadManifestPath     string
+	ReadManifestManifest *manifest.Manifest
+	ReadManifestErr      error
+}
+
+func (repo *FakeManifestRepository) ReadManifest(path string) (m *manifest.Manifest, err error) {
+	repo.ReadManifestPath = path
+	m = repo.ReadManifestManifest
+	err = repo.ReadManifestErr
+
+	if m == nil && err == nil {
+		err = os.ErrNotExist
+	}
+	return
+}
@@ -0,0 +1,78 @@
+package plugin
+
+import (
+	"cf/plugin"
+	"fmt"
+)
+
+type FakePluginRepository struct {
+	Plugins []plugin.Plugin
+
+	ReadMetadataExecutable string
+	ReadMetadataPlugin     plugin.Plugin
+	ReadMetadataErr        error
+
+	InstalledPlugin plugin.Plugin
+	InstallErr      error
+
+	UninstalledName string
+
+	RunPlugin      plugin.Plugin
+	RunCommandName string
+	RunArgs        []string
+	RunContext     plugin.Context
+	RunErr         error
+}
+
+func (repo *FakePluginRepository) List() (plugins []plugin.Plugin, err error) {
+	plugins = repo.Plugins
+	return
+}
+
+func (repo *FakePluginRepository) FindByCommandName(commandName string) (installedPlugin plugin.Plugin, found bool, err error) {
+	for _, installedPlugin = range repo.Plugins {
+		_, found = installedPlugin.FindCommand(commandName)
+		if found {
+			return
+		}
+	}
+	installedPlugin = plugin.Plugin{}
+	return
+}
+
+func (repo *FakePluginRepository) ReadMetadata(executable string) (metadata plugin.Plugin, err error) {
+	repo.ReadMetadataExecutable = executable
+	metadata = repo.ReadMetadataPlugin
+	err = repo.ReadMetadataErr
+	return
+}
+
+func (repo *FakePluginRepository) Install(newPlugin plugin.Plugin) (err error) {
+	repo.InstalledPlugin = newPlugin
+	err = repo.InstallErr
+	if err == nil {
+		repo.Plugins = append(repo.Plugins, newPlugin)
+	}
+	return
+}
+
+func (repo *FakePluginRepository) Uninstall(name string) (err error) {
+	repo.UninstalledName = name
+	for index, installedPlugin := range repo.Plugins {
+		if installedPlugin.Name == name {
+			repo.Plugins = append(repo.Plugins[:index], repo.Plugins[index+1:]...)
+			return
+		}
+	}
+	err = fmt.Errorf("Plugin %s is not installed.", name)
+	return
+}
+
+func (repo *FakePluginRepository) Run(installedPlugin plugin.Plugin, commandName string, args []string, context plugin.Context) (err error) {
+	repo.RunPlugin = installedPlugin
+	repo.RunCommandName = commandName
+	repo.RunArgs = args
+	repo.RunContext = context
+	err = repo.RunErr
+	return
+}
@@ -15,6 +15,11 @@ type FakeUI struct {
 	Inputs  []string
 	FailedWithUsage bool
 	ShowConfigurationCalled bool
+	ProgressReports []int64
+	OutputFormat string
+	Records interface{}
+	ScreenClears int
+	WaitDurations []time.Duration
 }
 
 func (ui *FakeUI) Say(message string, args ...interface{}) {
@@ -83,11 +88,19 @@ func (ui *FakeUI) ShowConfiguration(config *configuration.Configuration) {
 	ui.ShowConfigurationCalled = true
 }
 
+func (ui *FakeUI) ShowProgress(sent, total int64, elapsed time.Duration) {
+	ui.ProgressReports = append(ui.ProgressReports, sent)
+}
+
 func (ui FakeUI) LoadingIndication() {
 }
 
-func (c FakeUI) Wait(duration time.Duration) {
-	time.Sleep(duration)
+func (ui *FakeUI) Wait(duration time.Duration) {
+	ui.WaitDurations = append(ui.WaitDurations, duration)
+}
+
+func (ui *FakeUI) ClearScreen() {
+	ui.ScreenClears++
 }
 
 func (ui *FakeUI) showBaseConfig(config *configuration.Configuration) {
@@ -104,3 +117,13 @@ func (ui *FakeUI) DisplayTable(table [][]string) {
 		ui.Say("%s",output)
 	}
 }
+
+func (ui *FakeUI) DisplayRecords(table [][]string, records interface{}) {
+	ui.Records = records
+	ui.DisplayTable(table)
+}
+
+func (ui *FakeUI) SetOutputFormat(format string) (err error) {
+	ui.OutputFormat = format
+	return
+}